@@ -1,6 +1,7 @@
 package graphqlerrors
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/chris-ramon/graphql-go/language/location"
 )
@@ -8,12 +9,46 @@ import (
 type GraphQLFormattedError struct {
 	Message   string
 	Locations []location.SourceLocation
+	// Response path of the field which raised the error, made of response
+	// names and list indices.
+	Path         []interface{}
+	ArgumentPath []string
+	// Error raised by a resolve function, not reported in responses, see
+	// GraphQLError.OriginalError.
+	OriginalError error
 }
 
 func (g GraphQLFormattedError) Error() string {
 	return g.Message
 }
 
+// Unwrap returns the original error, so that errors.Is and errors.As match
+// against it.
+func (g GraphQLFormattedError) Unwrap() error {
+	return g.OriginalError
+}
+
+// Encodes the error as specified by the GraphQL response format, additional
+// information is reported under `extensions`.
+func (g GraphQLFormattedError) MarshalJSON() ([]byte, error) {
+	formatted := struct {
+		Message    string                    `json:"message"`
+		Locations  []location.SourceLocation `json:"locations,omitempty"`
+		Path       []interface{}             `json:"path,omitempty"`
+		Extensions map[string]interface{}    `json:"extensions,omitempty"`
+	}{
+		Message:   g.Message,
+		Locations: g.Locations,
+		Path:      g.Path,
+	}
+	if len(g.ArgumentPath) > 0 {
+		formatted.Extensions = map[string]interface{}{
+			"argumentPath": g.ArgumentPath,
+		}
+	}
+	return json.Marshal(formatted)
+}
+
 func NewGraphQLFormattedError(message string) GraphQLFormattedError {
 	err := errors.New(message)
 	return FormatError(err)
@@ -25,13 +60,19 @@ func FormatError(err error) GraphQLFormattedError {
 		return err
 	case *GraphQLError:
 		return GraphQLFormattedError{
-			Message:   err.Error(),
-			Locations: err.Locations,
+			Message:       err.Error(),
+			Locations:     err.Locations,
+			Path:          err.Path,
+			ArgumentPath:  err.ArgumentPath,
+			OriginalError: err.OriginalError,
 		}
 	case GraphQLError:
 		return GraphQLFormattedError{
-			Message:   err.Error(),
-			Locations: err.Locations,
+			Message:       err.Error(),
+			Locations:     err.Locations,
+			Path:          err.Path,
+			ArgumentPath:  err.ArgumentPath,
+			OriginalError: err.OriginalError,
 		}
 	default:
 		return GraphQLFormattedError{
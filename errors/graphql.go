@@ -14,6 +14,13 @@ type GraphQLError struct {
 	Source    *source.Source
 	Positions []int
 	Locations []location.SourceLocation
+	// Path to the offending value within an argument, e.g. ["input", "author", "id"]
+	ArgumentPath []string
+	// Response path of the field which raised the error.
+	Path []interface{}
+	// Error raised by a resolve function, e.g. to be logged while a
+	// sanitized message is returned to clients.
+	OriginalError error
 }
 
 // implements Golang's built-in `error` interface
@@ -21,6 +28,12 @@ func (g GraphQLError) Error() string {
 	return fmt.Sprintf("%v", g.Message)
 }
 
+// Unwrap returns the original error, so that errors.Is and errors.As match
+// against it.
+func (g GraphQLError) Unwrap() error {
+	return g.OriginalError
+}
+
 func NewGraphQLError(message string, nodes []ast.Node, stack string, source *source.Source, positions []int) *GraphQLError {
 	if stack == "" && message != "" {
 		stack = message
@@ -34,19 +47,15 @@ func NewGraphQLError(message string, nodes []ast.Node, stack string, source *sou
 			break
 		}
 	}
+	locations := []location.SourceLocation{}
 	if len(positions) == 0 && len(nodes) > 0 {
-		for _, node := range nodes {
-			if node.GetLoc() == nil {
-				continue
-			}
-			positions = append(positions, node.GetLoc().Start)
+		positions = nodePositions(nodes)
+		locations = NodeLocations(nodes)
+	} else {
+		for _, pos := range positions {
+			locations = append(locations, sourceLocation(source, pos))
 		}
 	}
-	locations := []location.SourceLocation{}
-	for _, pos := range positions {
-		loc := location.GetLocation(source, pos)
-		locations = append(locations, loc)
-	}
 	return &GraphQLError{
 		Message:   message,
 		Stack:     stack,
@@ -56,3 +65,36 @@ func NewGraphQLError(message string, nodes []ast.Node, stack string, source *sou
 		Locations: locations,
 	}
 }
+
+// NodeLocations returns the locations of the nodes within their source, the
+// Locations of an error raised at the nodes.
+func NodeLocations(nodes []ast.Node) []location.SourceLocation {
+	locations := []location.SourceLocation{}
+	for _, node := range nodes {
+		if node.GetLoc() == nil {
+			continue
+		}
+		locations = append(locations, sourceLocation(node.GetLoc().Source, node.GetLoc().Start))
+	}
+	return locations
+}
+
+// Returns the location of a position within a source, which may be nil.
+func sourceLocation(s *source.Source, position int) location.SourceLocation {
+	body := ""
+	if s != nil {
+		body = s.Body
+	}
+	return location.GetLocation(body, position)
+}
+
+func nodePositions(nodes []ast.Node) []int {
+	positions := []int{}
+	for _, node := range nodes {
+		if node.GetLoc() == nil {
+			continue
+		}
+		positions = append(positions, node.GetLoc().Start)
+	}
+	return positions
+}
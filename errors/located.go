@@ -4,22 +4,32 @@ import (
 	"github.com/chris-ramon/graphql-go/language/ast"
 )
 
+// NewLocatedError locates the error at the nodes which raised it, keeping
+// it as the original error. An already located GraphQLError is returned as
+// is.
 func NewLocatedError(err interface{}, nodes []ast.Node) *GraphQLError {
+	if err, ok := err.(*GraphQLError); ok && len(err.Locations) > 0 {
+		return err
+	}
 	message := "An unknown error occurred."
+	var originalError error
 	if err, ok := err.(error); ok {
 		message = err.Error()
+		originalError = err
 	}
 	if err, ok := err.(string); ok {
 		message = err
 	}
 	stack := message
-	return NewGraphQLError(
+	locatedError := NewGraphQLError(
 		message,
 		nodes,
 		stack,
 		nil,
 		[]int{},
 	)
+	locatedError.OriginalError = originalError
+	return locatedError
 }
 
 func FieldASTsToNodeASTs(fieldASTs []*ast.Field) []ast.Node {
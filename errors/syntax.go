@@ -10,7 +10,7 @@ import (
 )
 
 func NewSyntaxError(s *source.Source, position int, description string) *GraphQLError {
-	l := location.GetLocation(s, position)
+	l := location.GetLocation(s.Body, position)
 	return NewGraphQLError(
 		fmt.Sprintf("Syntax Error %s (%d:%d) %s\n\n%s", s.Name, l.Line, l.Column, description, highlightSourceAtLocation(s, l)),
 		[]ast.Node{},
@@ -3,6 +3,7 @@ package executor_test
 import (
 	"github.com/chris-ramon/graphql-go"
 	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/executor"
 	"github.com/chris-ramon/graphql-go/language/location"
 	"github.com/chris-ramon/graphql-go/testutil"
 	"github.com/chris-ramon/graphql-go/types"
@@ -447,6 +448,7 @@ func TestResolveTypeOnInterfaceYieldsUsefulError(t *testing.T) {
 			graphqlerrors.GraphQLFormattedError{
 				Message:   `Runtime Object type "Human" is not a possible type for "Pet".`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"pets", 2},
 			},
 		},
 	}
@@ -605,6 +607,7 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 			graphqlerrors.GraphQLFormattedError{
 				Message:   `Runtime Object type "Human" is not a possible type for "Pet".`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"pets", 2},
 			},
 		},
 	}
@@ -623,3 +626,385 @@ func TestResolveTypeOnUnionYieldsUsefulError(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestIsTypeOfUsedToResolveRuntimeTypeForInterfaceImplementedThroughThunk(t *testing.T) {
+
+	// Pet is defined after its implementations, which refer to it through
+	// an interfaces thunk
+	var petType *types.GraphQLInterfaceType
+
+	dogType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Dog",
+		Interfaces: (types.GraphQLInterfacesThunk)(func() []*types.GraphQLInterfaceType {
+			return []*types.GraphQLInterfaceType{petType}
+		}),
+		IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
+			_, ok := value.(*testDog)
+			return ok
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testDog).Name
+				},
+			},
+			"woofs": &types.GraphQLFieldConfig{
+				Type: types.GraphQLBoolean,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testDog).Woofs
+				},
+			},
+		},
+	})
+	catType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Cat",
+		Interfaces: (types.GraphQLInterfacesThunk)(func() []*types.GraphQLInterfaceType {
+			return []*types.GraphQLInterfaceType{petType}
+		}),
+		IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
+			_, ok := value.(*testCat)
+			return ok
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testCat).Name
+				},
+			},
+			"meows": &types.GraphQLFieldConfig{
+				Type: types.GraphQLBoolean,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testCat).Meows
+				},
+			},
+		},
+	})
+	petType = types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Pet",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	ownerType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Owner",
+		Fields: types.GraphQLFieldConfigMap{
+			"pet": &types.GraphQLFieldConfig{
+				Type: petType,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"owners": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(ownerType),
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return []interface{}{
+							map[string]interface{}{"pet": &testDog{"Odie", true}},
+							map[string]interface{}{"pet": &testCat{"Garfield", false}},
+						}
+					},
+				},
+				"dog": &types.GraphQLFieldConfig{
+					Type: dogType,
+				},
+				"cat": &types.GraphQLFieldConfig{
+					Type: catType,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	query := `{
+      owners {
+        pet {
+          name
+          ... on Dog {
+            woofs
+          }
+          ... on Cat {
+            meows
+          }
+        }
+      }
+    }`
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"owners": []interface{}{
+				map[string]interface{}{
+					"pet": map[string]interface{}{
+						"name":  "Odie",
+						"woofs": bool(true),
+					},
+				},
+				map[string]interface{}{
+					"pet": map[string]interface{}{
+						"name":  "Garfield",
+						"meows": bool(false),
+					},
+				},
+			},
+		},
+		Errors: nil,
+	}
+
+	resultChannel := make(chan *types.GraphQLResult)
+	go gql.Graphql(gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: query,
+	}, resultChannel)
+	result := <-resultChannel
+	if len(result.Errors) != 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestIsTypeOfUsedToResolveRuntimeTypeForInterfaceReturnedByFieldTypeThunk(t *testing.T) {
+
+	// Owner and Pet refer to each other, Owner is defined first and refers to
+	// Pet through a field type thunk
+	var petType *types.GraphQLInterfaceType
+
+	ownerType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Owner",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"pets": &types.GraphQLFieldConfig{
+				Type: types.GraphQLOutputTypeThunk(func() types.GraphQLOutputType {
+					return types.NewGraphQLList(petType)
+				}),
+			},
+		},
+	})
+	petType = types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Pet",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"owner": &types.GraphQLFieldConfig{
+				Type: ownerType,
+			},
+		},
+	})
+	owner := map[string]interface{}{"name": "Jon"}
+	dogType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "Dog",
+		Interfaces: []*types.GraphQLInterfaceType{petType},
+		IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
+			_, ok := value.(*testDog)
+			return ok
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testDog).Name
+				},
+			},
+			"owner": &types.GraphQLFieldConfig{
+				Type: ownerType,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return owner
+				},
+			},
+			"woofs": &types.GraphQLFieldConfig{
+				Type: types.GraphQLBoolean,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testDog).Woofs
+				},
+			},
+		},
+	})
+	catType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "Cat",
+		Interfaces: []*types.GraphQLInterfaceType{petType},
+		IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
+			_, ok := value.(*testCat)
+			return ok
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testCat).Name
+				},
+			},
+			"owner": &types.GraphQLFieldConfig{
+				Type: ownerType,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return owner
+				},
+			},
+			"meows": &types.GraphQLFieldConfig{
+				Type: types.GraphQLBoolean,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testCat).Meows
+				},
+			},
+		},
+	})
+	owner["pets"] = []interface{}{&testDog{"Odie", true}, &testCat{"Garfield", false}}
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"owner": &types.GraphQLFieldConfig{
+					Type: ownerType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return owner
+					},
+				},
+			},
+		}),
+		Types: []types.GraphQLType{dogType, catType},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	if fieldType := ownerType.GetFields()["pets"].Type; fieldType.String() != "[Pet]" {
+		t.Fatalf("Expected the type of Owner.pets to be the one returned by the thunk, got: %v", fieldType)
+	}
+
+	query := `{
+      owner {
+        pets {
+          name
+          owner {
+            name
+          }
+          ... on Dog {
+            woofs
+          }
+          ... on Cat {
+            meows
+          }
+        }
+      }
+    }`
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"owner": map[string]interface{}{
+				"pets": []interface{}{
+					map[string]interface{}{
+						"name":  "Odie",
+						"owner": map[string]interface{}{"name": "Jon"},
+						"woofs": bool(true),
+					},
+					map[string]interface{}{
+						"name":  "Garfield",
+						"owner": map[string]interface{}{"name": "Jon"},
+						"meows": bool(false),
+					},
+				},
+			},
+		},
+	}
+
+	resultChannel := make(chan *types.GraphQLResult)
+	go gql.Graphql(gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: query,
+	}, resultChannel)
+	result := <-resultChannel
+	if len(result.Errors) != 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestInterfaceFieldsAreResolvedByTheFieldsOfTheRuntimeType(t *testing.T) {
+	nodeType := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Node",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLID),
+				// the fields of an interface are not resolved themselves
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return "interface"
+				},
+			},
+		},
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			if _, ok := value.(*testDog); ok {
+				return info.Schema.GetType("Dog").(*types.GraphQLObjectType)
+			}
+			return info.Schema.GetType("Human").(*types.GraphQLObjectType)
+		},
+	})
+	dogType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "Dog",
+		Interfaces: []*types.GraphQLInterfaceType{nodeType},
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLID),
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return "dog:" + p.Source.(*testDog).Name
+				},
+			},
+		},
+	})
+	humanType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "Human",
+		Interfaces: []*types.GraphQLInterfaceType{nodeType},
+		Fields: types.GraphQLFieldConfigMap{
+			// resolved by the default resolver
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLID),
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"nodes": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(nodeType),
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return []interface{}{
+							&testDog{Name: "Odie"},
+							map[string]interface{}{"id": "human:1"},
+						}
+					},
+				},
+			},
+		}),
+		Types: []types.GraphQLType{dogType, humanType},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{"id": "dog:Odie"},
+				map[string]interface{}{"id": "human:1"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ nodes { id } }`),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
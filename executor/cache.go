@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Cache stores the resolved values of the fields configured with a
+// types.GraphQLFieldCacheConfig across executions, see ExecuteOptions.Cache.
+// It must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value cached under key, if any.
+	Get(key string) (value interface{}, ok bool)
+	// Set caches the value under key for the given duration, zero meaning
+	// without expiration.
+	Set(key string, value interface{}, ttl time.Duration)
+}
+
+type inMemoryCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// InMemoryCache is a Cache keeping the values in memory until they expire.
+type InMemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]inMemoryCacheEntry
+}
+
+func NewInMemoryCache() *InMemoryCache {
+	return &InMemoryCache{
+		entries: map[string]inMemoryCacheEntry{},
+	}
+}
+
+func (c *InMemoryCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *InMemoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	entry := inMemoryCacheEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+}
+
+// Calls the resolve function of the field, unless the field is cached and
+// its value for the key of p is in the cache of the execution. The resolved
+// value is cached rather than its completed value, unless it is an error;
+// the value of a thunk is cached once resolved. The fields below the root
+// are only cached with a KeyFn, see types.GraphQLFieldCacheConfig.
+func resolveCached(eCtx *ExecutionContext, fieldDef *types.GraphQLFieldDefinition, resolveFn types.GraphQLFieldResolveFn, p types.GQLFRParams) interface{} {
+	cacheConfig := fieldDef.Cache
+	if cacheConfig == nil || eCtx.Cache == nil || (cacheConfig.KeyFn == nil && len(p.Info.Path) > 1) {
+		return resolveFn(p)
+	}
+	key := fieldCacheKey(cacheConfig, p)
+	if value, ok := eCtx.Cache.Get(key); ok {
+		return value
+	}
+	value := resolveFn(p)
+	if thunk, ok := value.(Thunk); ok {
+		return Thunk(func() interface{} {
+			value := thunk()
+			if _, ok := value.(error); !ok {
+				eCtx.Cache.Set(key, value, cacheConfig.TTL)
+			}
+			return value
+		})
+	}
+	if _, ok := value.(error); !ok {
+		eCtx.Cache.Set(key, value, cacheConfig.TTL)
+	}
+	return value
+}
+
+// Returns the key of the field's value, by default its JSON encoded
+// arguments.
+func fieldCacheKey(cacheConfig *types.GraphQLFieldCacheConfig, p types.GQLFRParams) string {
+	key := ""
+	if cacheConfig.KeyFn != nil {
+		key = cacheConfig.KeyFn(p)
+	} else if b, err := json.Marshal(p.Args); err == nil {
+		key = string(b)
+	} else {
+		key = fmt.Sprintf("%v", p.Args)
+	}
+	return fmt.Sprintf("%v.%v:%v", p.Info.ParentType, p.Info.FieldName, key)
+}
@@ -0,0 +1,208 @@
+package executor_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+type cacheTestArticle struct {
+	Id    string `json:"id"`
+	Title string `json:"title"`
+	// counts the resolver calls by id, the root value of the executions
+	resolved map[string]int
+}
+
+var cacheTestArticleType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Article",
+	Fields: types.GraphQLFieldConfigMap{
+		"id": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"title": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+	},
+})
+
+var cacheTestSchema types.GraphQLSchema
+
+func init() {
+	cacheTestArticleType.AddFieldConfig("related", &types.GraphQLFieldConfig{
+		Type: cacheTestArticleType,
+		Cache: &types.GraphQLFieldCacheConfig{
+			TTL: time.Minute,
+		},
+		Resolve: func(p types.GQLFRParams) interface{} {
+			article := p.Source.(*cacheTestArticle)
+			id := article.Id + "-related"
+			article.resolved[id]++
+			return &cacheTestArticle{Id: id, resolved: article.resolved}
+		},
+	})
+
+	cacheTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: cacheTestArticleType,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"id": &types.GraphQLArgumentConfig{
+							Type: types.GraphQLID,
+						},
+					},
+					Cache: &types.GraphQLFieldCacheConfig{
+						TTL: time.Minute,
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						resolved := p.Source.(map[string]int)
+						id := fmt.Sprintf("%v", p.Args["id"])
+						resolved[id]++
+						if id == "missing" {
+							return errors.New("Article not found.")
+						}
+						return &cacheTestArticle{
+							Id:       id,
+							Title:    fmt.Sprintf("My Article %v", id),
+							resolved: resolved,
+						}
+					},
+				},
+			},
+		}),
+	})
+}
+
+func TestCache_SkipsTheResolverOfACachedFieldAcrossExecutions(t *testing.T) {
+	resolved := map[string]int{}
+	cache := executor.NewInMemoryCache()
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": map[string]interface{}{
+				"id":    "1",
+				"title": "My Article 1",
+			},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		result := testutil.Execute(t, executor.ExecuteParams{
+			Schema: cacheTestSchema,
+			Root:   resolved,
+			AST:    testutil.Parse(t, `{ article(id: "1") { id, title } }`),
+			ExecuteOptions: executor.ExecuteOptions{
+				Cache: cache,
+			},
+		})
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+	// a cached value is completed against the selection set of the query
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: cacheTestSchema,
+		Root:   resolved,
+		AST:    testutil.Parse(t, `{ article(id: "1") { title }, other: article(id: "2") { id } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			Cache: cache,
+		},
+	})
+	expected = &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": map[string]interface{}{
+				"title": "My Article 1",
+			},
+			"other": map[string]interface{}{
+				"id": "2",
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	expectedResolved := map[string]int{"1": 1, "2": 1}
+	if !reflect.DeepEqual(expectedResolved, resolved) {
+		t.Fatalf("Unexpected resolver calls, Diff: %v", testutil.Diff(expectedResolved, resolved))
+	}
+}
+
+func TestCache_DoesNotCacheErrors(t *testing.T) {
+	resolved := map[string]int{}
+	cache := executor.NewInMemoryCache()
+	for i := 0; i < 2; i++ {
+		result := testutil.Execute(t, executor.ExecuteParams{
+			Schema: cacheTestSchema,
+			Root:   resolved,
+			AST:    testutil.Parse(t, `{ article(id: "missing") { id } }`),
+			ExecuteOptions: executor.ExecuteOptions{
+				Cache: cache,
+			},
+		})
+		if len(result.Errors) != 1 {
+			t.Fatalf("Expected an error, got: %v", result.Errors)
+		}
+	}
+	if resolved["missing"] != 2 {
+		t.Fatalf("Expected the resolver to be called twice, got: %v", resolved["missing"])
+	}
+}
+
+func TestCache_DoesNotCacheTheFieldsOfAnObjectWithoutAKeyFn(t *testing.T) {
+	resolved := map[string]int{}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"a": map[string]interface{}{
+				"related": map[string]interface{}{"id": "1-related"},
+			},
+			"b": map[string]interface{}{
+				"related": map[string]interface{}{"id": "2-related"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: cacheTestSchema,
+		Root:   resolved,
+		AST:    testutil.Parse(t, `{ a: article(id: "1") { related { id } } b: article(id: "2") { related { id } } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			Cache: executor.NewInMemoryCache(),
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestCache_ResolvesEachTimeWithoutACache(t *testing.T) {
+	resolved := map[string]int{}
+	for i := 0; i < 2; i++ {
+		testutil.Execute(t, executor.ExecuteParams{
+			Schema: cacheTestSchema,
+			Root:   resolved,
+			AST:    testutil.Parse(t, `{ article(id: "1") { id } }`),
+		})
+	}
+	if resolved["1"] != 2 {
+		t.Fatalf("Expected the resolver to be called twice, got: %v", resolved["1"])
+	}
+}
+
+func TestInMemoryCache_ExpiresValuesAfterTheirTTL(t *testing.T) {
+	cache := executor.NewInMemoryCache()
+	cache.Set("a", 1, time.Nanosecond)
+	cache.Set("b", 2, 0)
+	time.Sleep(time.Millisecond)
+	if _, ok := cache.Get("a"); ok {
+		t.Fatalf("Expected value of a to be expired")
+	}
+	if value, ok := cache.Get("b"); !ok || value != 2 {
+		t.Fatalf("Expected value of b to be cached, got: %v", value)
+	}
+}
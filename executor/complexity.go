@@ -0,0 +1,108 @@
+package executor
+
+import (
+	"fmt"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Estimates the cost of the operation of eCtx, returns an error if it exceeds
+// eCtx.MaxComplexity.
+func checkMaxComplexity(eCtx *ExecutionContext) error {
+	operationType := operationRootType(eCtx)
+	if operationType == nil {
+		// reported by the execution of the operation
+		return nil
+	}
+	fields := collectFields(CollectFieldsParams{
+		ExeContext:    eCtx,
+		OperationType: operationType,
+		SelectionSet:  eCtx.Operation.GetSelectionSet(),
+	})
+	eCtx.complexity = fieldsComplexity(eCtx, operationType, fields)
+	if eCtx.complexity > eCtx.MaxComplexity {
+		return graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+			"Operation has complexity %v, exceeding the maximum complexity of %v.", eCtx.complexity, eCtx.MaxComplexity))
+	}
+	return nil
+}
+
+// Returns the root type of the operation of eCtx, nil if the schema has none.
+func operationRootType(eCtx *ExecutionContext) *types.GraphQLObjectType {
+	switch eCtx.Operation.GetOperation() {
+	case "mutation":
+		return eCtx.Schema.GetMutationType()
+	case "subscription":
+		return eCtx.Schema.GetSubscriptionType()
+	}
+	return eCtx.Schema.GetQueryType()
+}
+
+// Sums the estimated cost of the given fields of the parent type.
+func fieldsComplexity(eCtx *ExecutionContext, parentType *types.GraphQLObjectType, fields map[string][]*ast.Field) int {
+	complexity := 0
+	for _, fieldASTs := range fields {
+		fieldName := ""
+		if fieldASTs[0].Name != nil {
+			fieldName = fieldASTs[0].Name.Value
+		}
+		fieldDef := getFieldDef(eCtx.Schema, parentType, fieldName)
+		if fieldDef == nil {
+			continue
+		}
+		childComplexity := 0
+		switch namedType := types.GetNamedType(fieldDef.Type).(type) {
+		case *types.GraphQLObjectType:
+			childComplexity = fieldsComplexity(eCtx, namedType, gatherSubFields(eCtx, namedType, fieldASTs).fields)
+		case types.GraphQLAbstractType:
+			// the runtime type is only known once resolved, so the cost is
+			// that of the most expensive possible type
+			for _, possibleType := range eCtx.Schema.GetPossibleTypes(namedType.(types.GraphQLType)) {
+				possibleComplexity := fieldsComplexity(eCtx, possibleType, gatherSubFields(eCtx, possibleType, fieldASTs).fields)
+				if possibleComplexity > childComplexity {
+					childComplexity = possibleComplexity
+				}
+			}
+		}
+		args, _ := getArgumentValues(fieldDef.Args, fieldASTs[0].Arguments, eCtx.VariableValues)
+		if fieldDef.Complexity != nil {
+			complexity = addComplexity(complexity, fieldDef.Complexity(args, childComplexity))
+		} else {
+			complexity = addComplexity(complexity, defaultComplexity(fieldDef, args, childComplexity))
+		}
+	}
+	return complexity
+}
+
+// Returns 1 plus the cost of the sub-fields, which are resolved for each item
+// of a list limited by a `first` or `limit` argument.
+func defaultComplexity(fieldDef *types.GraphQLFieldDefinition, args map[string]interface{}, childComplexity int) int {
+	if _, ok := types.GetNullableType(fieldDef.Type).(*types.GraphQLList); ok {
+		for _, argName := range []string{"first", "limit"} {
+			if count, ok := args[argName].(int); ok && count > 0 {
+				return addComplexity(1, multiplyComplexity(count, childComplexity))
+			}
+		}
+	}
+	return addComplexity(1, childComplexity)
+}
+
+// The complexity the sums and products of costs saturate at, rather than
+// overflow to a cost within the maximum, e.g. for nested `first` arguments.
+const maxComplexity = int(^uint(0) >> 1)
+
+func addComplexity(a int, b int) int {
+	if b > 0 && a > maxComplexity-b {
+		return maxComplexity
+	}
+	return a + b
+}
+
+func multiplyComplexity(a int, b int) int {
+	if a > 0 && b > 0 && a > maxComplexity/b {
+		return maxComplexity
+	}
+	return a * b
+}
@@ -0,0 +1,166 @@
+package executor_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+var complexityAuthorType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Author",
+	Fields: types.GraphQLFieldConfigMap{
+		"name": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+	},
+})
+
+var complexityArticleType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Article",
+	Fields: types.GraphQLFieldConfigMap{
+		"title": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"author": &types.GraphQLFieldConfig{
+			Type: complexityAuthorType,
+		},
+		"body": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+			Complexity: func(args map[string]interface{}, childComplexity int) int {
+				return 10
+			},
+		},
+	},
+})
+
+var complexityTestSchema types.GraphQLSchema
+
+func init() {
+	complexityArticleType.AddFieldConfig("related", &types.GraphQLFieldConfig{
+		Type: types.NewGraphQLList(complexityArticleType),
+		Args: types.GraphQLFieldConfigArgumentMap{
+			"first": &types.GraphQLArgumentConfig{
+				Type: types.GraphQLInt,
+			},
+		},
+	})
+
+	complexityTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"articles": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(complexityArticleType),
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"first": &types.GraphQLArgumentConfig{
+							Type: types.GraphQLInt,
+						},
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return []interface{}{
+							map[string]interface{}{
+								"title":  "GraphQL",
+								"body":   "Query languages.",
+								"author": map[string]interface{}{"name": "John"},
+							},
+						}
+					},
+				},
+			},
+		}),
+	})
+}
+
+func TestMaxComplexity_MultipliesTheCostOfTheItemsOfAPaginatedField(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"articles": []interface{}{
+				map[string]interface{}{
+					"title":  "GraphQL",
+					"author": map[string]interface{}{"name": "John"},
+				},
+			},
+		},
+		// articles: 1 + 10 * (title: 1 + author: (1 + name: 1))
+		Extensions: map[string]interface{}{
+			"complexity": 31,
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: complexityTestSchema,
+		AST:    testutil.Parse(t, `query Q($first: Int) { articles(first: $first) { title author { name } } }`),
+		Args:   map[string]interface{}{"first": 10},
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxComplexity: 100,
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMaxComplexity_RejectsOperationsExceedingTheMaximumBeforeResolving(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message:   "Operation has complexity 111, exceeding the maximum complexity of 100.",
+				Locations: []location.SourceLocation{},
+			},
+		},
+		// articles: 1 + 10 * (title: 1 + body: 10)
+		Extensions: map[string]interface{}{
+			"complexity": 111,
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: complexityTestSchema,
+		AST:    testutil.Parse(t, `{ articles(first: 10) { title body } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxComplexity: 100,
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMaxComplexity_SaturatesInsteadOfOverflowing(t *testing.T) {
+	// the product of the `first` arguments overflows an int64
+	query := `{
+      articles(first: 2147483647) {
+        related(first: 2147483647) {
+          related(first: 2147483647) {
+            title
+          }
+        }
+      }
+    }`
+	maxInt := int(^uint(0) >> 1)
+	expected := &types.GraphQLResult{
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message:   fmt.Sprintf("Operation has complexity %v, exceeding the maximum complexity of 100.", maxInt),
+				Locations: []location.SourceLocation{},
+			},
+		},
+		Extensions: map[string]interface{}{
+			"complexity": maxInt,
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: complexityTestSchema,
+		AST:    testutil.Parse(t, query),
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxComplexity: 100,
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -0,0 +1,162 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/ast"
+)
+
+// Returns an error located at the first field of the operation nested deeper
+// than maxDepth, with the path of the field. Fragments are expanded, a
+// fragment spread within itself is not expanded again. The depth of each
+// fragment is measured once, so that the fragments spread many times are
+// only walked again when they nest a field too deep.
+func checkMaxDepth(eCtx *ExecutionContext, maxDepth int, ignoreIntrospection bool) error {
+	c := &depthChecker{
+		eCtx:                eCtx,
+		maxDepth:            maxDepth,
+		ignoreIntrospection: ignoreIntrospection,
+		spreads:             map[string]bool{},
+		fragmentDepths:      map[string]int{},
+	}
+	return c.checkSelectionSet(eCtx.Operation.GetSelectionSet(), []interface{}{})
+}
+
+// Returns the maximum depth and complexity of the operation of eCtx, those
+// of the introspection operations if p exempts them and the operation
+// selects only introspection fields, e.g. `__schema` or `__typename`.
+func operationLimits(eCtx *ExecutionContext, p ExecuteParams) (maxDepth int, maxComplexity int) {
+	if p.ExemptIntrospectionOperations && isIntrospectionOperation(eCtx) {
+		return p.MaxIntrospectionDepth, p.MaxIntrospectionComplexity
+	}
+	return p.MaxDepth, p.MaxComplexity
+}
+
+func isIntrospectionOperation(eCtx *ExecutionContext) bool {
+	operationType := operationRootType(eCtx)
+	if operationType == nil {
+		return false
+	}
+	fields := collectFields(CollectFieldsParams{
+		ExeContext:    eCtx,
+		OperationType: operationType,
+		SelectionSet:  eCtx.Operation.GetSelectionSet(),
+	})
+	if len(fields) == 0 {
+		return false
+	}
+	for _, fieldASTs := range fields {
+		if fieldASTs[0].Name == nil || !strings.HasPrefix(fieldASTs[0].Name.Value, "__") {
+			return false
+		}
+	}
+	return true
+}
+
+type depthChecker struct {
+	eCtx                *ExecutionContext
+	maxDepth            int
+	ignoreIntrospection bool
+	// The fragments spread along the path being checked.
+	spreads map[string]bool
+	// The depth of the fields of each fragment measured so far.
+	fragmentDepths map[string]int
+}
+
+func (c *depthChecker) checkSelectionSet(selectionSet *ast.SelectionSet, path []interface{}) error {
+	if selectionSet == nil {
+		return nil
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if err := c.checkField(selection, path); err != nil {
+				return err
+			}
+		case *ast.InlineFragment:
+			if err := c.checkSelectionSet(selection.SelectionSet, path); err != nil {
+				return err
+			}
+		case *ast.FragmentSpread:
+			if selection.Name == nil {
+				continue
+			}
+			name := selection.Name.Value
+			fragment, ok := c.eCtx.Fragments[name].(*ast.FragmentDefinition)
+			if !ok || c.spreads[name] || len(path)+c.fragmentDepth(name) <= c.maxDepth {
+				continue
+			}
+			c.spreads[name] = true
+			err := c.checkSelectionSet(fragment.SelectionSet, path)
+			delete(c.spreads, name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *depthChecker) checkField(field *ast.Field, path []interface{}) error {
+	name := ""
+	if field.Name != nil {
+		name = field.Name.Value
+	}
+	if c.ignoreIntrospection && strings.HasPrefix(name, "__") {
+		return nil
+	}
+	fieldPath := append(append([]interface{}{}, path...), getFieldEntryKey(field))
+	if len(fieldPath) > c.maxDepth {
+		err := graphqlerrors.FormatError(graphqlerrors.NewLocatedError(
+			fmt.Sprintf("Exceeded maximum depth of %v.", c.maxDepth),
+			[]ast.Node{field},
+		))
+		return withPath(err, fieldPath)
+	}
+	return c.checkSelectionSet(field.SelectionSet, fieldPath)
+}
+
+// Returns the depth of the fields of a fragment, measured once. A fragment
+// spread within itself, rejected by the validation, adds no depth.
+func (c *depthChecker) fragmentDepth(name string) int {
+	if depth, ok := c.fragmentDepths[name]; ok {
+		return depth
+	}
+	fragment, ok := c.eCtx.Fragments[name].(*ast.FragmentDefinition)
+	if !ok {
+		return 0
+	}
+	c.fragmentDepths[name] = 0
+	depth := c.selectionSetDepth(fragment.SelectionSet)
+	c.fragmentDepths[name] = depth
+	return depth
+}
+
+func (c *depthChecker) selectionSetDepth(selectionSet *ast.SelectionSet) int {
+	if selectionSet == nil {
+		return 0
+	}
+	depth := 0
+	for _, selection := range selectionSet.Selections {
+		selectionDepth := 0
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if c.ignoreIntrospection && selection.Name != nil && strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			selectionDepth = 1 + c.selectionSetDepth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			selectionDepth = c.selectionSetDepth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if selection.Name != nil {
+				selectionDepth = c.fragmentDepth(selection.Name.Value)
+			}
+		}
+		if selectionDepth > depth {
+			depth = selectionDepth
+		}
+	}
+	return depth
+}
@@ -0,0 +1,210 @@
+package executor_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestMaxDepth_RejectsOperationsNestedDeeperThanTheMaximumBeforeResolving(t *testing.T) {
+	query := `
+      query Nested {
+        hero {
+          ...friendNames
+        }
+      }
+
+      fragment friendNames on Character {
+        friends {
+          pals: friends {
+            name
+          }
+        }
+      }
+	`
+	expected := &types.GraphQLResult{
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: "Exceeded maximum depth of 3.",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 11, Column: 13},
+				},
+				Path: []interface{}{"hero", "friends", "pals", "name"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: testutil.StarWarsSchema,
+		AST:    testutil.Parse(t, query),
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxDepth: 3,
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMaxDepth_ExecutesOperationsWithinTheMaximum(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"friends": []interface{}{
+					map[string]interface{}{"name": "Luke Skywalker"},
+					map[string]interface{}{"name": "Han Solo"},
+					map[string]interface{}{"name": "Leia Organa"},
+				},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: testutil.StarWarsSchema,
+		AST:    testutil.Parse(t, `{ hero { friends { name } } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxDepth: 3,
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMaxDepth_DoesNotExpandFragmentCyclesAgain(t *testing.T) {
+	query := `
+      query Cycle {
+        hero {
+          ...friends
+        }
+      }
+
+      fragment friends on Character {
+        name
+        friends {
+          ...friends
+        }
+      }
+	`
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: testutil.StarWarsSchema,
+		AST:    testutil.Parse(t, query),
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxDepth: 2,
+		},
+	})
+	if len(result.Errors) != 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestMaxDepth_ExemptsIntrospectionFieldsIfIgnored(t *testing.T) {
+	query := `{ __schema { queryType { fields { name } } } }`
+	tests := []struct {
+		ignoreIntrospection bool
+		errors              int
+	}{
+		{ignoreIntrospection: false, errors: 1},
+		{ignoreIntrospection: true, errors: 0},
+	}
+	for _, test := range tests {
+		result := testutil.Execute(t, executor.ExecuteParams{
+			Schema: testutil.StarWarsSchema,
+			AST:    testutil.Parse(t, query),
+			ExecuteOptions: executor.ExecuteOptions{
+				MaxDepth:                 2,
+				IgnoreIntrospectionDepth: test.ignoreIntrospection,
+			},
+		})
+		if len(result.Errors) != test.errors {
+			t.Fatalf("Expected %v errors ignoring introspection %v, got: %v", test.errors, test.ignoreIntrospection, result.Errors)
+		}
+	}
+}
+
+func TestMaxDepth_ChecksIntrospectionOperationsAgainstTheirOwnLimits(t *testing.T) {
+	tests := []struct {
+		query                 string
+		maxIntrospectionDepth int
+		errors                int
+	}{
+		{query: `{ __schema { queryType { fields { name } } } }`, errors: 0},
+		{query: `{ __schema { queryType { fields { name } } } }`, maxIntrospectionDepth: 3, errors: 1},
+		{query: `{ __typename __schema { queryType { fields { name } } } }`, errors: 0},
+		{query: `{ hero { friends { friends { name } } } }`, errors: 1},
+		{query: `{ __typename hero { friends { friends { name } } } }`, errors: 1},
+	}
+	for _, test := range tests {
+		result := testutil.Execute(t, executor.ExecuteParams{
+			Schema: testutil.StarWarsSchema,
+			AST:    testutil.Parse(t, test.query),
+			ExecuteOptions: executor.ExecuteOptions{
+				MaxDepth:                      3,
+				ExemptIntrospectionOperations: true,
+				MaxIntrospectionDepth:         test.maxIntrospectionDepth,
+			},
+		})
+		if len(result.Errors) != test.errors {
+			t.Fatalf("Expected %v errors for %q, got: %v", test.errors, test.query, result.Errors)
+		}
+	}
+}
+
+func TestMaxDepth_MeasuresFragmentsSpreadManyTimesOnce(t *testing.T) {
+	nodeType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Node",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{Type: types.GraphQLString},
+		},
+	})
+	nodeType.AddFieldConfig("child", &types.GraphQLFieldConfig{Type: nodeType})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"node": &types.GraphQLFieldConfig{
+					Type: nodeType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// Each fragment spreads the next one twice, a walk expanding every
+	// spread would visit 2^40 fields.
+	levels := 40
+	query := `{ node { ...f0 } }`
+	for i := 0; i < levels; i++ {
+		query += fmt.Sprintf(` fragment f%v on Node { a: child { ...f%v } b: child { ...f%v } }`, i, i+1, i+1)
+	}
+	query += fmt.Sprintf(` fragment f%v on Node { name }`, levels)
+
+	tests := []struct {
+		maxDepth int
+		errors   int
+	}{
+		{maxDepth: levels + 2, errors: 0},
+		{maxDepth: levels + 1, errors: 1},
+	}
+	for _, test := range tests {
+		result := testutil.Execute(t, executor.ExecuteParams{
+			Schema: schema,
+			AST:    testutil.Parse(t, query),
+			ExecuteOptions: executor.ExecuteOptions{
+				MaxDepth: test.maxDepth,
+			},
+		})
+		if len(result.Errors) != test.errors {
+			t.Fatalf("Expected %v errors with a maximum depth of %v, got: %v", test.errors, test.maxDepth, result.Errors)
+		}
+	}
+}
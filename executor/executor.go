@@ -1,24 +1,164 @@
 package executor
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/chris-ramon/graphql-go/errors"
 	"github.com/chris-ramon/graphql-go/language/ast"
 	"github.com/chris-ramon/graphql-go/types"
 	"reflect"
+	"runtime/debug"
 	"strings"
 )
 
+// Default ceiling on the number of nested fields the executor resolves.
+const DefaultMaxExecutionDepth = 1000
+
 type ExecuteParams struct {
 	Schema        types.GraphQLSchema
 	Root          interface{}
 	AST           *ast.Document
 	OperationName string
 	Args          map[string]interface{}
+	// Passed to the resolve functions, e.g. to carry request-scoped loaders.
+	// Defaults to context.Background().
+	Context context.Context
+	ExecuteOptions
+}
+
+// ExecuteOptions are the options of Execute and Subscribe shared by the
+// requests of a server, e.g. the limits of the operations and the
+// middleware, also embedded in gql.GraphqlParams.
+type ExecuteOptions struct {
+	// Creates the extensions of each execution, see Extension.
+	Extensions []ExtensionFactory
+	// Batch functions of the loaders created for each execution, resolve
+	// functions get them with LoaderFrom(p.Context, key).
+	Loaders map[string]BatchFn
+	// Maximum number of nested fields resolved before execution of a field
+	// is stopped with an error, guards against runaway recursion.
+	// Defaults to DefaultMaxExecutionDepth.
+	MaxExecutionDepth int
+	// Maximum nesting depth of the fields of the operation, fragments
+	// expanded, checked before any field is resolved. The depth of the
+	// fields of the operation's selection set is 1. Unlimited if zero.
+	MaxDepth int
+	// Exempts the introspection fields, e.g. `__schema`, and their
+	// sub-fields from MaxDepth.
+	IgnoreIntrospectionDepth bool
+	// Checks the operations selecting only introspection fields, e.g. the
+	// introspection query of GraphiQL, against MaxIntrospectionDepth and
+	// MaxIntrospectionComplexity instead of MaxDepth and MaxComplexity.
+	ExemptIntrospectionOperations bool
+	// Maximum nesting depth of the exempted introspection operations,
+	// unlimited if zero.
+	MaxIntrospectionDepth int
+	// Maximum estimated cost of the exempted introspection operations,
+	// unlimited if zero.
+	MaxIntrospectionComplexity int
+	// Maximum estimated cost of the operation, see
+	// types.GraphQLFieldConfig.Complexity, checked before any field is
+	// resolved. The cost is reported under the result's `complexity`
+	// extension. Unlimited if zero.
+	MaxComplexity int
+	// Reports the timing of each resolved field under the result's `tracing`
+	// extension.
+	EnableTracing bool
+	// Caches the resolved values of the fields configured with a
+	// types.GraphQLFieldCacheConfig, fields are not cached without it.
+	Cache Cache
+	// Records a span for each resolved field, fields are not traced without
+	// it.
+	Tracer Tracer
+	// Formats each error before it is reported in the result, e.g. to mask
+	// internal error messages. Errors are reported unchanged without it.
+	ErrorFormatter ErrorFormatter
+	// Wraps the resolver of every field, including the default resolver, the
+	// first middleware being the outermost.
+	Middleware []FieldMiddleware
+	// Makes the objects of the result's data *types.OrderedMap values, whose
+	// keys follow the order of the fields in the query, e.g. to marshal the
+	// same bytes for the same query. The objects are maps without it.
+	OrderedResult bool
+	// Reports an error for each field of the operation that is not defined
+	// on its parent type, the introspection meta-fields being defined on
+	// every type, instead of leaving it out of the result.
+	StrictFields bool
+	// Reports an error for each field resolved to a nil pointer or map
+	// where an object is expected, e.g. to debug resolvers. The fields are
+	// null without it, and the fields of the null object are not resolved.
+	PanicOnNilSource bool
+	// Maximum number of items of the lists resolved for the fields, see
+	// types.GraphQLFieldConfig.MaxListSize for the limit of a field, a longer
+	// list being handled according to ListSizePolicy. The items received
+	// from a channel are limited likewise. Unlimited if zero.
+	MaxListSize int
+	// Stops the fields resolving lists longer than their limit with an error
+	// by default, or truncates the lists, see ListSizeTruncate.
+	ListSizePolicy ListSizePolicy
+	// Keeps resolving the siblings of a non-null field or list item which
+	// failed, and reports their errors too, although the failure nulls their
+	// parent. Data is nulled the same as without it, but resolving fields
+	// whose values are discarded costs the time and the resolver calls
+	// which are otherwise saved, so it is meant for debugging.
+	CollectAllErrors bool
+	// Attaches the stack of the goroutine to the errors of the fields whose
+	// resolve function, or the serialization of their value, panicked, as a
+	// *PanicError original error. Capturing a stack is costly, panics being
+	// recovered as field errors with the message of the panic without it.
+	CapturePanicStack bool
+}
+
+// ListSizePolicy is how the executor handles a list longer than the limit of
+// its field, see ExecuteOptions.MaxListSize.
+type ListSizePolicy int
+
+const (
+	// Stops the field with an error, as any other field error.
+	ListSizeError ListSizePolicy = iota
+	// Keeps the first items of the list, up to the limit. The path, size
+	// and limit of the truncated lists are reported under the result's
+	// `truncatedLists` extension.
+	ListSizeTruncate
+)
+
+// FieldMiddleware wraps the resolver of a field, e.g. to check authorization
+// or to log. It calls next to resolve the field, or short-circuits it by
+// returning an error, reported as the field's error.
+type FieldMiddleware func(next types.GraphQLFieldResolveFn) types.GraphQLFieldResolveFn
+
+// ErrorFormatter formats an error reported in a result, see
+// ExecuteOptions.ErrorFormatter.
+type ErrorFormatter func(err graphqlerrors.GraphQLFormattedError) graphqlerrors.GraphQLFormattedError
+
+// FormatErrors returns the errors formatted by the formatter, or unchanged
+// if it is nil, e.g. to format the parse and validation errors of a request
+// as its execution errors.
+func (formatter ErrorFormatter) FormatErrors(errs []graphqlerrors.GraphQLFormattedError) []graphqlerrors.GraphQLFormattedError {
+	if formatter == nil || len(errs) == 0 {
+		return errs
+	}
+	formatted := []graphqlerrors.GraphQLFormattedError{}
+	for _, err := range errs {
+		formatted = append(formatted, formatter(err))
+	}
+	return formatted
 }
 
 func Execute(p ExecuteParams, resultChan chan *types.GraphQLResult) {
+	execute(p, resultChan, true)
+}
+
+// Executes p, answering types.IntrospectionQuery with the result memoized by
+// the schema if memoizedIntrospection is true.
+func execute(p ExecuteParams, resultChan chan *types.GraphQLResult, memoizedIntrospection bool) {
+	if p.ErrorFormatter != nil {
+		formattedChan := make(chan *types.GraphQLResult)
+		go sendFormattedResults(p.ErrorFormatter, formattedChan, resultChan)
+		defer close(formattedChan)
+		resultChan = formattedChan
+	}
 	var errors []graphqlerrors.GraphQLFormattedError
 	var result types.GraphQLResult
 	params := BuildExecutionCtxParams{
@@ -31,18 +171,52 @@ func Execute(p ExecuteParams, resultChan chan *types.GraphQLResult) {
 		Result:        &result,
 		ResultChan:    resultChan,
 	}
+	var tracing *Tracing
+	if p.EnableTracing {
+		tracing = newTracing()
+	}
+	extensions := newExtensions(p)
 	exeContext := buildExecutionContext(params)
 	if result.HasErrors() {
 		return
 	}
+	exeContext.Extensions = extensions
+	exeContext.tracing = tracing
+	exeContext.applyOptions(p)
+	maxDepth, maxComplexity := operationLimits(exeContext, p)
+	if maxDepth > 0 {
+		if err := checkMaxDepth(exeContext, maxDepth, p.IgnoreIntrospectionDepth); err != nil {
+			result.Errors = append(result.Errors, graphqlerrors.FormatError(err))
+			resultChan <- &result
+			return
+		}
+	}
+	if maxComplexity > 0 {
+		exeContext.MaxComplexity = maxComplexity
+		if err := checkMaxComplexity(exeContext); err != nil {
+			result.Errors = append(result.Errors, graphqlerrors.FormatError(err))
+			result.Extensions = exeContext.extensionsResult()
+			resultChan <- &result
+			return
+		}
+	}
+	if memoizedIntrospection && isIntrospectionQuery(p, maxComplexity) {
+		resultChan <- p.Schema.IntrospectionResult()
+		return
+	}
+	// set once the checks above, which collect the fields too, are done
+	exeContext.StrictFields = p.StrictFields
 	defer func() {
 		if r := recover(); r != nil {
-			var err error
-			if r, ok := r.(error); ok {
-				err = graphqlerrors.FormatError(r)
+			if _, ok := r.(reportedError); !ok {
+				var err error
+				if r, ok := r.(error); ok {
+					err = graphqlerrors.FormatError(r)
+				}
+				exeContext.Errors = append(exeContext.Errors, graphqlerrors.FormatError(err))
 			}
-			exeContext.Errors = append(exeContext.Errors, graphqlerrors.FormatError(err))
 			result.Errors = exeContext.Errors
+			result.Extensions = exeContext.extensionsResult()
 			resultChan <- &result
 		}
 	}()
@@ -54,6 +228,30 @@ func Execute(p ExecuteParams, resultChan chan *types.GraphQLResult) {
 	executeOperation(eOperationParams, resultChan)
 }
 
+// Creates the extensions of an execution of p, see ExtensionFactory.
+func newExtensions(p ExecuteParams) []Extension {
+	extensions := []Extension{}
+	for _, newExtension := range p.Extensions {
+		extension := newExtension()
+		extension.Init(p)
+		extensions = append(extensions, extension)
+	}
+	return extensions
+}
+
+// Sends the results with their errors formatted by the formatter until
+// resultChan is closed.
+func sendFormattedResults(formatter ErrorFormatter, resultChan <-chan *types.GraphQLResult, formattedChan chan<- *types.GraphQLResult) {
+	for result := range resultChan {
+		formatResultErrors(formatter, result)
+		formattedChan <- result
+	}
+}
+
+func formatResultErrors(formatter ErrorFormatter, result *types.GraphQLResult) {
+	result.Errors = formatter.FormatErrors(result.Errors)
+}
+
 type BuildExecutionCtxParams struct {
 	Schema        types.GraphQLSchema
 	Root          interface{}
@@ -63,14 +261,71 @@ type BuildExecutionCtxParams struct {
 	Errors        []graphqlerrors.GraphQLFormattedError
 	Result        *types.GraphQLResult
 	ResultChan    chan *types.GraphQLResult
+	// Leaves out the variables which are not provided rather than reporting
+	// those of a non-null type, e.g. to plan an operation, see ExecutePlan.
+	PartialVariables bool
 }
 type ExecutionContext struct {
-	Schema         types.GraphQLSchema
-	Fragments      map[string]ast.Definition
-	Root           interface{}
-	Operation      ast.Definition
-	VariableValues map[string]interface{}
-	Errors         []graphqlerrors.GraphQLFormattedError
+	Schema            types.GraphQLSchema
+	Fragments         map[string]ast.Definition
+	Root              interface{}
+	Operation         ast.Definition
+	VariableValues    map[string]interface{}
+	Errors            []graphqlerrors.GraphQLFormattedError
+	Extensions        []Extension
+	MaxExecutionDepth int
+	MaxComplexity     int
+	Context           context.Context
+	Cache             Cache
+	Tracer            Tracer
+	Middleware        []FieldMiddleware
+	OrderedResult     bool
+	StrictFields      bool
+	PanicOnNilSource  bool
+	MaxListSize       int
+	ListSizePolicy    ListSizePolicy
+	CollectAllErrors  bool
+	CapturePanicStack bool
+
+	// fields whose resolution was deferred to the next resolution level
+	deferred []func()
+	// estimated cost of the operation, checked against MaxComplexity
+	complexity int
+	// nil unless tracing is enabled
+	tracing *Tracing
+	// lists truncated to the limit of their field, see ListSizeTruncate
+	truncatedLists []interface{}
+	// sub-fields collected for each object type and field ASTs during this
+	// execution, the documents of executions differ
+	subFields map[subFieldsKey][]subFields
+	// resolves the root field for an event of a subscription, see Subscribe
+	eventResolveFn types.GraphQLFieldResolveFn
+}
+
+// Sets the context and the options of p on the execution context, but for
+// the operation limits and StrictFields, which are set once the operation
+// is checked.
+func (eCtx *ExecutionContext) applyOptions(p ExecuteParams) {
+	eCtx.Context = p.Context
+	if eCtx.Context == nil {
+		eCtx.Context = context.Background()
+	}
+	if len(p.Loaders) > 0 {
+		eCtx.Context = WithLoaders(eCtx.Context, p.Loaders)
+	}
+	eCtx.Cache = p.Cache
+	eCtx.Tracer = p.Tracer
+	eCtx.Middleware = p.Middleware
+	eCtx.OrderedResult = p.OrderedResult
+	eCtx.PanicOnNilSource = p.PanicOnNilSource
+	eCtx.MaxListSize = p.MaxListSize
+	eCtx.ListSizePolicy = p.ListSizePolicy
+	eCtx.CollectAllErrors = p.CollectAllErrors
+	eCtx.CapturePanicStack = p.CapturePanicStack
+	eCtx.MaxExecutionDepth = p.MaxExecutionDepth
+	if eCtx.MaxExecutionDepth <= 0 {
+		eCtx.MaxExecutionDepth = DefaultMaxExecutionDepth
+	}
 }
 
 func buildExecutionContext(p BuildExecutionCtxParams) *ExecutionContext {
@@ -121,9 +376,14 @@ func buildExecutionContext(p BuildExecutionCtxParams) *ExecutionContext {
 		p.ResultChan <- p.Result
 		return eCtx
 	}
-	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args)
-	if err != nil {
-		p.Result.Errors = append(p.Result.Errors, graphqlerrors.FormatError(err))
+	// Invalid variables fail the whole request, reporting all of them at once.
+	operationDef := operation.(*ast.OperationDefinition)
+	if p.PartialVariables {
+		operationDef = withProvidedVariables(operationDef, p.Args)
+	}
+	variableValues, errs := CoerceVariableValues(p.Schema, operationDef, p.Args)
+	if len(errs) > 0 {
+		p.Result.Errors = append(p.Result.Errors, graphqlerrors.FormatErrors(errs...)...)
 		p.ResultChan <- p.Result
 		return eCtx
 	}
@@ -146,11 +406,20 @@ type ExecuteOperationParams struct {
 func executeOperation(p ExecuteOperationParams, resultChan chan *types.GraphQLResult) {
 	var results types.GraphQLResult
 	operationType := getOperationRootType(p.ExecutionContext.Schema, p.Operation, resultChan)
+	if operationType == nil {
+		return
+	}
+
+	for _, extension := range p.ExecutionContext.Extensions {
+		extension.ExecutionDidStart(p.ExecutionContext)
+	}
 
+	responseNames := []string{}
 	collectFieldsParams := CollectFieldsParams{
 		ExeContext:    p.ExecutionContext,
 		OperationType: operationType,
 		SelectionSet:  p.Operation.GetSelectionSet(),
+		ResponseNames: &responseNames,
 	}
 	fields := collectFields(collectFieldsParams)
 	executeFieldsParams := ExecuteFieldsParams{
@@ -158,13 +427,16 @@ func executeOperation(p ExecuteOperationParams, resultChan chan *types.GraphQLRe
 		ParentType:       operationType,
 		Source:           p.Root,
 		Fields:           fields,
+		ResponseNames:    responseNames,
 	}
 	if p.Operation.GetOperation() == "mutation" {
 		executeFieldsSerially(executeFieldsParams, resultChan)
 		return
 	}
 	results = executeFields(executeFieldsParams)
+	p.ExecutionContext.resolveDeferred()
 	results.Errors = p.ExecutionContext.Errors
+	results.Extensions = p.ExecutionContext.extensionsResult()
 	resultChan <- &results
 }
 
@@ -182,7 +454,7 @@ func getOperationRootType(schema types.GraphQLSchema, operation ast.Definition,
 		return schema.GetQueryType()
 	case "mutation":
 		mutationType := schema.GetMutationType()
-		if mutationType.Name == "" {
+		if mutationType == nil || mutationType.Name == "" {
 			var result types.GraphQLResult
 			err := graphqlerrors.NewGraphQLFormattedError("Schema is not configured for mutations")
 			result.Errors = append(result.Errors, err)
@@ -190,6 +462,16 @@ func getOperationRootType(schema types.GraphQLSchema, operation ast.Definition,
 			return objType
 		}
 		return mutationType
+	case "subscription":
+		var result types.GraphQLResult
+		message := "Can only execute queries and mutations"
+		if schema.GetSubscriptionType() == nil {
+			message = "Schema is not configured for subscriptions"
+		}
+		err := graphqlerrors.NewGraphQLFormattedError(message)
+		result.Errors = append(result.Errors, err)
+		r <- &result
+		return objType
 	default:
 		var result types.GraphQLResult
 		err := graphqlerrors.NewGraphQLFormattedError("Can only execute queries and mutations")
@@ -204,6 +486,9 @@ type ExecuteFieldsParams struct {
 	ParentType       *types.GraphQLObjectType
 	Source           interface{}
 	Fields           map[string][]*ast.Field
+	// The response names of Fields in the order of the query.
+	ResponseNames []string
+	Path          []interface{}
 }
 
 // Implements the "Evaluating selection sets" section of the spec for "write" mode.
@@ -216,16 +501,25 @@ func executeFieldsSerially(p ExecuteFieldsParams, resultChan chan *types.GraphQL
 	}
 	var result types.GraphQLResult
 
-	finalResults := map[string]interface{}{}
-	for responseName, fieldASTs := range p.Fields {
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs)
+	finalResults := newResultObject(p.ExecutionContext)
+	for _, responseName := range p.ResponseNames {
+		fieldASTs := p.Fields[responseName]
+		fieldPath := appendPath(p.Path, responseName)
+		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs, fieldPath)
 		if state.hasNoFieldDefs {
 			continue
 		}
-		finalResults[responseName] = resolved
+		// each root field, including its sub-fields, is resolved before the
+		// next one
+		if state.deferred != nil {
+			resolved = state.deferred()
+		}
+		p.ExecutionContext.resolveDeferred()
+		finalResults.set(responseName, resolved)
 	}
 	result.Errors = p.ExecutionContext.Errors
-	result.Data = finalResults
+	result.Data = finalResults.data()
+	result.Extensions = p.ExecutionContext.extensionsResult()
 	resultChan <- &result
 }
 
@@ -237,27 +531,79 @@ func executeFields(p ExecuteFieldsParams) (result types.GraphQLResult) {
 	if p.Fields == nil {
 		p.Fields = map[string][]*ast.Field{}
 	}
-	finalResults := map[string]interface{}{}
-	for responseName, fieldASTs := range p.Fields {
-		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs)
-		if state.hasNoFieldDefs {
+	finalResults := newResultObject(p.ExecutionContext)
+	propagated := &propagatedError{}
+	for _, responseName := range p.ResponseNames {
+		fieldASTs := p.Fields[responseName]
+		fieldPath := appendPath(p.Path, responseName)
+		var resolved interface{}
+		var state resolveFieldResultState
+		failed := propagated.catch(p.ExecutionContext, func() {
+			resolved, state = resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs, fieldPath)
+		})
+		if failed || state.hasNoFieldDefs {
 			continue
 		}
-		finalResults[responseName] = resolved
+		if state.deferred != nil {
+			responseName, resolve := responseName, state.deferred
+			p.ExecutionContext.deferred = append(p.ExecutionContext.deferred, func() {
+				finalResults.set(responseName, resolve())
+			})
+		}
+		finalResults.set(responseName, resolved)
 	}
+	propagated.propagate()
 	result.Errors = p.ExecutionContext.Errors
-	if len(finalResults) > 0 {
-		result.Data = finalResults
+	if finalResults.len() > 0 {
+		result.Data = finalResults.data()
 	}
 	return result
 }
 
+// The object of a result, a map or, with OrderedResult, a *types.OrderedMap.
+type resultObject struct {
+	values  map[string]interface{}
+	ordered *types.OrderedMap
+}
+
+func newResultObject(eCtx *ExecutionContext) resultObject {
+	if eCtx.OrderedResult {
+		return resultObject{ordered: types.NewOrderedMap()}
+	}
+	return resultObject{values: map[string]interface{}{}}
+}
+
+func (o resultObject) set(key string, value interface{}) {
+	if o.ordered != nil {
+		o.ordered.Set(key, value)
+		return
+	}
+	o.values[key] = value
+}
+
+func (o resultObject) len() int {
+	if o.ordered != nil {
+		return o.ordered.Len()
+	}
+	return len(o.values)
+}
+
+func (o resultObject) data() interface{} {
+	if o.ordered != nil {
+		return o.ordered
+	}
+	return o.values
+}
+
 type CollectFieldsParams struct {
 	ExeContext           *ExecutionContext
 	OperationType        *types.GraphQLObjectType
 	SelectionSet         *ast.SelectionSet
 	Fields               map[string][]*ast.Field
 	VisitedFragmentNames map[string]bool
+	// Appended with the response names of the collected fields in the order
+	// in which they are first collected, if not nil.
+	ResponseNames *[]string
 }
 
 // Given a selectionSet, adds all of the fields in that selection to
@@ -280,9 +626,15 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 			if !shouldIncludeNode(p.ExeContext, selection.Directives) {
 				continue
 			}
+			if p.ExeContext.StrictFields {
+				checkFieldDefined(p.ExeContext.Schema, p.OperationType, selection)
+			}
 			name := getFieldEntryKey(selection)
 			if _, ok := fields[name]; !ok {
 				fields[name] = []*ast.Field{}
+				if p.ResponseNames != nil {
+					*p.ResponseNames = append(*p.ResponseNames, name)
+				}
 			}
 			fields[name] = append(fields[name], selection)
 		case *ast.InlineFragment:
@@ -297,6 +649,7 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				SelectionSet:         selection.SelectionSet,
 				Fields:               fields,
 				VisitedFragmentNames: p.VisitedFragmentNames,
+				ResponseNames:        p.ResponseNames,
 			}
 			collectFields(innerParams)
 		case *ast.FragmentSpread:
@@ -325,6 +678,7 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 					SelectionSet:         fragment.GetSelectionSet(),
 					Fields:               fields,
 					VisitedFragmentNames: p.VisitedFragmentNames,
+					ResponseNames:        p.ResponseNames,
 				}
 				collectFields(innerParams)
 			}
@@ -333,97 +687,37 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 	return fields
 }
 
-// Determines if a field should be included based on the @include and @skip
-// directives, where @skip has higher precedence than @include.
-func shouldIncludeNode(eCtx *ExecutionContext, directives []*ast.Directive) bool {
-
-	defaultReturnValue := true
-
-	var skipAST *ast.Directive
-	var includeAST *ast.Directive
-	for _, directive := range directives {
-		if directive == nil || directive.Name == nil {
-			continue
-		}
-		if directive.Name.Value == types.GraphQLSkipDirective.Name {
-			skipAST = directive
-			break
-		}
-	}
-	if skipAST != nil {
-		argValues, err := getArgumentValues(
-			types.GraphQLSkipDirective.Args,
-			skipAST.Arguments,
-			eCtx.VariableValues,
-		)
-		if err != nil {
-			return defaultReturnValue
-		}
-		if skipIf, ok := argValues["if"]; ok {
-			if boolSkipIf, ok := skipIf.(bool); ok {
-				return !boolSkipIf
-			}
-		}
-		return defaultReturnValue
-	}
-	for _, directive := range directives {
-		if directive == nil || directive.Name == nil {
-			continue
-		}
-		if directive.Name.Value == types.GraphQLIncludeDirective.Name {
-			includeAST = directive
-			break
-		}
+// Panics with an error located at the field if it is not defined on the
+// given type, see ExecuteOptions.StrictFields.
+func checkFieldDefined(schema types.GraphQLSchema, objectType *types.GraphQLObjectType, field *ast.Field) {
+	fieldName := ""
+	if field.Name != nil {
+		fieldName = field.Name.Value
 	}
-	if includeAST != nil {
-		argValues, err := getArgumentValues(
-			types.GraphQLIncludeDirective.Args,
-			includeAST.Arguments,
-			eCtx.VariableValues,
-		)
-		if err != nil {
-			return defaultReturnValue
-		}
-		if includeIf, ok := argValues["if"]; ok {
-			if boolIncludeIf, ok := includeIf.(bool); ok {
-				return boolIncludeIf
-			}
-		}
-		return defaultReturnValue
+	if getFieldDef(schema, objectType, fieldName) != nil {
+		return
 	}
-	return defaultReturnValue
+	panic(graphqlerrors.FormatError(graphqlerrors.NewLocatedError(
+		fmt.Sprintf(`Cannot query field "%v" on type "%v".`, fieldName, objectType),
+		[]ast.Node{field},
+	)))
 }
 
-// Determines if a fragment is applicable to the given type.
-func doesFragmentConditionMatch(eCtx *ExecutionContext, fragment ast.Node, ttype *types.GraphQLObjectType) bool {
+// Determines if a field should be included based on the @include and @skip
+// directives, see types.ShouldIncludeNode.
+func shouldIncludeNode(eCtx *ExecutionContext, directives []*ast.Directive) bool {
+	return types.ShouldIncludeNode(directives, eCtx.VariableValues)
+}
 
+// Determines if a fragment is applicable to the given type, see
+// types.DoesFragmentConditionMatch.
+func doesFragmentConditionMatch(eCtx *ExecutionContext, fragment ast.Node, ttype *types.GraphQLObjectType) bool {
 	switch fragment := fragment.(type) {
 	case *ast.FragmentDefinition:
-		conditionalType, err := typeFromAST(eCtx.Schema, fragment.TypeCondition)
-		if err != nil {
-			return false
-		}
-		if conditionalType == ttype {
-			return true
-		}
-
-		if conditionalType, ok := conditionalType.(types.GraphQLAbstractType); ok {
-			return conditionalType.IsPossibleType(ttype)
-		}
+		return types.DoesFragmentConditionMatch(eCtx.Schema, fragment.TypeCondition, ttype)
 	case *ast.InlineFragment:
-		conditionalType, err := typeFromAST(eCtx.Schema, fragment.TypeCondition)
-		if err != nil {
-			return false
-		}
-		if conditionalType == ttype {
-			return true
-		}
-
-		if conditionalType, ok := conditionalType.(types.GraphQLAbstractType); ok {
-			return conditionalType.IsPossibleType(ttype)
-		}
+		return types.DoesFragmentConditionMatch(eCtx.Schema, fragment.TypeCondition, ttype)
 	}
-
 	return false
 }
 
@@ -442,6 +736,8 @@ func getFieldEntryKey(node *ast.Field) string {
 // Internal resolveField state
 type resolveFieldResultState struct {
 	hasNoFieldDefs bool
+	// completes the field once its thunk can be resolved
+	deferred func() interface{}
 }
 
 /**
@@ -450,27 +746,23 @@ type resolveFieldResultState struct {
  * then calls completeValue to complete promises, serialize scalars, or execute
  * the sub-selection-set for objects.
  */
-func resolveField(eCtx *ExecutionContext, parentType *types.GraphQLObjectType, source interface{}, fieldASTs []*ast.Field) (result interface{}, resultState resolveFieldResultState) {
+func resolveField(eCtx *ExecutionContext, parentType *types.GraphQLObjectType, source interface{}, fieldASTs []*ast.Field, path []interface{}) (result interface{}, resultState resolveFieldResultState) {
 	// catch panic from resolveFn
 	var returnType types.GraphQLOutputType
 	defer func() (interface{}, resolveFieldResultState) {
 		if r := recover(); r != nil {
-
-			var err error
-			if r, ok := r.(string); ok {
-				err = graphqlerrors.NewLocatedError(
-					fmt.Sprintf("%v", r),
-					graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
-				)
-			}
-			if r, ok := r.(error); ok {
-				err = graphqlerrors.FormatError(r)
+			if _, ok := r.(reportedError); ok {
+				if _, ok := returnType.(*types.GraphQLNonNull); ok {
+					panic(r)
+				}
+				return result, resultState
 			}
+			formattedErr := withPath(recoveredError(capturePanic(eCtx, r), fieldASTs), path)
 			// send panic upstream
 			if _, ok := returnType.(*types.GraphQLNonNull); ok {
-				panic(graphqlerrors.FormatError(err))
+				panic(formattedErr)
 			}
-			eCtx.Errors = append(eCtx.Errors, graphqlerrors.FormatError(err))
+			eCtx.Errors = append(eCtx.Errors, formattedErr)
 			return result, resultState
 		}
 		return result, resultState
@@ -488,7 +780,16 @@ func resolveField(eCtx *ExecutionContext, parentType *types.GraphQLObjectType, s
 		return nil, resultState
 	}
 	returnType = fieldDef.Type
+	if fieldDepth(path) > eCtx.MaxExecutionDepth {
+		panic(graphqlerrors.NewLocatedError(
+			fmt.Sprintf("Exceeded maximum execution depth of %v.", eCtx.MaxExecutionDepth),
+			graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
+		))
+	}
 	resolveFn := fieldDef.Resolve
+	if eCtx.eventResolveFn != nil && len(path) == 1 {
+		resolveFn = eCtx.eventResolveFn
+	}
 	if resolveFn == nil {
 		resolveFn = defaultResolveFn
 	}
@@ -496,12 +797,16 @@ func resolveField(eCtx *ExecutionContext, parentType *types.GraphQLObjectType, s
 	// Build a map of arguments from the field.arguments AST, using the
 	// variables scope to fulfill any variable references.
 	// TODO: find a way to memoize, in case this field is within a List type.
-	args, _ := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	args, err := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	if err != nil {
+		panic(err)
+	}
 
 	// The resolve function's optional third argument is a collection of
 	// information about the current execution state.
 	info := types.GraphQLResolveInfo{
 		FieldName:      fieldName,
+		Path:           append([]interface{}{}, path...),
 		FieldASTs:      fieldASTs,
 		ReturnType:     returnType,
 		ParentType:     parentType,
@@ -516,37 +821,236 @@ func resolveField(eCtx *ExecutionContext, parentType *types.GraphQLObjectType, s
 	// it is wrapped as a GraphQLError with locations. Log this error and return
 	// null if allowed, otherwise throw the error so the parent field can handle
 	// it.
-	result = resolveFn(types.GQLFRParams{
-		Source: source,
-		Args:   args,
-		Info:   info,
-	})
+	resolveFieldDidEnd := []func(result interface{}){}
+	for _, extension := range eCtx.Extensions {
+		if didEnd := extension.ResolveField(info); didEnd != nil {
+			resolveFieldDidEnd = append(resolveFieldDidEnd, didEnd)
+		}
+	}
+	var traceDidEnd func()
+	if eCtx.tracing != nil {
+		traceDidEnd = eCtx.tracing.resolveField(info, path)
+	}
+	resolveFn = tracedResolveFn(eCtx, resolveFn, path)
+	resolve := func(p types.GQLFRParams) interface{} {
+		return resolveCached(eCtx, fieldDef, resolveFn, p)
+	}
+	for i := len(eCtx.Middleware) - 1; i >= 0; i-- {
+		resolve = eCtx.Middleware[i](resolve)
+	}
+	result = func() (result interface{}) {
+		// the extensions and the tracing see the end of a panicking resolver
+		// too, the result is then nil
+		defer func() {
+			if traceDidEnd != nil {
+				traceDidEnd()
+			}
+			for _, didEnd := range resolveFieldDidEnd {
+				didEnd(result)
+			}
+		}()
+		return resolve(types.GQLFRParams{
+			Source:  source,
+			Args:    args,
+			Info:    info,
+			Context: eCtx.Context,
+		})
+	}()
+
+	// Defer thunks so that loads queued by the fields resolved after this one
+	// are batched together. Non-null fields are completed right away, their
+	// errors have to propagate to the parent field.
+	if thunk, ok := result.(Thunk); ok {
+		if _, ok := returnType.(*types.GraphQLNonNull); !ok {
+			resultState.deferred = func() interface{} {
+				return completeValueCatchingError(eCtx, returnType, fieldASTs, info, thunk, path)
+			}
+			return nil, resultState
+		}
+	}
 
-	completed := completeValueCatchingError(eCtx, returnType, fieldASTs, info, result)
+	completed := completeValueCatchingError(eCtx, returnType, fieldASTs, info, result, path)
 	return completed, resultState
 }
 
-func completeValueCatchingError(eCtx *ExecutionContext, returnType types.GraphQLType, fieldASTs []*ast.Field, info types.GraphQLResolveInfo, result interface{}) (completed interface{}) {
+// Returns the maximum number of items of the lists of the field, the limit
+// of the field or else of the execution, zero if unlimited.
+func maxListSize(eCtx *ExecutionContext, info types.GraphQLResolveInfo) int {
+	if parentType, ok := info.ParentType.(*types.GraphQLObjectType); ok {
+		if fieldDef := getFieldDef(info.Schema, parentType, info.FieldName); fieldDef != nil && fieldDef.MaxListSize > 0 {
+			return fieldDef.MaxListSize
+		}
+	}
+	return eCtx.MaxListSize
+}
+
+// Handles a list of the field longer than its limit according to the
+// ListSizePolicy of the execution: panics with the error of the field, or
+// records the truncation of the list. The size of a list streamed from a
+// channel is unknown, it is recorded only if size is positive.
+func truncateList(eCtx *ExecutionContext, fieldASTs []*ast.Field, info types.GraphQLResolveInfo, path []interface{}, size int, limit int) {
+	if eCtx.ListSizePolicy != ListSizeTruncate {
+		err := graphqlerrors.NewLocatedError(
+			fmt.Sprintf("List field %v.%v resolved more than %v items.", info.ParentType, info.FieldName, limit),
+			graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
+		)
+		panic(graphqlerrors.FormatError(err))
+	}
+	truncated := map[string]interface{}{
+		"path":  append([]interface{}{}, path...),
+		"limit": limit,
+	}
+	if size > 0 {
+		truncated["size"] = size
+	}
+	eCtx.truncatedLists = append(eCtx.truncatedLists, truncated)
+}
+
+// Returns true if the value is nil, or a nil pointer, map or interface, e.g.
+// a nil *Article resolved for a field of an object type.
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	valueVal := reflect.ValueOf(value)
+	switch valueVal.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface:
+		return valueVal.IsNil()
+	}
+	return false
+}
+
+// Returns the error of a field resolved to a nil object, see
+// ExecuteOptions.PanicOnNilSource.
+func nilSourceError(info types.GraphQLResolveInfo, fieldASTs []*ast.Field, value interface{}) graphqlerrors.GraphQLFormattedError {
+	return graphqlerrors.FormatError(graphqlerrors.NewLocatedError(
+		fmt.Sprintf(`Field %v.%v of type "%v" resolved to a nil %T.`, info.ParentType, info.FieldName, info.ReturnType, value),
+		graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
+	))
+}
+
+// The errors of the non-null fields or list items of an object or a list,
+// held with ExecuteOptions.CollectAllErrors until their siblings are
+// resolved.
+type propagatedError struct {
+	failed bool
+}
+
+// Propagated in place of the errors of non-null fields or list items which
+// are already reported, see propagatedError.
+type reportedError struct{}
+
+// Calls resolve, and with CollectAllErrors, recovers from the error it
+// propagates, returning whether it did. The errors are reported as they are
+// caught, so in the order the fields or items are resolved.
+func (p *propagatedError) catch(eCtx *ExecutionContext, resolve func()) (failed bool) {
+	defer func() {
+		if !eCtx.CollectAllErrors {
+			return
+		}
+		if r := recover(); r != nil {
+			failed = true
+			p.failed = true
+			if _, ok := r.(reportedError); !ok {
+				eCtx.Errors = append(eCtx.Errors, recoveredError(r, nil))
+			}
+		}
+	}()
+	resolve()
+	return false
+}
+
+// Propagates to the parent that an error was caught, if any.
+func (p *propagatedError) propagate() {
+	if p.failed {
+		panic(reportedError{})
+	}
+}
+
+// PanicError is the original error of a field which panicked, with the
+// stack of the goroutine at the time of the panic, see
+// ExecuteOptions.CapturePanicStack.
+type PanicError struct {
+	// The value the field panicked with.
+	Value interface{}
+	Stack []byte
+}
+
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("%v", err.Value)
+}
+
+// Unwrap returns the value the field panicked with if it is an error, e.g. a
+// runtime.Error.
+func (err *PanicError) Unwrap() error {
+	if valueErr, ok := err.Value.(error); ok {
+		return valueErr
+	}
+	return nil
+}
+
+// Wraps a value recovered from a panic of a field in a *PanicError with
+// CapturePanicStack, unless it is an error already raised by the executor.
+// It must be called by the deferred function recovering the panic, while
+// the stack is the stack of the panic.
+func capturePanic(eCtx *ExecutionContext, r interface{}) interface{} {
+	if !eCtx.CapturePanicStack {
+		return r
+	}
+	switch r.(type) {
+	case graphqlerrors.GraphQLFormattedError, *graphqlerrors.GraphQLError:
+		return r
+	}
+	return &PanicError{Value: r, Stack: debug.Stack()}
+}
+
+// Returns the error of a field recovered from a panic, located at the
+// field unless it is already a formatted error, e.g. an error returned by a
+// resolve function or a panic of a scalar's Serialize function.
+func recoveredError(r interface{}, fieldASTs []*ast.Field) graphqlerrors.GraphQLFormattedError {
+	var err error
+	switch r := r.(type) {
+	case graphqlerrors.GraphQLFormattedError:
+		return r
+	case error:
+		// keep the resolver's error as the original error
+		err = graphqlerrors.NewLocatedError(r, graphqlerrors.FieldASTsToNodeASTs(fieldASTs))
+	default:
+		err = graphqlerrors.NewLocatedError(
+			fmt.Sprintf("%v", r),
+			graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
+		)
+	}
+	return graphqlerrors.FormatError(err)
+}
+
+func completeValueCatchingError(eCtx *ExecutionContext, returnType types.GraphQLType, fieldASTs []*ast.Field, info types.GraphQLResolveInfo, result interface{}, path []interface{}) (completed interface{}) {
 	// catch panic
 	defer func() interface{} {
 		if r := recover(); r != nil {
+			if _, ok := r.(reportedError); ok {
+				if _, ok := returnType.(*types.GraphQLNonNull); ok {
+					panic(r)
+				}
+				return completed
+			}
+			// only this field is nulled, its siblings are still completed
+			err := withPath(recoveredError(capturePanic(eCtx, r), fieldASTs), path)
 			//send panic upstream
 			if _, ok := returnType.(*types.GraphQLNonNull); ok {
-				panic(r)
-			}
-			if err, ok := r.(graphqlerrors.GraphQLFormattedError); ok {
-				eCtx.Errors = append(eCtx.Errors, err)
+				panic(err)
 			}
+			eCtx.Errors = append(eCtx.Errors, err)
 			return completed
 		}
 		return completed
 	}()
 
 	if returnType, ok := returnType.(*types.GraphQLNonNull); ok {
-		completed := completeValue(eCtx, returnType, fieldASTs, info, result)
+		completed := completeValue(eCtx, returnType, fieldASTs, info, result, path)
 		return completed
 	}
-	completed = completeValue(eCtx, returnType, fieldASTs, info, result)
+	completed = completeValue(eCtx, returnType, fieldASTs, info, result, path)
 	resultVal := reflect.ValueOf(completed)
 	if resultVal.IsValid() && resultVal.Type().Kind() == reflect.Func {
 		if propertyFn, ok := completed.(func() interface{}); ok {
@@ -558,10 +1062,20 @@ func completeValueCatchingError(eCtx *ExecutionContext, returnType types.GraphQL
 	return completed
 }
 
-func completeValue(eCtx *ExecutionContext, returnType types.GraphQLType, fieldASTs []*ast.Field, info types.GraphQLResolveInfo, result interface{}) interface{} {
+func completeValue(eCtx *ExecutionContext, returnType types.GraphQLType, fieldASTs []*ast.Field, info types.GraphQLResolveInfo, result interface{}, path []interface{}) interface{} {
 
 	// TODO: explore resolving go-routines in completeValue
 
+	if thunk, ok := result.(Thunk); ok {
+		result = thunk()
+	}
+	if err, ok := result.(error); ok {
+		panic(graphqlerrors.FormatError(graphqlerrors.NewLocatedError(
+			err,
+			graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
+		)))
+	}
+
 	resultVal := reflect.ValueOf(result)
 	if resultVal.IsValid() && resultVal.Type().Kind() == reflect.Func {
 		if propertyFn, ok := result.(func() interface{}); ok {
@@ -571,8 +1085,24 @@ func completeValue(eCtx *ExecutionContext, returnType types.GraphQLType, fieldAS
 		panic(graphqlerrors.FormatError(err))
 	}
 
+	// If the resolver already completed the subtree, use it as-is. Non-null
+	// types unwrap first so that a nil completed value is still reported.
+	if completedValue, ok := result.(*types.CompletedValue); ok {
+		if _, ok := returnType.(*types.GraphQLNonNull); !ok {
+			if completedValue == nil {
+				return nil
+			}
+			return completedValue.Value
+		}
+	}
+
 	if returnType, ok := returnType.(*types.GraphQLNonNull); ok {
-		completed := completeValue(eCtx, returnType.OfType, fieldASTs, info, result)
+		// A nil slice is an empty list when the list is non-null, Go does not
+		// distinguish the two.
+		if _, ok := returnType.OfType.(*types.GraphQLList); ok && isNilSlice(result) {
+			return []interface{}{}
+		}
+		completed := completeValue(eCtx, returnType.OfType, fieldASTs, info, result, path)
 		if completed == nil {
 			err := graphqlerrors.NewLocatedError(
 				fmt.Sprintf("Cannot return null for non-nullable field %v.%v.", info.ParentType, info.FieldName),
@@ -591,6 +1121,9 @@ func completeValue(eCtx *ExecutionContext, returnType types.GraphQLType, fieldAS
 	if returnType, ok := returnType.(*types.GraphQLList); ok {
 
 		resultVal := reflect.ValueOf(result)
+		if resultVal.IsValid() && resultVal.Kind() == reflect.Chan {
+			return completeStreamedListValue(eCtx, returnType, fieldASTs, info, resultVal, path)
+		}
 		err := invariant(
 			resultVal.IsValid() && resultVal.Type().Kind() == reflect.Slice,
 			"User Error: expected iterable, but did not find one.",
@@ -598,14 +1131,26 @@ func completeValue(eCtx *ExecutionContext, returnType types.GraphQLType, fieldAS
 		if err != nil {
 			panic(graphqlerrors.FormatError(err))
 		}
+		if resultVal.IsNil() {
+			return nil
+		}
+		if limit := maxListSize(eCtx, info); limit > 0 && resultVal.Len() > limit {
+			truncateList(eCtx, fieldASTs, info, path, resultVal.Len(), limit)
+			resultVal = resultVal.Slice(0, limit)
+		}
 
 		itemType := returnType.OfType
 		completedResults := []interface{}{}
+		propagated := &propagatedError{}
 		for i := 0; i < resultVal.Len(); i++ {
 			val := resultVal.Index(i).Interface()
-			completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, info, val)
+			var completedItem interface{}
+			propagated.catch(eCtx, func() {
+				completedItem = completeValueCatchingError(eCtx, itemType, fieldASTs, info, val, appendPath(path, i))
+			})
 			completedResults = append(completedResults, completedItem)
 		}
+		propagated.propagate()
 		return completedResults
 	}
 
@@ -634,14 +1179,23 @@ func completeValue(eCtx *ExecutionContext, returnType types.GraphQLType, fieldAS
 		return serializedResult
 	}
 
+	// A nil pointer or map is the null object, its sub-selection is not
+	// executed.
+	if isNilValue(result) {
+		if eCtx.PanicOnNilSource {
+			panic(nilSourceError(info, fieldASTs, result))
+		}
+		return nil
+	}
+
 	// Field type must be Object, Interface or Union and expect sub-selections.
 	var objectType *types.GraphQLObjectType
-	switch returnType := returnType.(type) {
+	switch ttype := returnType.(type) {
 	case *types.GraphQLObjectType:
-		objectType = returnType
+		objectType = ttype
 	case types.GraphQLAbstractType:
-		objectType = returnType.GetObjectType(result, info)
-		if objectType != nil && !returnType.IsPossibleType(objectType) {
+		objectType = resolveAbstractType(eCtx, returnType, result, info)
+		if objectType != nil && !eCtx.Schema.IsPossibleType(returnType, objectType) {
 			panic(graphqlerrors.NewGraphQLFormattedError(
 				fmt.Sprintf(`Runtime Object type "%v" is not a possible type `+
 					`for "%v".`, objectType, returnType),
@@ -661,8 +1215,160 @@ func completeValue(eCtx *ExecutionContext, returnType types.GraphQLType, fieldAS
 		))
 	}
 
-	// Collect sub-fields to execute to complete this value.
-	subFieldASTs := map[string][]*ast.Field{}
+	collected := collectOrderedSubFields(eCtx, objectType, fieldASTs)
+	executeFieldsParams := ExecuteFieldsParams{
+		ExecutionContext: eCtx,
+		ParentType:       objectType,
+		Source:           result,
+		Fields:           collected.fields,
+		ResponseNames:    collected.responseNames,
+		Path:             path,
+	}
+	results := executeFields(executeFieldsParams)
+
+	return results.Data
+
+}
+
+// Completes the items received from the channel resolved for a list field,
+// in the order they are received, until the channel is closed. A nil channel
+// is an empty list. The items are limited as those of a slice, see
+// ExecuteOptions.MaxListSize. The field fails once the context of the
+// execution is done, the resolver must then stop sending and close the
+// channel: the items sent once the field is stopped, for any reason, are
+// received and discarded until the channel is closed or the context is done,
+// so that a producer blocked on a send is released.
+func completeStreamedListValue(eCtx *ExecutionContext, returnType *types.GraphQLList, fieldASTs []*ast.Field, info types.GraphQLResolveInfo, resultVal reflect.Value, path []interface{}) interface{} {
+	err := invariant(
+		resultVal.Type().ChanDir()&reflect.RecvDir != 0,
+		"User Error: expected a channel to receive from, but found a send-only channel.",
+	)
+	if err != nil {
+		panic(graphqlerrors.FormatError(err))
+	}
+	completedResults := []interface{}{}
+	if resultVal.IsNil() {
+		return completedResults
+	}
+	ctx := eCtx.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: resultVal},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	}
+	limit := maxListSize(eCtx, info)
+	closed := false
+	defer func() {
+		if !closed {
+			go drainChannel(ctx, resultVal)
+		}
+	}()
+	for i := 0; ; i++ {
+		chosen, val, ok := reflect.Select(cases)
+		if chosen == 1 {
+			err := graphqlerrors.NewLocatedError(
+				fmt.Sprintf("List field %v.%v stopped streaming: %v.", info.ParentType, info.FieldName, ctx.Err()),
+				graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
+			)
+			panic(graphqlerrors.FormatError(err))
+		}
+		if !ok {
+			closed = true
+			break
+		}
+		if limit > 0 && i >= limit {
+			truncateList(eCtx, fieldASTs, info, path, 0, limit)
+			break
+		}
+		completedItem := completeValueCatchingError(eCtx, returnType.OfType, fieldASTs, info, val.Interface(), appendPath(path, i))
+		completedResults = append(completedResults, completedItem)
+	}
+	return completedResults
+}
+
+// Receives and discards the items of a channel until it is closed or the
+// context is done.
+func drainChannel(ctx context.Context, channel reflect.Value) {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: channel},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	}
+	for {
+		chosen, _, ok := reflect.Select(cases)
+		if chosen == 1 || !ok {
+			return
+		}
+	}
+}
+
+// The sub-fields collected for an object type and the first of the merged
+// field ASTs, a field AST being merged with others, e.g. when spread by a
+// fragment, those are compared too, see sameFieldASTs.
+type subFieldsKey struct {
+	objectType *types.GraphQLObjectType
+	fieldAST   *ast.Field
+}
+
+// The sub-fields of a field for an object type, see collectSubFields.
+type subFields struct {
+	fieldASTs     []*ast.Field
+	fields        map[string][]*ast.Field
+	responseNames []string
+}
+
+// Reports whether the merged field ASTs are the same nodes.
+func sameFieldASTs(a []*ast.Field, b []*ast.Field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Collects the sub-fields to execute to complete a value of the given object
+// type. The sub-fields only depend on the object type and the field ASTs, so
+// they are collected once per execution, e.g. for all the items of a list.
+func collectSubFields(eCtx *ExecutionContext, objectType *types.GraphQLObjectType, fieldASTs []*ast.Field) map[string][]*ast.Field {
+	return collectOrderedSubFields(eCtx, objectType, fieldASTs).fields
+}
+
+// Collects the sub-fields along with their response names in the order of
+// the query.
+func collectOrderedSubFields(eCtx *ExecutionContext, objectType *types.GraphQLObjectType, fieldASTs []*ast.Field) subFields {
+	var key subFieldsKey
+	if len(fieldASTs) > 0 {
+		key = subFieldsKey{objectType, fieldASTs[0]}
+		for _, collected := range eCtx.subFields[key] {
+			if sameFieldASTs(collected.fieldASTs, fieldASTs) {
+				return collected
+			}
+		}
+	}
+	collected := gatherSubFields(eCtx, objectType, fieldASTs)
+	if len(fieldASTs) > 0 {
+		if eCtx.subFields == nil {
+			eCtx.subFields = map[subFieldsKey][]subFields{}
+		}
+		eCtx.subFields[key] = append(eCtx.subFields[key], collected)
+	}
+	return collected
+}
+
+// Collects the sub-fields without the cache of the execution, e.g. for the
+// checks run before the options which change the collection, such as
+// StrictFields, are set.
+func gatherSubFields(eCtx *ExecutionContext, objectType *types.GraphQLObjectType, fieldASTs []*ast.Field) subFields {
+	collected := subFields{
+		fieldASTs:     fieldASTs,
+		fields:        map[string][]*ast.Field{},
+		responseNames: []string{},
+	}
 	visitedFragmentNames := map[string]bool{}
 	for _, fieldAST := range fieldASTs {
 		if fieldAST == nil {
@@ -674,22 +1380,68 @@ func completeValue(eCtx *ExecutionContext, returnType types.GraphQLType, fieldAS
 				ExeContext:           eCtx,
 				OperationType:        objectType,
 				SelectionSet:         selectionSet,
-				Fields:               subFieldASTs,
+				Fields:               collected.fields,
 				VisitedFragmentNames: visitedFragmentNames,
+				ResponseNames:        &collected.responseNames,
 			}
-			subFieldASTs = collectFields(innerParams)
+			collected.fields = collectFields(innerParams)
 		}
 	}
-	executeFieldsParams := ExecuteFieldsParams{
-		ExecutionContext: eCtx,
-		ParentType:       objectType,
-		Source:           result,
-		Fields:           subFieldASTs,
+	return collected
+}
+
+// Determines the runtime object type of a value of an abstract type, using
+// the type's resolveType function or else the isTypeOf function of its possible
+// types. Possible types are looked up in the schema, which also knows about the
+// implementations declared through interfaces thunks.
+func resolveAbstractType(eCtx *ExecutionContext, abstractType types.GraphQLType, value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+	switch ttype := abstractType.(type) {
+	case *types.GraphQLInterfaceType:
+		if ttype.ResolveType != nil {
+			return ttype.ResolveType(value, info)
+		}
+	case *types.GraphQLUnionType:
+		if ttype.ResolveType != nil {
+			return ttype.ResolveType(value, info)
+		}
+	case types.GraphQLAbstractType:
+		return ttype.GetObjectType(value, info)
 	}
-	results := executeFields(executeFieldsParams)
+	for _, possibleType := range eCtx.Schema.GetPossibleTypes(abstractType) {
+		if possibleType.IsTypeOf != nil && possibleType.IsTypeOf(value, info) {
+			return possibleType
+		}
+	}
+	return nil
+}
 
-	return results.Data
+// Returns a copy of the given response path extended with key, which is
+// either a response name or a list index.
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	newPath := make([]interface{}, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, key)
+}
+
+// Returns the number of nested fields in the response path, list indices
+// are not counted.
+func fieldDepth(path []interface{}) int {
+	depth := 0
+	for _, key := range path {
+		if _, ok := key.(string); ok {
+			depth++
+		}
+	}
+	return depth
+}
 
+// Sets the response path at which the error occurred, unless it was already
+// set closer to where the error was raised.
+func withPath(err graphqlerrors.GraphQLFormattedError, path []interface{}) graphqlerrors.GraphQLFormattedError {
+	if err.Path == nil {
+		err.Path = path
+	}
+	return err
 }
 
 func defaultResolveFn(p types.GQLFRParams) interface{} {
@@ -724,6 +1476,10 @@ func defaultResolveFn(p types.GQLFRParams) interface{} {
 			}
 			return valueField.Interface()
 		}
+		// then a method named after the field, see resolveMethod
+		if result, ok := resolveMethod(p); ok {
+			return result
+		}
 		return nil
 	}
 
@@ -768,8 +1524,5 @@ func getFieldDef(schema types.GraphQLSchema, parentType *types.GraphQLObjectType
 		schema.GetQueryType() == parentType {
 		return types.TypeMetaFieldDef
 	}
-	if fieldName == types.TypeNameMetaFieldDef.Name {
-		return types.TypeNameMetaFieldDef
-	}
-	return parentType.GetFields()[fieldName]
+	return parentType.GetField(fieldName)
 }
@@ -1,11 +1,20 @@
 package executor_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"github.com/chris-ramon/graphql-go/errors"
 	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/language/parser"
 	"github.com/chris-ramon/graphql-go/testutil"
 	"github.com/chris-ramon/graphql-go/types"
 	"reflect"
+	"runtime"
+	"sync"
 	"testing"
 )
 
@@ -20,14 +29,22 @@ type testPic struct {
 	Height string `json:"height"`
 }
 
-type testPicFn func(width, height string) *testPic
-
 type testAuthor struct {
 	Id            int          `json:"id"`
 	Name          string       `json:"name"`
-	Pic           testPicFn    `json:"pic"`
 	RecentArticle *testArticle `json:"recentArticle"`
 }
+
+// Resolves the `pic` field of the authors with the default resolver, which
+// binds the arguments of the field to the parameters in the order of the
+// field's ArgOrder.
+func (author *testAuthor) Pic(width, height int) (*testPic, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("Picture dimensions must be positive.")
+	}
+	return getPic(author.Id, fmt.Sprintf("%v", width), fmt.Sprintf("%v", height)), nil
+}
+
 type testArticle struct {
 	Id          string        `json:"id"`
 	IsPublished string        `json:"isPublished"`
@@ -65,11 +82,8 @@ func article(id interface{}) *testArticle {
 func TestExecutesUsingAComplexSchema(t *testing.T) {
 
 	johnSmith = &testAuthor{
-		Id:   123,
-		Name: "John Smith",
-		Pic: func(width string, height string) *testPic {
-			return getPic(123, width, height)
-		},
+		Id:            123,
+		Name:          "John Smith",
 		RecentArticle: article("1"),
 	}
 
@@ -106,14 +120,7 @@ func TestExecutesUsingAComplexSchema(t *testing.T) {
 						Type: types.GraphQLInt,
 					},
 				},
-				Resolve: func(p types.GQLFRParams) interface{} {
-					if author, ok := p.Source.(*testAuthor); ok {
-						width := fmt.Sprintf("%v", p.Args["width"])
-						height := fmt.Sprintf("%v", p.Args["height"])
-						return author.Pic(width, height)
-					}
-					return nil
-				},
+				ArgOrder: []string{"width", "height"},
 			},
 			"recentArticle": &types.GraphQLFieldConfig{},
 		},
@@ -312,4 +319,1103 @@ func TestExecutesUsingAComplexSchema(t *testing.T) {
 	if !reflect.DeepEqual(expected, result) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
+
+	// the first of the undefined fields of the fragment, `hidden` and
+	// `notdefined`, is an error of the article with StrictFields
+	ep.StrictFields = true
+	expected.Data.(map[string]interface{})["article"] = nil
+	expected.Errors = []graphqlerrors.GraphQLFormattedError{
+		{
+			Message: `Cannot query field "hidden" on type "Article".`,
+			Locations: []location.SourceLocation{
+				{Line: 30, Column: 9},
+			},
+			Path: []interface{}{"article"},
+		},
+	}
+	result = testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result with StrictFields, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+type testGallery struct {
+	Id int
+}
+
+type testCDNKey struct{}
+
+// Resolves the `thumbnail` field of the galleries, binding the arguments of
+// the field by name, whatever the order of the fields of args.
+func (gallery *testGallery) Thumbnail(ctx context.Context, args struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}) (*testPic, error) {
+	if args.Width <= 0 || args.Height <= 0 {
+		return nil, errors.New("Thumbnail dimensions must be positive.")
+	}
+	return &testPic{
+		Url:    fmt.Sprintf("%v://%v", ctx.Value(testCDNKey{}), gallery.Id),
+		Width:  fmt.Sprintf("%v", args.Width),
+		Height: fmt.Sprintf("%v", args.Height),
+	}, nil
+}
+
+// Resolves the `cover` field of the galleries, binding the arguments of the
+// field by position, in the order of the field's ArgOrder.
+func (gallery *testGallery) Cover(width, height int) *testPic {
+	return &testPic{
+		Url:    fmt.Sprintf("cdn://%v", gallery.Id),
+		Width:  fmt.Sprintf("%v", width),
+		Height: fmt.Sprintf("%v", height),
+	}
+}
+
+// Cannot resolve the `frame` field, which has fewer arguments than the
+// parameters of the method.
+func (gallery *testGallery) Frame(width, height, depth int) *testPic {
+	return &testPic{}
+}
+
+func TestExecutesMethodsBindingArgumentsByNameOrPosition(t *testing.T) {
+	blogImage := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Image",
+		Fields: types.GraphQLFieldConfigMap{
+			"url": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"width": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+			},
+			"height": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+			},
+		},
+	})
+	blogGallery := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Gallery",
+		Fields: types.GraphQLFieldConfigMap{
+			"thumbnail": &types.GraphQLFieldConfig{
+				Type: blogImage,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"width": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLInt,
+					},
+					"height": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLInt,
+					},
+				},
+			},
+			"cover": &types.GraphQLFieldConfig{
+				Type: blogImage,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"width": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLInt,
+					},
+					"height": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLInt,
+					},
+				},
+				ArgOrder: []string{"width", "height"},
+			},
+			"frame": &types.GraphQLFieldConfig{
+				Type: blogImage,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"width": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLInt,
+					},
+					"height": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLInt,
+					},
+				},
+			},
+		},
+	})
+	blogSchema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"gallery": &types.GraphQLFieldConfig{
+					Type: blogGallery,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return &testGallery{Id: 7}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	request := `{
+        gallery {
+          thumbnail(width: 64, height: 32) { url, width, height }
+          empty: thumbnail(width: 0) { url }
+          cover(width: 64, height: 32) { url, width, height }
+          frame(width: 64, height: 32) { url }
+        }
+      }`
+	frameError := `Method Frame of *executor_test.testGallery cannot resolve field "frame", it takes more parameters than the field has arguments.`
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"gallery": map[string]interface{}{
+				"thumbnail": map[string]interface{}{
+					"url":    "cdn://7",
+					"width":  64,
+					"height": 32,
+				},
+				"empty": nil,
+				"cover": map[string]interface{}{
+					"url":    "cdn://7",
+					"width":  64,
+					"height": 32,
+				},
+				"frame": nil,
+			},
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			{
+				Message: "Thumbnail dimensions must be positive.",
+				Locations: []location.SourceLocation{
+					{Line: 4, Column: 11},
+				},
+				Path:          []interface{}{"gallery", "empty"},
+				OriginalError: errors.New("Thumbnail dimensions must be positive."),
+			},
+			{
+				Message: frameError,
+				Locations: []location.SourceLocation{
+					{Line: 6, Column: 11},
+				},
+				Path:          []interface{}{"gallery", "frame"},
+				OriginalError: errors.New(frameError),
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema:  blogSchema,
+		AST:     testutil.Parse(t, request),
+		Context: context.WithValue(context.Background(), testCDNKey{}, "cdn"),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesSiblingsOfAFieldWhoseResolverFails(t *testing.T) {
+	blogImage := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Image",
+		Fields: types.GraphQLFieldConfigMap{
+			"url": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	blogAuthor := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Author",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"pic": &types.GraphQLFieldConfig{
+				Type: blogImage,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return errors.New("Image service unavailable")
+				},
+			},
+			"joined": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLScalarType(types.GraphQLScalarTypeConfig{
+					Name: "Date",
+					Serialize: func(value interface{}) interface{} {
+						panic("Cannot serialize a date")
+					},
+					ParseValue: func(value interface{}) interface{} {
+						return nil
+					},
+					ParseLiteral: func(valueAST ast.Value) interface{} {
+						return nil
+					},
+				}),
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return "2015-07-01"
+				},
+			},
+		},
+	})
+	blogArticle := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"body": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"author": &types.GraphQLFieldConfig{
+				Type: blogAuthor,
+			},
+		},
+	})
+	blogSchema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: blogArticle,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return &testArticle{
+							Title:  "My Article 1",
+							Body:   "This is a post",
+							Author: &testAuthor{Id: 123, Name: "John Smith"},
+						}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	request := `{
+        article {
+          title,
+          author { id, name, pic { url }, joined },
+          body
+        }
+      }`
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": map[string]interface{}{
+				"title": "My Article 1",
+				"author": map[string]interface{}{
+					"id":     "123",
+					"name":   "John Smith",
+					"pic":    nil,
+					"joined": nil,
+				},
+				"body": "This is a post",
+			},
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			{
+				Message: "Image service unavailable",
+				Locations: []location.SourceLocation{
+					{Line: 4, Column: 30},
+				},
+				Path:          []interface{}{"article", "author", "pic"},
+				OriginalError: errors.New("Image service unavailable"),
+			},
+			{
+				Message: "Cannot serialize a date",
+				Locations: []location.SourceLocation{
+					{Line: 4, Column: 43},
+				},
+				Path: []interface{}{"article", "author", "joined"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: blogSchema,
+		AST:    testutil.Parse(t, request),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesTheSubSelectionOfANilObjectAsNull(t *testing.T) {
+	resolvedSources := []interface{}{}
+	blogArticle := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+			},
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					resolvedSources = append(resolvedSources, p.Source)
+					return p.Source.(*testArticle).Title
+				},
+			},
+		},
+	})
+	blogAuthor := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Author",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"recentArticle": &types.GraphQLFieldConfig{
+				Type: blogArticle,
+			},
+		},
+	})
+	blogSchema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"author": &types.GraphQLFieldConfig{
+					Type: blogAuthor,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return &testAuthor{Name: "John Smith"}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	ep := executor.ExecuteParams{
+		Schema: blogSchema,
+		AST: testutil.Parse(t, `{
+        author { name, recentArticle { id, title } }
+      }`),
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"author": map[string]interface{}{
+				"name":          "John Smith",
+				"recentArticle": nil,
+			},
+		},
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if len(resolvedSources) > 0 {
+		t.Fatalf("Expected the fields of the nil article not to be resolved, got sources %v", resolvedSources)
+	}
+
+	ep.PanicOnNilSource = true
+	expected.Errors = []graphqlerrors.GraphQLFormattedError{
+		{
+			Message: `Field Author.recentArticle of type "Article" resolved to a nil *executor_test.testArticle.`,
+			Locations: []location.SourceLocation{
+				{Line: 2, Column: 24},
+			},
+			Path: []interface{}{"author", "recentArticle"},
+		},
+	}
+	result = testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result with PanicOnNilSource, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesWithIDVariableBoundToNumberOrString(t *testing.T) {
+
+	blogArticle := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLID),
+			},
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	blogQuery := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"article": &types.GraphQLFieldConfig{
+				Type: blogArticle,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"id": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLID,
+					},
+				},
+				Resolve: func(p types.GQLFRParams) interface{} {
+					id := p.Args["id"]
+					return article(id)
+				},
+			},
+		},
+	})
+	blogSchema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: blogQuery,
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	request := `
+      query ArticleQuery($id: ID) {
+        article(id: $id) {
+          id,
+          title
+        }
+      }
+	`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": map[string]interface{}{
+				"id":    "1",
+				"title": "My Article 1",
+			},
+		},
+	}
+
+	// parse query
+	ast := testutil.Parse(t, request)
+
+	for _, id := range []interface{}{float64(1), "1"} {
+		// execute
+		ep := executor.ExecuteParams{
+			Schema: blogSchema,
+			AST:    ast,
+			Args: map[string]interface{}{
+				"id": id,
+			},
+		}
+		result := testutil.Execute(t, ep)
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result for id %#v, Diff: %v", id, testutil.Diff(expected, result))
+		}
+	}
+}
+
+func blogFeedSchema() (types.GraphQLSchema, error) {
+	blogAuthor := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Author",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	blogArticle := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+			},
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"author": &types.GraphQLFieldConfig{
+				Type: blogAuthor,
+			},
+		},
+	})
+	blogQuery := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"feed": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLList(blogArticle),
+				Resolve: func(p types.GQLFRParams) interface{} {
+					feed := []*testArticle{}
+					for i := 1; i <= 10; i++ {
+						feed = append(feed, &testArticle{
+							Id:     fmt.Sprintf("%v", i),
+							Title:  fmt.Sprintf("My Article %v", i),
+							Author: &testAuthor{Id: 123, Name: "John Smith"},
+						})
+					}
+					return feed
+				},
+			},
+		},
+	})
+	return types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: blogQuery,
+	})
+}
+
+const blogFeedQuery = `
+  {
+    feed {
+      id,
+      title,
+      author { id, name }
+    }
+  }
+`
+
+func TestExecutesUndefinedFieldsAsErrorsWithStrictFields(t *testing.T) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	ep := executor.ExecuteParams{
+		Schema: blogSchema,
+		AST:    testutil.Parse(t, `{ __typename, notdefined }`),
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"__typename": "Query",
+		},
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	ep.StrictFields = true
+	expected = &types.GraphQLResult{
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			{
+				Message: `Cannot query field "notdefined" on type "Query".`,
+				Locations: []location.SourceLocation{
+					{Line: 1, Column: 15},
+				},
+			},
+		},
+	}
+	result = testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result with StrictFields, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesTheIntrospectionFieldsOnlyOnTheQueryTypeOfTheSchema(t *testing.T) {
+	queryType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"a": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: queryType,
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	// the query type of the first schema is a nested type of the second
+	nestingSchema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Root",
+			Fields: types.GraphQLFieldConfigMap{
+				"nested": &types.GraphQLFieldConfig{
+					Type: queryType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return map[string]interface{}{"a": "b"}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	ep := executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ __schema { queryType { name } } }`),
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"__schema": map[string]interface{}{
+				"queryType": map[string]interface{}{
+					"name": "Query",
+				},
+			},
+		},
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	ep = executor.ExecuteParams{
+		Schema: nestingSchema,
+		AST:    testutil.Parse(t, `{ nested { a, __schema { queryType { name } } } }`),
+	}
+	ep.StrictFields = true
+	expected = &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"nested": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			{
+				Message: `Cannot query field "__schema" on type "Query".`,
+				Locations: []location.SourceLocation{
+					{Line: 1, Column: 15},
+				},
+				Path: []interface{}{"nested"},
+			},
+		},
+	}
+	result = testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result with StrictFields, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesUndefinedFieldsAsErrorsWithStrictFieldsAndOperationLimits(t *testing.T) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	ep := executor.ExecuteParams{
+		Schema: blogSchema,
+		AST:    testutil.Parse(t, `{ feed { id, notdefined } }`),
+	}
+	ep.StrictFields = true
+	expected := testutil.Execute(t, ep)
+	if len(expected.Errors) == 0 {
+		t.Fatalf("Expected the undefined field to be an error with StrictFields, got %v", expected)
+	}
+	for _, err := range expected.Errors {
+		if err.Message != `Cannot query field "notdefined" on type "Article".` {
+			t.Fatalf("Unexpected error with StrictFields: %v", err.Message)
+		}
+	}
+
+	// the checks of the limits, which collect the fields first, do not
+	// leave the undefined field out of the execution, the extensions
+	// reporting the complexity aside
+	limits := map[string]executor.ExecuteOptions{
+		"MaxComplexity":          {StrictFields: true, MaxComplexity: 100},
+		"MaxDepth":               {StrictFields: true, MaxDepth: 10},
+		"MaxComplexity,MaxDepth": {StrictFields: true, MaxComplexity: 100, MaxDepth: 10},
+	}
+	for name, options := range limits {
+		ep.ExecuteOptions = options
+		result := testutil.Execute(t, ep)
+		result.Extensions = nil
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result with StrictFields and %v, Diff: %v", name, testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestExecutesListsLongerThanMaxListSize(t *testing.T) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	ep := executor.ExecuteParams{
+		Schema: blogSchema,
+		AST:    testutil.Parse(t, `{ feed { id } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxListSize: 3,
+		},
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"feed": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			{
+				Message: "List field Query.feed resolved more than 3 items.",
+				Locations: []location.SourceLocation{
+					{Line: 1, Column: 3},
+				},
+				Path: []interface{}{"feed"},
+			},
+		},
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	ep.ListSizePolicy = executor.ListSizeTruncate
+	expected = &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"feed": []interface{}{
+				map[string]interface{}{"id": "1"},
+				map[string]interface{}{"id": "2"},
+				map[string]interface{}{"id": "3"},
+			},
+		},
+		Extensions: map[string]interface{}{
+			"truncatedLists": []interface{}{
+				map[string]interface{}{
+					"path":  []interface{}{"feed"},
+					"size":  10,
+					"limit": 3,
+				},
+			},
+		},
+	}
+	result = testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected truncated result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesListsLongerThanTheMaxListSizeOfTheirField(t *testing.T) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	feed := blogSchema.GetQueryType().GetFields()["feed"]
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"feed": &types.GraphQLFieldConfig{
+					Type:        feed.Type,
+					Resolve:     feed.Resolve,
+					MaxListSize: 2,
+				},
+				"recentFeed": &types.GraphQLFieldConfig{
+					Type:    feed.Type,
+					Resolve: feed.Resolve,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// the limit of the field overrides the limit of the execution
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"feed": []interface{}{
+				map[string]interface{}{"id": "1"},
+				map[string]interface{}{"id": "2"},
+			},
+			"recentFeed": []interface{}{
+				map[string]interface{}{"id": "1"},
+				map[string]interface{}{"id": "2"},
+				map[string]interface{}{"id": "3"},
+				map[string]interface{}{"id": "4"},
+			},
+		},
+		Extensions: map[string]interface{}{
+			"truncatedLists": []interface{}{
+				map[string]interface{}{
+					"path":  []interface{}{"feed"},
+					"size":  10,
+					"limit": 2,
+				},
+				map[string]interface{}{
+					"path":  []interface{}{"recentFeed"},
+					"size":  10,
+					"limit": 4,
+				},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ feed { id }, recentFeed { id } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxListSize:    4,
+			ListSizePolicy: executor.ListSizeTruncate,
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesConcurrentlyAgainstTheSameSchema(t *testing.T) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	ast := testutil.Parse(t, blogFeedQuery)
+
+	var wg sync.WaitGroup
+	results := make([]*types.GraphQLResult, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = testutil.Execute(t, executor.ExecuteParams{
+				Schema: blogSchema,
+				AST:    ast,
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		if !reflect.DeepEqual(results[0], result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(results[0], result))
+		}
+	}
+}
+
+func TestExecutesAFragmentFieldMergedDifferentlyUnderTwoParents(t *testing.T) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	ep := executor.ExecuteParams{
+		Schema: blogSchema,
+		AST: testutil.Parse(t, `
+      {
+        a: feed { ...F, author { id } }
+        b: feed { ...F }
+      }
+      fragment F on Article { author { name } }
+    `),
+	}
+
+	result := testutil.Execute(t, ep)
+	if len(result.Errors) != 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	data := result.Data.(map[string]interface{})
+	expected := map[string]map[string]interface{}{
+		"a": map[string]interface{}{"name": "John Smith", "id": "123"},
+		"b": map[string]interface{}{"name": "John Smith"},
+	}
+	for alias, author := range expected {
+		for _, article := range data[alias].([]interface{}) {
+			got := article.(map[string]interface{})["author"]
+			if !reflect.DeepEqual(author, got) {
+				t.Fatalf("Unexpected author under %v, Diff: %v", alias, testutil.Diff(author, got))
+			}
+		}
+	}
+}
+
+// The field maps of the types are memoized across executions, the
+// sub-fields of the feed articles are collected once per execution.
+func BenchmarkExecutesBlogFeedQuery(b *testing.B) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		b.Fatalf("Error in schema %v", err.Error())
+	}
+	ast, err := parser.Parse(parser.ParseParams{
+		Source: blogFeedQuery,
+	})
+	if err != nil {
+		b.Fatalf("Error in query %v", err.Error())
+	}
+	ep := executor.ExecuteParams{
+		Schema: blogSchema,
+		AST:    ast,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resultChannel := make(chan *types.GraphQLResult)
+		go executor.Execute(ep, resultChannel)
+		<-resultChannel
+	}
+}
+
+func TestCollectsNamedSpreadsNestedInInlineFragments(t *testing.T) {
+	author := &testAuthor{Id: 123, Name: "John Smith"}
+	post := &testArticle{Id: "1", Title: "My Article 1", Author: author}
+
+	blogAuthor := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Author",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+			},
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	blogArticle := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+			},
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"author": &types.GraphQLFieldConfig{
+				Type: blogAuthor,
+			},
+		},
+	})
+	searchResult := types.NewGraphQLUnionType(types.GraphQLUnionTypeConfig{
+		Name:  "SearchResult",
+		Types: []*types.GraphQLObjectType{blogArticle, blogAuthor},
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			if _, ok := value.(*testAuthor); ok {
+				return blogAuthor
+			}
+			return blogArticle
+		},
+	})
+	blogSchema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"search": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(searchResult),
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return []interface{}{post, author}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// the inline fragments on Author are dropped for the article, along with
+	// the spreads within them
+	request := `
+      {
+        search {
+          ... on Author {
+            ...authorFields
+          }
+          ... on Article {
+            title
+            ... on Article {
+              ...articleAuthor
+            }
+            ... on Author {
+              ...authorFields
+            }
+          }
+        }
+      }
+
+      fragment authorFields on Author {
+        name
+        ... on Author {
+          id
+        }
+      }
+
+      fragment articleAuthor on Article {
+        author {
+          ...authorFields
+        }
+      }
+	`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"search": []interface{}{
+				map[string]interface{}{
+					"title": "My Article 1",
+					"author": map[string]interface{}{
+						"name": "John Smith",
+						"id":   "123",
+					},
+				},
+				map[string]interface{}{
+					"name": "John Smith",
+					"id":   "123",
+				},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: blogSchema,
+		AST:    testutil.Parse(t, request),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecutesAFieldWhoseResolverDereferencesANilPointer(t *testing.T) {
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"authorName": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Source.(*testArticle).Author.Name
+				},
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: articleType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return &testArticle{Title: "My Article 1"}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	request := `{
+        article {
+          authorName
+          title
+        }
+      }`
+	expectedData := map[string]interface{}{
+		"article": map[string]interface{}{
+			"authorName": nil,
+			"title":      "My Article 1",
+		},
+	}
+	assertPanicError := func(result *types.GraphQLResult) {
+		if !reflect.DeepEqual(expectedData, result.Data) {
+			t.Fatalf("Unexpected data, Diff: %v", testutil.Diff(expectedData, result.Data))
+		}
+		if len(result.Errors) != 1 {
+			t.Fatalf("Expected an error, got %v", result.Errors)
+		}
+		expected := graphqlerrors.GraphQLFormattedError{
+			Message:   "runtime error: invalid memory address or nil pointer dereference",
+			Locations: []location.SourceLocation{{Line: 3, Column: 11}},
+			Path:      []interface{}{"article", "authorName"},
+		}
+		err := result.Errors[0]
+		err.OriginalError = nil
+		if !reflect.DeepEqual(expected, err) {
+			t.Fatalf("Unexpected error, Diff: %v", testutil.Diff(expected, err))
+		}
+		var runtimeErr runtime.Error
+		if !errors.As(result.Errors[0], &runtimeErr) {
+			t.Fatalf("Expected the original error to be a runtime.Error, got %#v", result.Errors[0].OriginalError)
+		}
+	}
+
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, request),
+	})
+	assertPanicError(result)
+
+	result = testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, request),
+		ExecuteOptions: executor.ExecuteOptions{
+			CapturePanicStack: true,
+		},
+	})
+	assertPanicError(result)
+	panicErr, ok := result.Errors[0].OriginalError.(*executor.PanicError)
+	if !ok {
+		t.Fatalf("Expected a *executor.PanicError, got %#v", result.Errors[0].OriginalError)
+	}
+	if !bytes.Contains(panicErr.Stack, []byte("TestExecutesAFieldWhoseResolverDereferencesANilPointer")) {
+		t.Fatalf("Expected the stack of the resolver, got %s", panicErr.Stack)
+	}
 }
@@ -2,14 +2,17 @@ package executor_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/chris-ramon/graphql-go/errors"
 	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/ast"
 	"github.com/chris-ramon/graphql-go/language/location"
 	"github.com/chris-ramon/graphql-go/testutil"
 	"github.com/chris-ramon/graphql-go/types"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestExecutesArbitraryCode(t *testing.T) {
@@ -109,8 +112,7 @@ func TestExecutesArbitraryCode(t *testing.T) {
 		if !ok {
 			return nil
 		}
-		// get and type assert argument
-		sizeArg, ok := p.Args["size"].(int)
+		sizeArg, ok := p.IntArg("size")
 		if !ok {
 			return nil
 		}
@@ -423,6 +425,7 @@ func TestNullsOutErrorSubtrees(t *testing.T) {
 					Line: 3, Column: 7,
 				},
 			},
+			Path: []interface{}{"syncError"},
 		},
 	}
 
@@ -472,6 +475,68 @@ func TestNullsOutErrorSubtrees(t *testing.T) {
 	}
 }
 
+func TestMarshalsPartialFailureResultInResponseFormat(t *testing.T) {
+
+	query := `{
+      sync,
+      nest { syncError }
+    }`
+
+	expected := `{"data":{"nest":{"syncError":null},"sync":"sync"},` +
+		`"errors":[{"message":"Error getting syncError","locations":[{"line":3,"column":14}],"path":["nest","syncError"]}]}`
+
+	nestType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Nest",
+		Fields: types.GraphQLFieldConfigMap{
+			"syncError": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					panic("Error getting syncError")
+				},
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Type",
+			Fields: types.GraphQLFieldConfigMap{
+				"sync": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return "sync"
+					},
+				},
+				"nest": &types.GraphQLFieldConfig{
+					Type: nestType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return map[string]interface{}{}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// parse query
+	ast := testutil.Parse(t, query)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: schema,
+		AST:    ast,
+	}
+	result := testutil.Execute(t, ep)
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Unexpected error marshaling result: %v", err)
+	}
+	if string(b) != expected {
+		t.Fatalf("Unexpected JSON, expected: %v, got: %v", expected, string(b))
+	}
+}
+
 func TestUsesTheInlineOperationIfNoOperationIsProvided(t *testing.T) {
 
 	doc := `{ a }`
@@ -721,6 +786,60 @@ func TestUsesTheMutationSchemaForQueries(t *testing.T) {
 	}
 }
 
+func TestDoesNotExecuteSubscriptionOperations(t *testing.T) {
+	queryType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Q",
+		Fields: types.GraphQLFieldConfigMap{
+			"a": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	subscriptionType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "S",
+		Fields: types.GraphQLFieldConfigMap{
+			"b": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	tests := []struct {
+		config  types.GraphQLSchemaConfig
+		message string
+	}{
+		{
+			config:  types.GraphQLSchemaConfig{Query: queryType},
+			message: "Schema is not configured for subscriptions",
+		},
+		{
+			config:  types.GraphQLSchemaConfig{Query: queryType, Subscription: subscriptionType},
+			message: "Can only execute queries and mutations",
+		},
+	}
+	for _, test := range tests {
+		schema, err := types.NewGraphQLSchema(test.config)
+		if err != nil {
+			t.Fatalf("Error in schema %v", err.Error())
+		}
+		expected := &types.GraphQLResult{
+			Errors: []graphqlerrors.GraphQLFormattedError{
+				graphqlerrors.GraphQLFormattedError{
+					Message:   test.message,
+					Locations: []location.SourceLocation{},
+				},
+			},
+		}
+		ep := executor.ExecuteParams{
+			Schema: schema,
+			AST:    testutil.Parse(t, `subscription S { b }`),
+		}
+		result := testutil.Execute(t, ep)
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+}
+
 func TestCorrectFieldOrderingDespiteExecutionOrder(t *testing.T) {
 
 	doc := `
@@ -1004,6 +1123,7 @@ func TestFailsWhenAnIsTypeOfCheckIsNotMet(t *testing.T) {
 			graphqlerrors.GraphQLFormattedError{
 				Message:   `Expected value of type "SpecialType" but got: executor_test.testNotSpecialType.`,
 				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"specials", 1},
 			},
 		},
 	}
@@ -1107,3 +1227,550 @@ func TestFailsToExecuteQueryContainingATypeDefinition(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestUsesCompletedValueReturnedByResolverVerbatim(t *testing.T) {
+
+	query := `{ cached { a b { c } } }`
+
+	cachedSubtree := map[string]interface{}{
+		"a": "Apple",
+		"b": map[string]interface{}{
+			"c": "Cookie",
+		},
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"cached": cachedSubtree,
+		},
+	}
+
+	subType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Sub",
+		Fields: types.GraphQLFieldConfigMap{
+			"c": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					panic("sub-fields of a completed value must not be resolved")
+				},
+			},
+		},
+	})
+	cachedType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Cached",
+		Fields: types.GraphQLFieldConfigMap{
+			"a": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					panic("sub-fields of a completed value must not be resolved")
+				},
+			},
+			"b": &types.GraphQLFieldConfig{
+				Type: subType,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"cached": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLNonNull(cachedType),
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return types.CompleteValue(cachedSubtree)
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// parse query
+	ast := testutil.Parse(t, query)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: schema,
+		AST:    ast,
+	}
+	result := testutil.Execute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestStopsRunawayRecursionAtMaxExecutionDepth(t *testing.T) {
+
+	// fragment spreads itself within its sub-selection, which would recurse
+	// infinitely without a depth ceiling
+	query := `{
+      author { ...AuthorFields }
+    }
+    fragment AuthorFields on Author {
+      self { ...AuthorFields }
+    }`
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"author": map[string]interface{}{
+				"self": map[string]interface{}{
+					"self": map[string]interface{}{
+						"self": nil,
+					},
+				},
+			},
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: "Exceeded maximum execution depth of 3.",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 5, Column: 7},
+				},
+				Path: []interface{}{"author", "self", "self", "self"},
+			},
+		},
+	}
+
+	authorType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:   "Author",
+		Fields: types.GraphQLFieldConfigMap{},
+	})
+	authorType.AddFieldConfig("self", &types.GraphQLFieldConfig{
+		Type: authorType,
+		Resolve: func(p types.GQLFRParams) interface{} {
+			return p.Source
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"author": &types.GraphQLFieldConfig{
+					Type: authorType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return map[string]interface{}{}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// parse query
+	ast := testutil.Parse(t, query)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: schema,
+		AST:    ast,
+		ExecuteOptions: executor.ExecuteOptions{
+			MaxExecutionDepth: 3,
+		},
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSerializesStringersWithTheirStringFormUnlessTheScalarOverridesIt(t *testing.T) {
+	durationType := types.NewGraphQLScalarType(types.GraphQLScalarTypeConfig{
+		Name: "Seconds",
+		Serialize: func(value interface{}) interface{} {
+			if value, ok := value.(time.Duration); ok {
+				return value.Seconds()
+			}
+			return nil
+		},
+		ParseValue: func(value interface{}) interface{} {
+			return nil
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			return nil
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"timeout": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return 90 * time.Second
+					},
+				},
+				"timeoutSeconds": &types.GraphQLFieldConfig{
+					Type: durationType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return 90 * time.Second
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"timeout":        "1m30s",
+			"timeoutSeconds": float64(90),
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ timeout, timeoutSeconds }`),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+type executorTestPermissionError struct {
+	User string
+}
+
+func (e *executorTestPermissionError) Error() string {
+	return fmt.Sprintf("%v is not allowed to read the secret", e.User)
+}
+
+func TestKeepsTheOriginalErrorOfAResolver(t *testing.T) {
+	originalError := &executorTestPermissionError{User: "Jane"}
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"secret": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						panic(fmt.Errorf("Unable to resolve: %w", originalError))
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ secret }`),
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected one error, got: %v", result.Errors)
+	}
+	resultErr := result.Errors[0]
+	expectedLocations := []location.SourceLocation{
+		location.SourceLocation{Line: 1, Column: 3},
+	}
+	if !reflect.DeepEqual(expectedLocations, resultErr.Locations) {
+		t.Fatalf("Unexpected locations, Diff: %v", testutil.Diff(expectedLocations, resultErr.Locations))
+	}
+	if !reflect.DeepEqual([]interface{}{"secret"}, resultErr.Path) {
+		t.Fatalf("Unexpected path: %v", resultErr.Path)
+	}
+	var permissionError *executorTestPermissionError
+	if !errors.As(resultErr, &permissionError) || permissionError != originalError {
+		t.Fatalf("Expected the error to unwrap to the original error, got: %v", resultErr.OriginalError)
+	}
+	if b, err := json.Marshal(resultErr); err != nil || string(b) != `{"message":"Unable to resolve: Jane is not allowed to read the secret","locations":[{"line":1,"column":3}],"path":["secret"]}` {
+		t.Fatalf("Unexpected JSON: %s, %v", b, err)
+	}
+}
+
+func TestFormatsErrorsWithTheErrorFormatter(t *testing.T) {
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+						Name: "Article",
+						Fields: types.GraphQLFieldConfigMap{
+							"title": &types.GraphQLFieldConfig{
+								Type: types.GraphQLString,
+								Resolve: func(p types.GQLFRParams) interface{} {
+									panic(errors.New("dial tcp 10.0.0.3:5432: connection refused"))
+								},
+							},
+						},
+					}),
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return map[string]interface{}{}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": map[string]interface{}{
+				"title": nil,
+			},
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: "Internal server error",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 1, Column: 13},
+				},
+				Path: []interface{}{"article", "title"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ article { title } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			ErrorFormatter: func(err graphqlerrors.GraphQLFormattedError) graphqlerrors.GraphQLFormattedError {
+				if err.OriginalError != nil {
+					err.Message = "Internal server error"
+					err.OriginalError = nil
+				}
+				return err
+			},
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestProvidesThePathOfTheFieldToItsResolver(t *testing.T) {
+	paths := [][]interface{}{}
+	authorType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Author",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					paths = append(paths, p.Info.Path)
+					return "John"
+				},
+			},
+		},
+	})
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"author": &types.GraphQLFieldConfig{
+				Type: authorType,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return map[string]interface{}{}
+				},
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"feed": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(articleType),
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return []interface{}{map[string]interface{}{}, map[string]interface{}{}}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ articles: feed { writer: author { name } } }`),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expected := [][]interface{}{
+		[]interface{}{"articles", 0, "writer", "name"},
+		[]interface{}{"articles", 1, "writer", "name"},
+	}
+	if !reflect.DeepEqual(expected, paths) {
+		t.Fatalf("Unexpected paths, Diff: %v", testutil.Diff(expected, paths))
+	}
+}
+
+var intRangeTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"echo": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"value": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLInt,
+					},
+				},
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return p.Args["value"]
+				},
+			},
+			"big": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return 2147483648
+				},
+			},
+		},
+	}),
+})
+
+func TestRejectsAnOutOfRangeIntLiteralArgument(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"max":  2147483647,
+			"echo": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Argument "value" has invalid value: Expected type "Int", found 2147483648.`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 1, Column: 44},
+				},
+				Path:         []interface{}{"echo"},
+				ArgumentPath: []string{"value"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: intRangeTestSchema,
+		AST:    testutil.Parse(t, `{ max: echo(value: 2147483647) echo(value: 2147483648) }`),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSerializesAnOutOfRangeIntAsNull(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"big": nil,
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: intRangeTestSchema,
+		AST:    testutil.Parse(t, `{ big }`),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRoundTripsFloatsThroughArgumentsAndResolvers(t *testing.T) {
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"price": &types.GraphQLFieldConfig{
+					Type: types.GraphQLFloat,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"precision": &types.GraphQLArgumentConfig{
+							Type:         types.GraphQLFloat,
+							DefaultValue: 0.01,
+						},
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						if precision, ok := p.Args["precision"].(float64); ok {
+							return precision
+						}
+						return nil
+					},
+				},
+				"ratio": &types.GraphQLFieldConfig{
+					Type: types.GraphQLFloat,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return float32(0.5)
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	if schema.GetType("Float") != types.GraphQLFloat {
+		t.Fatalf("Expected Float in the type map")
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"default":  0.01,
+			"literal":  1.1,
+			"int":      float64(2),
+			"variable": 0.25,
+			"ratio":    0.5,
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST: testutil.Parse(t, `query Q($precision: Float) {
+			default: price
+			literal: price(precision: 1.1)
+			int: price(precision: 2)
+			variable: price(precision: $precision)
+			ratio
+		}`),
+		Args: map[string]interface{}{"precision": 0.25},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestOrdersTheResultAsTheFieldsOfTheQuery(t *testing.T) {
+	query := `
+      query HeroQuery {
+        hero {
+          name
+          ...heroID
+          friends { name id }
+        }
+        droid: hero(episode: EMPIRE) { id }
+      }
+      fragment heroID on Character { id }
+	`
+	expected := `{"data":{"hero":{"name":"R2-D2","id":"2001","friends":[` +
+		`{"name":"Luke Skywalker","id":"1000"},{"name":"Han Solo","id":"1002"},{"name":"Leia Organa","id":"1003"}]},` +
+		`"droid":{"id":"1000"}}}`
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: testutil.StarWarsSchema,
+		AST:    testutil.Parse(t, query),
+		ExecuteOptions: executor.ExecuteOptions{
+			OrderedResult: true,
+		},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(b) != expected {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, string(b)))
+	}
+
+	unordered := testutil.Execute(t, executor.ExecuteParams{
+		Schema: testutil.StarWarsSchema,
+		AST:    testutil.Parse(t, query),
+	})
+	data := result.Data.(*types.OrderedMap).Map()
+	if !reflect.DeepEqual(unordered.Data, data) {
+		t.Fatalf("Unexpected data, Diff: %v", testutil.Diff(unordered.Data, data))
+	}
+}
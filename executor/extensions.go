@@ -0,0 +1,57 @@
+package executor
+
+import (
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Extension hooks into the execution of a request to collect data, e.g.
+// tracing or metrics, which is reported in the result's `extensions` entry
+// under the extension's name. An extension is created for each execution by
+// its ExtensionFactory.
+type Extension interface {
+	// Name of the entry in the result's extensions.
+	Name() string
+
+	// Init is called before the execution context is built.
+	Init(p ExecuteParams)
+
+	// ExecutionDidStart is called before the operation's root fields are
+	// resolved.
+	ExecutionDidStart(eCtx *ExecutionContext)
+
+	// ResolveField is called before a field's resolve function. The returned
+	// function, if any, is called with the resolved value afterwards, nil if
+	// the resolve function panicked.
+	ResolveField(info types.GraphQLResolveInfo) func(result interface{})
+
+	// Result is the data reported for this extension.
+	Result() interface{}
+}
+
+// ExtensionFactory creates an extension for an execution, so that the data
+// it collects is that of the execution only, e.g.
+//
+//	func() executor.Extension { return &timingExtension{} }
+type ExtensionFactory func() Extension
+
+// Collects the data reported by each extension and the tracing data, returns
+// nil if there is none so that the result omits them.
+func (eCtx *ExecutionContext) extensionsResult() map[string]interface{} {
+	if len(eCtx.Extensions) == 0 && eCtx.tracing == nil && eCtx.MaxComplexity == 0 && len(eCtx.truncatedLists) == 0 {
+		return nil
+	}
+	extensions := map[string]interface{}{}
+	for _, extension := range eCtx.Extensions {
+		extensions[extension.Name()] = extension.Result()
+	}
+	if eCtx.tracing != nil {
+		extensions["tracing"] = eCtx.tracing.result()
+	}
+	if eCtx.MaxComplexity > 0 {
+		extensions["complexity"] = eCtx.complexity
+	}
+	if len(eCtx.truncatedLists) > 0 {
+		extensions["truncatedLists"] = eCtx.truncatedLists
+	}
+	return extensions
+}
@@ -0,0 +1,149 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// testCountingExtension counts the calls to each of its hooks and records
+// the resolved fields.
+type testCountingExtension struct {
+	inits          int
+	executions     int
+	resolvedFields []string
+}
+
+func (ext *testCountingExtension) Name() string {
+	return "counting"
+}
+func (ext *testCountingExtension) Init(p executor.ExecuteParams) {
+	ext.inits++
+}
+func (ext *testCountingExtension) ExecutionDidStart(eCtx *executor.ExecutionContext) {
+	ext.executions++
+}
+func (ext *testCountingExtension) ResolveField(info types.GraphQLResolveInfo) func(result interface{}) {
+	return func(result interface{}) {
+		ext.resolvedFields = append(ext.resolvedFields, info.FieldName)
+	}
+}
+func (ext *testCountingExtension) Result() interface{} {
+	return map[string]interface{}{
+		"inits":          ext.inits,
+		"executions":     ext.executions,
+		"resolvedFields": ext.resolvedFields,
+	}
+}
+
+var extensionsTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"a": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return "Apple"
+				},
+			},
+		},
+	}),
+})
+
+func TestExtensions_ReportsDataCollectedByExtensions(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"a": "Apple",
+		},
+		Extensions: map[string]interface{}{
+			"counting": map[string]interface{}{
+				"inits":          1,
+				"executions":     1,
+				"resolvedFields": []string{"a"},
+			},
+		},
+	}
+	ep := executor.ExecuteParams{
+		Schema: extensionsTestSchema,
+		AST:    testutil.Parse(t, `{ a }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			Extensions: []executor.ExtensionFactory{func() executor.Extension {
+				return &testCountingExtension{}
+			}},
+		},
+	}
+	// each execution reports its own data
+	for i := 0; i < 2; i++ {
+		result := testutil.Execute(t, ep)
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+		}
+	}
+}
+
+func TestExtensions_SeesTheEndOfPanickingResolvers(t *testing.T) {
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"a": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return "Apple"
+					},
+				},
+				"broken": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						panic("broken")
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	extension := &testCountingExtension{}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ a broken }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			Extensions: []executor.ExtensionFactory{func() executor.Extension {
+				return extension
+			}},
+		},
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected the error of broken, got: %v", result.Errors)
+	}
+	if expected := []string{"a", "broken"}; !reflect.DeepEqual(expected, extension.resolvedFields) {
+		t.Fatalf("Unexpected resolved fields, Diff: %v", testutil.Diff(expected, extension.resolvedFields))
+	}
+}
+
+func TestExtensions_OmitsExtensionsWhenNoneAreConfigured(t *testing.T) {
+	ep := executor.ExecuteParams{
+		Schema: extensionsTestSchema,
+		AST:    testutil.Parse(t, `{ a }`),
+	}
+	result := testutil.Execute(t, ep)
+	if result.Extensions != nil {
+		t.Fatalf("expected nil extensions, got: %v", result.Extensions)
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"data":{"a":"Apple"}}`
+	if string(b) != expected {
+		t.Fatalf("expected JSON %v, got: %v", expected, string(b))
+	}
+}
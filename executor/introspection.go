@@ -0,0 +1,54 @@
+package executor
+
+import (
+	"sync"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/parser"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func init() {
+	types.ExecuteIntrospectionQuery = executeIntrospectionQuery
+}
+
+var introspectionQuery struct {
+	once sync.Once
+	ast  *ast.Document
+	err  error
+}
+
+// Executes types.IntrospectionQuery, parsed once, against the schema.
+func executeIntrospectionQuery(schema types.GraphQLSchema) *types.GraphQLResult {
+	introspectionQuery.once.Do(func() {
+		introspectionQuery.ast, introspectionQuery.err = parser.Parse(parser.ParseParams{
+			Source: types.IntrospectionQuery,
+		})
+	})
+	if introspectionQuery.err != nil {
+		return &types.GraphQLResult{
+			Errors: []graphqlerrors.GraphQLFormattedError{graphqlerrors.FormatError(introspectionQuery.err)},
+		}
+	}
+	resultChan := make(chan *types.GraphQLResult)
+	go execute(ExecuteParams{
+		Schema: schema,
+		AST:    introspectionQuery.ast,
+	}, resultChan, false)
+	return <-resultChan
+}
+
+// Reports whether the document of p is types.IntrospectionQuery, executed
+// without the options observing or adding to its result, e.g. middleware,
+// so that its result is the one memoized by the schema.
+func isIntrospectionQuery(p ExecuteParams, maxComplexity int) bool {
+	if p.AST == nil || p.AST.Loc == nil || p.AST.Loc.Source == nil || p.AST.Loc.Source.Body != types.IntrospectionQuery {
+		return false
+	}
+	if p.OperationName != "" && p.OperationName != "IntrospectionQuery" {
+		return false
+	}
+	return maxComplexity == 0 && len(p.Middleware) == 0 && len(p.Extensions) == 0 &&
+		!p.EnableTracing && p.Tracer == nil && !p.OrderedResult
+}
@@ -1,6 +1,8 @@
 package executor_test
 
 import (
+	"context"
+	"fmt"
 	"github.com/chris-ramon/graphql-go/errors"
 	"github.com/chris-ramon/graphql-go/executor"
 	"github.com/chris-ramon/graphql-go/language/location"
@@ -8,6 +10,7 @@ import (
 	"github.com/chris-ramon/graphql-go/types"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func checkList(t *testing.T, testType types.GraphQLType, testData interface{}, expected *types.GraphQLResult) {
@@ -100,6 +103,17 @@ func TestLists_ListOfNullableObjects_ReturnsNull(t *testing.T) {
 	}
 	checkList(t, ttype, nil, expected)
 }
+func TestLists_ListOfNullableObjects_ReturnsNullForANilSlice(t *testing.T) {
+	ttype := types.NewGraphQLList(types.GraphQLInt)
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"nest": map[string]interface{}{
+				"test": nil,
+			},
+		},
+	}
+	checkList(t, ttype, []int(nil), expected)
+}
 
 // Describe [T] Func()Array<T> // equivalent to Promise<Array<T>>
 func TestLists_ListOfNullableFunc_ContainsValues(t *testing.T) {
@@ -263,11 +277,23 @@ func TestLists_NonNullListOfNullableObjectsReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
 	checkList(t, ttype, nil, expected)
 }
+func TestLists_NonNullListOfNullableObjectsReturnsEmptyListForANilSlice(t *testing.T) {
+	ttype := types.NewGraphQLNonNull(types.NewGraphQLList(types.GraphQLInt))
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"nest": map[string]interface{}{
+				"test": []interface{}{},
+			},
+		},
+	}
+	checkList(t, ttype, []int(nil), expected)
+}
 
 // Describe [T]! Func()Array<T> // equivalent to Promise<Array<T>>
 func TestLists_NonNullListOfNullableFunc_ContainsValues(t *testing.T) {
@@ -333,6 +359,7 @@ func TestLists_NonNullListOfNullableFunc_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -429,6 +456,7 @@ func TestLists_NullableListOfNonNullObjects_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -494,6 +522,7 @@ func TestLists_NullableListOfNonNullFunc_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -605,6 +634,7 @@ func TestLists_NonNullListOfNonNullObjects_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -626,6 +656,7 @@ func TestLists_NonNullListOfNonNullObjects_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -677,6 +708,7 @@ func TestLists_NonNullListOfNonNullFunc_ContainsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test", 1},
 			},
 		},
 	}
@@ -703,6 +735,7 @@ func TestLists_NonNullListOfNonNullFunc_ReturnsNull(t *testing.T) {
 						Column: 10,
 					},
 				},
+				Path: []interface{}{"nest", "test"},
 			},
 		},
 	}
@@ -761,3 +794,230 @@ func TestLists_NonNullListOfNonNullArrayOfFunc_ContainsNulls(t *testing.T) {
 	}
 	checkList(t, ttype, data, expected)
 }
+
+var streamedArticleType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Article",
+	Fields: types.GraphQLFieldConfigMap{
+		"id": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"title": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+	},
+})
+
+// Describe [Article] streamed from a channel, the feed of the root value
+var streamedArticlesTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"feed": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLList(streamedArticleType),
+			},
+		},
+	}),
+})
+
+func streamArticles(count int) <-chan interface{} {
+	articles := make(chan interface{}, count)
+	go func() {
+		defer close(articles)
+		for i := 1; i <= count; i++ {
+			articles <- map[string]interface{}{
+				"id":    fmt.Sprintf("%v", i),
+				"title": fmt.Sprintf("My Article %v", i),
+			}
+		}
+	}()
+	return articles
+}
+
+func TestLists_StreamsTheItemsOfAChannelInOrder(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"feed": []interface{}{
+				map[string]interface{}{"id": "1", "title": "My Article 1"},
+				map[string]interface{}{"id": "2", "title": "My Article 2"},
+				map[string]interface{}{"id": "3", "title": "My Article 3"},
+			},
+		},
+	}
+	ep := executor.ExecuteParams{
+		Schema: streamedArticlesTestSchema,
+		AST:    testutil.Parse(t, `{ feed { id title } }`),
+		Root:   map[string]interface{}{"feed": streamArticles(3)},
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestLists_CompletesANilChannelAsAnEmptyList(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"feed": []interface{}{},
+		},
+	}
+	var articles <-chan interface{}
+	ep := executor.ExecuteParams{
+		Schema: streamedArticlesTestSchema,
+		AST:    testutil.Parse(t, `{ feed { id } }`),
+		Root:   map[string]interface{}{"feed": articles},
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestLists_LimitsTheItemsOfAChannelAsThoseOfASlice(t *testing.T) {
+	tests := []struct {
+		policy   executor.ListSizePolicy
+		expected *types.GraphQLResult
+	}{
+		{
+			policy: executor.ListSizeError,
+			expected: &types.GraphQLResult{
+				Data: map[string]interface{}{
+					"feed": nil,
+				},
+				Errors: []graphqlerrors.GraphQLFormattedError{
+					graphqlerrors.GraphQLFormattedError{
+						Message: "List field Query.feed resolved more than 2 items.",
+						Locations: []location.SourceLocation{
+							location.SourceLocation{Line: 1, Column: 3},
+						},
+						Path: []interface{}{"feed"},
+					},
+				},
+			},
+		},
+		{
+			policy: executor.ListSizeTruncate,
+			expected: &types.GraphQLResult{
+				Data: map[string]interface{}{
+					"feed": []interface{}{
+						map[string]interface{}{"id": "1"},
+						map[string]interface{}{"id": "2"},
+					},
+				},
+				Extensions: map[string]interface{}{
+					"truncatedLists": []interface{}{
+						map[string]interface{}{"path": []interface{}{"feed"}, "limit": 2},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		ep := executor.ExecuteParams{
+			Schema: streamedArticlesTestSchema,
+			AST:    testutil.Parse(t, `{ feed { id } }`),
+			Root:   map[string]interface{}{"feed": streamArticles(3)},
+			ExecuteOptions: executor.ExecuteOptions{
+				MaxListSize:    2,
+				ListSizePolicy: test.policy,
+			},
+		}
+		result := testutil.Execute(t, ep)
+		if !reflect.DeepEqual(test.expected, result) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(test.expected, result))
+		}
+	}
+}
+
+func TestLists_StopsStreamingAChannelOnceTheContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	articles := make(chan interface{})
+	go func() {
+		defer close(stopped)
+		defer close(articles)
+		for i := 1; ; i++ {
+			select {
+			case articles <- map[string]interface{}{"id": fmt.Sprintf("%v", i)}:
+				if i == 2 {
+					cancel()
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"feed": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: "List field Query.feed stopped streaming: context canceled.",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 1, Column: 3},
+				},
+				Path: []interface{}{"feed"},
+			},
+		},
+	}
+	ep := executor.ExecuteParams{
+		Schema:  streamedArticlesTestSchema,
+		AST:     testutil.Parse(t, `{ feed { id } }`),
+		Root:    map[string]interface{}{"feed": articles},
+		Context: ctx,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the goroutine sending the items to stop")
+	}
+}
+
+type listsTestDirection int
+
+var listsTestDirectionType = types.NewGraphQLEnumType(types.GraphQLEnumTypeConfig{
+	Name: "Direction",
+	Values: types.GraphQLEnumValueConfigMap{
+		"NORTH": &types.GraphQLEnumValueConfig{Value: listsTestDirection(0)},
+		"SOUTH": &types.GraphQLEnumValueConfig{Value: listsTestDirection(1)},
+	},
+})
+
+func TestLists_ListOfEnums_SerializesEachItemToItsName(t *testing.T) {
+	north, south := listsTestDirection(0), listsTestDirection(1)
+	tests := []struct {
+		ttype    types.GraphQLType
+		data     interface{}
+		expected interface{}
+	}{
+		{
+			ttype:    types.NewGraphQLList(listsTestDirectionType),
+			data:     []interface{}{south, nil, north},
+			expected: []interface{}{"SOUTH", nil, "NORTH"},
+		},
+		{
+			ttype:    types.NewGraphQLList(listsTestDirectionType),
+			data:     []*listsTestDirection{&south, nil},
+			expected: []interface{}{"SOUTH", nil},
+		},
+		{
+			ttype:    types.NewGraphQLList(types.NewGraphQLList(listsTestDirectionType)),
+			data:     [][]listsTestDirection{{north, south}, nil, {south}},
+			expected: []interface{}{[]interface{}{"NORTH", "SOUTH"}, nil, []interface{}{"SOUTH"}},
+		},
+	}
+	for _, test := range tests {
+		expected := &types.GraphQLResult{
+			Data: map[string]interface{}{
+				"nest": map[string]interface{}{
+					"test": test.expected,
+				},
+			},
+		}
+		checkList(t, test.ttype, test.data, expected)
+	}
+}
@@ -0,0 +1,133 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// BatchFn loads the values of the given keys at once, e.g. with a single
+// database query. It must return one value per key, in the order of the keys;
+// a key that failed to load is reported by returning an error in its place.
+type BatchFn func(keys []interface{}) []interface{}
+
+// Thunk is a value which is not known yet. The executor resolves thunks
+// returned by resolve functions only after all the fields of the current
+// resolution level were resolved, see Loader.
+type Thunk func() interface{}
+
+// Loader batches and caches the loading of values by key, avoiding N+1
+// lookups when e.g. each item of a list resolves the same kind of related
+// object.
+//
+// Load queues the key and returns a Thunk. The executor defers thunks of
+// nullable fields until the current resolution level was resolved, so the
+// first thunk to be resolved calls the batch function with the keys of all
+// the loads queued so far. Identical keys are loaded once. The value of a
+// key that failed to load, an error, is not cached: the next load of the key
+// loads it again.
+type Loader struct {
+	batchFn BatchFn
+	mu      sync.Mutex
+	queue   []interface{}
+	queued  map[interface{}]bool
+	results map[interface{}]interface{}
+}
+
+func NewLoader(batchFn BatchFn) *Loader {
+	return &Loader{
+		batchFn: batchFn,
+		queued:  map[interface{}]bool{},
+		results: map[interface{}]interface{}{},
+	}
+}
+
+// Load queues the key for the next batch, unless its value was already
+// loaded. The key must be comparable.
+func (l *Loader) Load(key interface{}) Thunk {
+	l.mu.Lock()
+	value, ok := l.results[key]
+	if _, failed := value.(error); ok && failed {
+		delete(l.results, key)
+		ok = false
+	}
+	if !ok && !l.queued[key] {
+		l.queue = append(l.queue, key)
+		l.queued[key] = true
+	}
+	l.mu.Unlock()
+	return func() interface{} {
+		l.mu.Lock()
+		_, ok := l.results[key]
+		l.mu.Unlock()
+		if !ok {
+			l.dispatch()
+		}
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.results[key]
+	}
+}
+
+// Calls the batch function with the queued keys and caches their values.
+func (l *Loader) dispatch() {
+	l.mu.Lock()
+	keys := l.queue
+	l.queue = nil
+	l.queued = map[interface{}]bool{}
+	l.mu.Unlock()
+	if len(keys) == 0 {
+		return
+	}
+	values := l.batchFn(keys)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, key := range keys {
+		if len(values) != len(keys) {
+			l.results[key] = fmt.Errorf("Loader batch function returned %v values for %v keys.", len(values), len(keys))
+			continue
+		}
+		l.results[key] = values[i]
+	}
+}
+
+type loadersContextKey struct{}
+
+// WithLoaders returns a copy of ctx holding a fresh loader for each of the
+// given batch functions, keyed as in batchFns. The executor installs the
+// loaders of ExecuteOptions.Loaders this way on each execution, so that their
+// batching and caching are scoped to a single request.
+func WithLoaders(ctx context.Context, batchFns map[string]BatchFn) context.Context {
+	loaders := map[string]*Loader{}
+	if parent, ok := ctx.Value(loadersContextKey{}).(map[string]*Loader); ok {
+		for key, loader := range parent {
+			loaders[key] = loader
+		}
+	}
+	for key, batchFn := range batchFns {
+		loaders[key] = NewLoader(batchFn)
+	}
+	return context.WithValue(ctx, loadersContextKey{}, loaders)
+}
+
+// LoaderFrom returns the loader installed in ctx under key, or nil if there
+// is none.
+func LoaderFrom(ctx context.Context, key string) *Loader {
+	if ctx == nil {
+		return nil
+	}
+	loaders, _ := ctx.Value(loadersContextKey{}).(map[string]*Loader)
+	return loaders[key]
+}
+
+// Resolves the deferred fields, including the ones deferred while doing so,
+// one resolution level at a time.
+func (eCtx *ExecutionContext) resolveDeferred() {
+	for len(eCtx.deferred) > 0 {
+		deferred := eCtx.deferred
+		eCtx.deferred = nil
+		for _, resolve := range deferred {
+			resolve()
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+type loaderContextKey string
+
+// Returns the loader of the authors of the context value, or else the one
+// installed by ExecuteOptions.Loaders.
+func authorLoader(ctx context.Context) *executor.Loader {
+	if loader, ok := ctx.Value(loaderContextKey("author")).(*executor.Loader); ok {
+		return loader
+	}
+	return executor.LoaderFrom(ctx, "author")
+}
+
+var loaderAuthorType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Author",
+	Fields: types.GraphQLFieldConfigMap{
+		"name": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+	},
+})
+
+var loaderArticleType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Article",
+	Fields: types.GraphQLFieldConfigMap{
+		"id": &types.GraphQLFieldConfig{
+			Type: types.GraphQLInt,
+		},
+		"author": &types.GraphQLFieldConfig{
+			Type: loaderAuthorType,
+			Resolve: func(p types.GQLFRParams) interface{} {
+				return authorLoader(p.Context).Load(p.Source.(map[string]interface{})["authorId"])
+			},
+		},
+	},
+})
+
+var loaderTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"feed": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLList(loaderArticleType),
+				Resolve: func(p types.GQLFRParams) interface{} {
+					feed := []interface{}{}
+					for i := 1; i <= 4; i++ {
+						feed = append(feed, map[string]interface{}{
+							"id":       i,
+							"authorId": 2 - i%2,
+						})
+					}
+					return feed
+				},
+			},
+		},
+	}),
+})
+
+func TestLoader_BatchesLoadsOfAResolutionLevel(t *testing.T) {
+	query := `{ feed { id, author { name } } }`
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"feed": []interface{}{
+				map[string]interface{}{"id": 1, "author": map[string]interface{}{"name": "Author 1"}},
+				map[string]interface{}{"id": 2, "author": map[string]interface{}{"name": "Author 2"}},
+				map[string]interface{}{"id": 3, "author": map[string]interface{}{"name": "Author 1"}},
+				map[string]interface{}{"id": 4, "author": map[string]interface{}{"name": "Author 2"}},
+			},
+		},
+	}
+
+	batches := [][]interface{}{}
+	loader := executor.NewLoader(func(keys []interface{}) []interface{} {
+		batches = append(batches, keys)
+		authors := []interface{}{}
+		for _, key := range keys {
+			authors = append(authors, map[string]interface{}{
+				"name": fmt.Sprintf("Author %v", key),
+			})
+		}
+		return authors
+	})
+
+	ep := executor.ExecuteParams{
+		Schema:  loaderTestSchema,
+		AST:     testutil.Parse(t, query),
+		Context: context.WithValue(context.Background(), loaderContextKey("author"), loader),
+	}
+	result := testutil.Execute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	expectedBatches := [][]interface{}{
+		[]interface{}{1, 2},
+	}
+	if !reflect.DeepEqual(expectedBatches, batches) {
+		t.Fatalf("Unexpected batches, Diff: %v", testutil.Diff(expectedBatches, batches))
+	}
+}
+
+func TestLoader_ReportsErrorsReturnedByTheBatchFunction(t *testing.T) {
+	query := `{ feed { id, author { name } } }`
+
+	errNotFound := errors.New("Author 2 not found")
+	loader := executor.NewLoader(func(keys []interface{}) []interface{} {
+		authors := []interface{}{}
+		for _, key := range keys {
+			if key == 2 {
+				authors = append(authors, errNotFound)
+				continue
+			}
+			authors = append(authors, map[string]interface{}{
+				"name": fmt.Sprintf("Author %v", key),
+			})
+		}
+		return authors
+	})
+
+	ep := executor.ExecuteParams{
+		Schema:  loaderTestSchema,
+		AST:     testutil.Parse(t, query),
+		Context: context.WithValue(context.Background(), loaderContextKey("author"), loader),
+	}
+	result := testutil.Execute(t, ep)
+
+	expectedFeed := []interface{}{
+		map[string]interface{}{"id": 1, "author": map[string]interface{}{"name": "Author 1"}},
+		map[string]interface{}{"id": 2, "author": nil},
+		map[string]interface{}{"id": 3, "author": map[string]interface{}{"name": "Author 1"}},
+		map[string]interface{}{"id": 4, "author": nil},
+	}
+	expectedErrors := []graphqlerrors.GraphQLFormattedError{
+		graphqlerrors.GraphQLFormattedError{
+			Message: "Author 2 not found",
+			Locations: []location.SourceLocation{
+				location.SourceLocation{Line: 1, Column: 14},
+			},
+			Path:          []interface{}{"feed", 1, "author"},
+			OriginalError: errNotFound,
+		},
+		graphqlerrors.GraphQLFormattedError{
+			Message: "Author 2 not found",
+			Locations: []location.SourceLocation{
+				location.SourceLocation{Line: 1, Column: 14},
+			},
+			Path:          []interface{}{"feed", 3, "author"},
+			OriginalError: errNotFound,
+		},
+	}
+	if feed := result.Data.(map[string]interface{})["feed"]; !reflect.DeepEqual(expectedFeed, feed) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedFeed, feed))
+	}
+	if !reflect.DeepEqual(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
+func TestLoader_LoadsTheKeysThatFailedAgain(t *testing.T) {
+	calls := [][]interface{}{}
+	loader := executor.NewLoader(func(keys []interface{}) []interface{} {
+		calls = append(calls, keys)
+		values := []interface{}{}
+		for _, key := range keys {
+			if key == 2 && len(calls) == 1 {
+				values = append(values, errors.New("Author 2 is unavailable"))
+				continue
+			}
+			values = append(values, fmt.Sprintf("Author %v", key))
+		}
+		return values
+	})
+
+	first, second := loader.Load(1), loader.Load(2)
+	if value := first(); value != "Author 1" {
+		t.Fatalf("Expected Author 1, got: %v", value)
+	}
+	if err, ok := second().(error); !ok || err.Error() != "Author 2 is unavailable" {
+		t.Fatalf("Expected the error of Author 2, got: %v", err)
+	}
+	// the value of key 1 is cached, key 2 is loaded again
+	first, second = loader.Load(1), loader.Load(2)
+	if value := second(); value != "Author 2" {
+		t.Fatalf("Expected Author 2, got: %v", value)
+	}
+	if value := first(); value != "Author 1" {
+		t.Fatalf("Expected Author 1, got: %v", value)
+	}
+	expectedCalls := [][]interface{}{{1, 2}, {2}}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Fatalf("Unexpected batches, Diff: %v", testutil.Diff(expectedCalls, calls))
+	}
+}
+
+func TestLoader_InstallsFreshLoadersForEachExecution(t *testing.T) {
+	query := `{ feed { id, author { name } } }`
+
+	var mu sync.Mutex
+	batches := [][]interface{}{}
+	ep := executor.ExecuteParams{
+		Schema: loaderTestSchema,
+		AST:    testutil.Parse(t, query),
+		ExecuteOptions: executor.ExecuteOptions{
+			Loaders: map[string]executor.BatchFn{
+				"author": func(keys []interface{}) []interface{} {
+					mu.Lock()
+					batches = append(batches, keys)
+					mu.Unlock()
+					authors := []interface{}{}
+					for _, key := range keys {
+						authors = append(authors, map[string]interface{}{
+							"name": fmt.Sprintf("Author %v", key),
+						})
+					}
+					return authors
+				},
+			},
+		},
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*types.GraphQLResult, 2)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = testutil.Execute(t, ep)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+	}
+	// a loader shared by the executions would load the keys once
+	expectedBatches := [][]interface{}{
+		[]interface{}{1, 2},
+		[]interface{}{1, 2},
+	}
+	if !reflect.DeepEqual(expectedBatches, batches) {
+		t.Fatalf("Unexpected batches, Diff: %v", testutil.Diff(expectedBatches, batches))
+	}
+}
@@ -0,0 +1,162 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Resolves a field with the method of the source named after the field, its
+// first letter upper-cased, e.g. `Pic` for the field `pic`, reporting false
+// if there is no such method. The parameters of the method are bound to the
+// arguments of the field:
+//
+//   - an optional first context.Context parameter is bound to p.Context
+//   - a single struct parameter has its fields bound to the arguments of the
+//     same name, matched by json tag, or by field name ignoring case, e.g.
+//     `func (a *Author) Pic(args struct{ Width, Height int }) *Pic`
+//   - otherwise the parameters are bound to the arguments by position, the
+//     arguments in the order of the field's definition, as declared by
+//     types.GraphQLFieldConfig.ArgOrder, e.g.
+//     `func (a *Author) Pic(width, height int) *Pic` with the ArgOrder
+//     `[]string{"width", "height"}`
+//
+// A struct field matching no argument of the field, or a parameter beyond
+// the arguments of the field, is an error. Missing arguments bind to zero
+// values. The method returns the value of the field, optionally followed by
+// an error reported as the field's error.
+func resolveMethod(p types.GQLFRParams) (interface{}, bool) {
+	fieldName := p.Info.FieldName
+	first, size := utf8.DecodeRuneInString(fieldName)
+	if size == 0 {
+		return nil, false
+	}
+	methodName := string(unicode.ToUpper(first)) + fieldName[size:]
+	method := reflect.ValueOf(p.Source).MethodByName(methodName)
+	if !method.IsValid() {
+		return nil, false
+	}
+	methodType := method.Type()
+	if methodType.IsVariadic() || methodType.NumOut() < 1 || methodType.NumOut() > 2 ||
+		(methodType.NumOut() == 2 && methodType.Out(1) != errorType) {
+		return fmt.Errorf(`Method %v of %T cannot resolve field "%v", it must return a value and optionally an error.`,
+			methodName, p.Source, fieldName), true
+	}
+
+	in := []reflect.Value{}
+	params := []reflect.Type{}
+	for i := 0; i < methodType.NumIn(); i++ {
+		params = append(params, methodType.In(i))
+	}
+	if len(params) > 0 && params[0] == contextType {
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		in = append(in, reflect.ValueOf(ctx))
+		params = params[1:]
+	}
+	var argDefs []*types.GraphQLArgument
+	if parentType, ok := p.Info.ParentType.(*types.GraphQLObjectType); ok {
+		if fieldDef := getFieldDef(p.Info.Schema, parentType, fieldName); fieldDef != nil {
+			argDefs = fieldDef.Args
+		}
+	}
+	switch {
+	case len(params) == 1 && params[0].Kind() == reflect.Struct:
+		arg, err := bindArgsStruct(params[0], argDefs, p.Args)
+		if err != nil {
+			return fmt.Errorf(`Method %v of %T cannot resolve field "%v": %v`, methodName, p.Source, fieldName, err), true
+		}
+		in = append(in, arg)
+	case len(params) > len(argDefs):
+		return fmt.Errorf(`Method %v of %T cannot resolve field "%v", it takes more parameters than the field has arguments.`,
+			methodName, p.Source, fieldName), true
+	default:
+		for i, param := range params {
+			name := argDefs[i].Name
+			arg, err := bindArg(param, name, p.Args[name])
+			if err != nil {
+				return fmt.Errorf(`Method %v of %T cannot resolve field "%v": %v`, methodName, p.Source, fieldName, err), true
+			}
+			in = append(in, arg)
+		}
+	}
+
+	out := method.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return out[1].Interface(), true
+	}
+	return out[0].Interface(), true
+}
+
+// Returns the struct of the given type whose fields hold the arguments of
+// the same name, see resolveMethod.
+func bindArgsStruct(structType reflect.Type, argDefs []*types.GraphQLArgument, args map[string]interface{}) (reflect.Value, error) {
+	structVal := reflect.New(structType).Elem()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, ok := argName(field, argDefs)
+		if !ok {
+			return structVal, fmt.Errorf(`field %v of the parameter matches no argument.`, field.Name)
+		}
+		arg, err := bindArg(field.Type, name, args[name])
+		if err != nil {
+			return structVal, err
+		}
+		structVal.Field(i).Set(arg)
+	}
+	return structVal, nil
+}
+
+// Returns the name of the argument held by the struct field, matched by
+// json tag or by field name ignoring case.
+func argName(field reflect.StructField, argDefs []*types.GraphQLArgument) (string, bool) {
+	tag := strings.Split(field.Tag.Get("json"), ",")[0]
+	for _, argDef := range argDefs {
+		if argDef.Name == tag || (tag == "" && strings.EqualFold(argDef.Name, field.Name)) {
+			return argDef.Name, true
+		}
+	}
+	return "", false
+}
+
+// Returns the value of an argument as a value of the parameter's type,
+// converting numbers, the zero value if the argument is missing.
+func bindArg(paramType reflect.Type, name string, value interface{}) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(paramType), nil
+	}
+	valueVal := reflect.ValueOf(value)
+	if valueVal.Type().AssignableTo(paramType) {
+		return valueVal, nil
+	}
+	if isNumberKind(valueVal.Kind()) && isNumberKind(paramType.Kind()) {
+		return valueVal.Convert(paramType), nil
+	}
+	return reflect.Value{}, fmt.Errorf(`Argument "%v" of type %T cannot be bound to a parameter of type %v.`,
+		name, value, paramType)
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
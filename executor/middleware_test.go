@@ -0,0 +1,207 @@
+package executor_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+var middlewareTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"greeting": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return "hello"
+				},
+			},
+			// resolved by the default resolver
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"secret": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	}),
+})
+
+func TestMiddleware_WrapsEveryResolverInOrder(t *testing.T) {
+	// the fields are not resolved in a particular order, the calls are
+	// recorded per field
+	calls := map[string][]string{}
+	logging := func(next types.GraphQLFieldResolveFn) types.GraphQLFieldResolveFn {
+		return func(p types.GQLFRParams) interface{} {
+			calls[p.Info.FieldName] = append(calls[p.Info.FieldName], "log")
+			return next(p)
+		}
+	}
+	upper := func(next types.GraphQLFieldResolveFn) types.GraphQLFieldResolveFn {
+		return func(p types.GQLFRParams) interface{} {
+			calls[p.Info.FieldName] = append(calls[p.Info.FieldName], "upper")
+			if value, ok := next(p).(string); ok {
+				return strings.ToUpper(value)
+			}
+			return nil
+		}
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"greeting": "HELLO",
+			"name":     "JOHN",
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: middlewareTestSchema,
+		AST:    testutil.Parse(t, `{ greeting name }`),
+		Root:   map[string]interface{}{"name": "John"},
+		ExecuteOptions: executor.ExecuteOptions{
+			Middleware: []executor.FieldMiddleware{logging, upper},
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	expectedCalls := map[string][]string{
+		"greeting": []string{"log", "upper"},
+		"name":     []string{"log", "upper"},
+	}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Fatalf("Unexpected calls, Diff: %v", testutil.Diff(expectedCalls, calls))
+	}
+}
+
+func TestMiddleware_ShortCircuitsAFieldWithAnError(t *testing.T) {
+	authorize := func(next types.GraphQLFieldResolveFn) types.GraphQLFieldResolveFn {
+		return func(p types.GQLFRParams) interface{} {
+			if p.Info.FieldName == "secret" {
+				return errors.New("not authorized")
+			}
+			return next(p)
+		}
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"name":   "John",
+			"secret": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: "not authorized",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 1, Column: 8},
+				},
+				Path: []interface{}{"secret"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: middlewareTestSchema,
+		AST:    testutil.Parse(t, `{ name secret }`),
+		Root:   map[string]interface{}{"name": "John", "secret": "42"},
+		ExecuteOptions: executor.ExecuteOptions{
+			Middleware: []executor.FieldMiddleware{authorize},
+		},
+	})
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMiddleware_ReadsTheExtensionsOfTheField(t *testing.T) {
+	userType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "User",
+		Extensions: map[string]interface{}{"table": "users"},
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"email": &types.GraphQLFieldConfig{
+				Type:       types.GraphQLString,
+				Extensions: map[string]interface{}{"role": "admin"},
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"masked": &types.GraphQLArgumentConfig{
+						Type:       types.GraphQLBoolean,
+						Extensions: map[string]interface{}{"internal": true},
+					},
+				},
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"viewer": &types.GraphQLFieldConfig{
+					Type: userType,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	tables := []interface{}{}
+	authorize := func(next types.GraphQLFieldResolveFn) types.GraphQLFieldResolveFn {
+		return func(p types.GQLFRParams) interface{} {
+			if parentType, ok := p.Info.ParentType.(*types.GraphQLObjectType); ok && parentType.Extensions != nil {
+				tables = append(tables, parentType.Extensions["table"])
+			}
+			if fieldDef := p.Info.FieldDefinition(); fieldDef != nil && fieldDef.Extensions["role"] == "admin" {
+				return errors.New("not authorized")
+			}
+			return next(p)
+		}
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"viewer": map[string]interface{}{
+				"name":  "John",
+				"email": nil,
+			},
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: "not authorized",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 1, Column: 17},
+				},
+				Path: []interface{}{"viewer", "email"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ viewer { name email } }`),
+		Root: map[string]interface{}{
+			"viewer": map[string]interface{}{"name": "John", "email": "john@example.com"},
+		},
+		ExecuteOptions: executor.ExecuteOptions{
+			Middleware: []executor.FieldMiddleware{authorize},
+		},
+	})
+	for i := range result.Errors {
+		result.Errors[i].OriginalError = nil
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if expectedTables := []interface{}{"users", "users"}; !reflect.DeepEqual(expectedTables, tables) {
+		t.Fatalf("Unexpected tables, Diff: %v", testutil.Diff(expectedTables, tables))
+	}
+	args := userType.GetFields()["email"].Args
+	if len(args) != 1 || args[0].Extensions["internal"] != true {
+		t.Fatalf("Expected the extensions of the argument to be carried onto its definition, got: %v", args)
+	}
+}
@@ -223,12 +223,14 @@ func TestMutations_EvaluatesMutationsCorrectlyInThePresenceOfAFailedMutation(t *
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 8, Column: 7},
 				},
+				Path: []interface{}{"third"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: `Cannot change the number`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 17, Column: 7},
 				},
+				Path: []interface{}{"sixth"},
 			},
 		},
 	}
@@ -250,3 +252,128 @@ func TestMutations_EvaluatesMutationsCorrectlyInThePresenceOfAFailedMutation(t *
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+type testMutationArticle struct {
+	Id    string `json:"id"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func TestMutations_ReturnsTheMutatedObjectToItsSubSelection(t *testing.T) {
+
+	article := &testMutationArticle{
+		Id:    "1",
+		Title: "My Article",
+		Body:  "This is a post",
+	}
+	resolvedFields := []string{}
+
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+			},
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"body": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: articleType,
+				},
+			},
+		}),
+		Mutation: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Mutation",
+			Fields: types.GraphQLFieldConfigMap{
+				"updateArticle": &types.GraphQLFieldConfig{
+					Type: articleType,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"title": &types.GraphQLArgumentConfig{
+							Type: types.GraphQLString,
+						},
+						"body": &types.GraphQLArgumentConfig{
+							Type: types.GraphQLString,
+						},
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						resolvedFields = append(resolvedFields, p.Info.FieldASTs[0].Alias.Value)
+						if title, ok := p.Args["title"].(string); ok {
+							article.Title = title
+						}
+						if body, ok := p.Args["body"].(string); ok {
+							article.Body = body
+						}
+						// return a copy, so each root reflects the state after its own update
+						updated := *article
+						return &updated
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	doc := `mutation M {
+      first: updateArticle(title: "First title") {
+        id
+        title
+      }
+      second: updateArticle(body: "Second body") {
+        title
+        body
+      }
+      third: updateArticle(title: "Third title") {
+        id
+        title
+        body
+      }
+    }`
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"first": map[string]interface{}{
+				"id":    "1",
+				"title": "First title",
+			},
+			"second": map[string]interface{}{
+				"title": "First title",
+				"body":  "Second body",
+			},
+			"third": map[string]interface{}{
+				"id":    "1",
+				"title": "Third title",
+				"body":  "Second body",
+			},
+		},
+	}
+	// parse query
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: schema,
+		AST:    ast,
+	}
+	result := testutil.Execute(t, ep)
+	if len(result.Errors) != len(expected.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expected.Errors, result.Errors))
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	expectedOrder := []string{"first", "second", "third"}
+	if !reflect.DeepEqual(expectedOrder, resolvedFields) {
+		t.Fatalf("Unexpected mutation execution order, Diff: %v", testutil.Diff(expectedOrder, resolvedFields))
+	}
+}
@@ -1,6 +1,7 @@
 package executor_test
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"testing"
@@ -65,9 +66,7 @@ var dataType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
 	},
 })
 
-var nonNullTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
-	Query: dataType,
-})
+var nonNullTestSchema types.GraphQLSchema
 
 func init() {
 	throwingData["nest"] = func() interface{} {
@@ -108,6 +107,10 @@ func init() {
 	dataType.AddFieldConfig("nonNullPromiseNest", &types.GraphQLFieldConfig{
 		Type: types.NewGraphQLNonNull(dataType),
 	})
+
+	nonNullTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: dataType,
+	})
 }
 
 // nulls a nullable field that panics
@@ -129,6 +132,7 @@ func TestNonNull_NullsANullableFieldThatThrowsSynchronously(t *testing.T) {
 						Line: 3, Column: 9,
 					},
 				},
+				Path: []interface{}{"sync"},
 			},
 		},
 	}
@@ -167,6 +171,7 @@ func TestNonNull_NullsANullableFieldThatThrowsInAPromise(t *testing.T) {
 						Line: 3, Column: 9,
 					},
 				},
+				Path: []interface{}{"promise"},
 			},
 		},
 	}
@@ -207,6 +212,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANullableFieldThat
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"nest", "nonNullSync"},
 			},
 		},
 	}
@@ -247,6 +253,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"nest", "nonNullPromise"},
 			},
 		},
 	}
@@ -287,6 +294,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"promiseNest", "nonNullSync"},
 			},
 		},
 	}
@@ -327,6 +335,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 						Line: 4, Column: 11,
 					},
 				},
+				Path: []interface{}{"promiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -410,72 +419,84 @@ func TestNonNull_NullsAComplexTreeOfNullableFieldsThatThrow(t *testing.T) {
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "sync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 7, Column: 13},
 				},
+				Path: []interface{}{"nest", "nest", "sync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 11, Column: 13},
 				},
+				Path: []interface{}{"nest", "promiseNest", "sync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 16, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "sync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 19, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "nest", "sync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: syncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 23, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "promiseNest", "sync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 5, Column: 11},
 				},
+				Path: []interface{}{"nest", "promise"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 8, Column: 13},
 				},
+				Path: []interface{}{"nest", "nest", "promise"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 12, Column: 13},
 				},
+				Path: []interface{}{"nest", "promiseNest", "promise"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 17, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "promise"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 20, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "nest", "promise"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: promiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 24, Column: 13},
 				},
+				Path: []interface{}{"promiseNest", "promiseNest", "promise"},
 			},
 		},
 	}
@@ -563,24 +584,28 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldThrowsInALongChainOfField
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 8, Column: 19},
 				},
+				Path: []interface{}{"nest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: nonNullSyncError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 19, Column: 19},
 				},
+				Path: []interface{}{"promiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: nonNullPromiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 30, Column: 19},
 				},
+				Path: []interface{}{"anotherNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: nonNullPromiseError,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 41, Column: 19},
 				},
+				Path: []interface{}{"anotherPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -687,6 +712,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "nonNullSync"},
 			},
 		},
 	}
@@ -725,6 +751,7 @@ func TestNonNull_NullsASynchronouslyReturnedObjectThatContainsANonNullableFieldT
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"nest", "nonNullPromise"},
 			},
 		},
 	}
@@ -764,6 +791,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "nonNullSync"},
 			},
 		},
 	}
@@ -802,6 +830,7 @@ func TestNonNull_NullsAnObjectReturnedInAPromiseThatContainsANonNullableFieldTha
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 4, Column: 11},
 				},
+				Path: []interface{}{"promiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -961,24 +990,28 @@ func TestNonNull_NullsTheFirstNullableObjectAfterAFieldReturnsNullInALongChainOf
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 8, Column: 19},
 				},
+				Path: []interface{}{"nest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: `Cannot return null for non-nullable field DataType.nonNullSync.`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 19, Column: 19},
 				},
+				Path: []interface{}{"promiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullSync"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 30, Column: 19},
 				},
+				Path: []interface{}{"anotherNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 			graphqlerrors.GraphQLFormattedError{
 				Message: `Cannot return null for non-nullable field DataType.nonNullPromise.`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 41, Column: 19},
 				},
+				Path: []interface{}{"anotherPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullNest", "nonNullPromiseNest", "nonNullPromise"},
 			},
 		},
 	}
@@ -1017,6 +1050,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldThrows(t *testing.T) {
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullSync"},
 			},
 		},
 	}
@@ -1049,6 +1083,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldErrors(t *testing.T) {
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullPromise"},
 			},
 		},
 	}
@@ -1081,6 +1116,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldReturnsNull(t *testing.T)
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullSync"},
 			},
 		},
 	}
@@ -1113,6 +1149,7 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldResolvesNull(t *testing.T
 				Locations: []location.SourceLocation{
 					location.SourceLocation{Line: 2, Column: 17},
 				},
+				Path: []interface{}{"nonNullPromise"},
 			},
 		},
 	}
@@ -1133,3 +1170,125 @@ func TestNonNull_NullsTheTopLevelIfSyncNonNullableFieldResolvesNull(t *testing.T
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestNonNull_CollectAllErrorsReportsTheErrorsOfTheSiblingsOfAFailedNonNullField(t *testing.T) {
+	doc := `
+      query Q {
+        nest {
+          nonNullSync,
+          nonNullPromise,
+          sync
+        }
+      }
+	`
+	nestErrors := []graphqlerrors.GraphQLFormattedError{
+		graphqlerrors.GraphQLFormattedError{
+			Message: nonNullSyncError,
+			Locations: []location.SourceLocation{
+				location.SourceLocation{Line: 4, Column: 11},
+			},
+			Path: []interface{}{"nest", "nonNullSync"},
+		},
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"nest": nil,
+		},
+		Errors: nestErrors,
+	}
+	ep := executor.ExecuteParams{
+		Schema: nonNullTestSchema,
+		AST:    testutil.Parse(t, doc),
+		Root:   throwingData,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// the siblings of the failed field are still resolved, the errors being
+	// reported in the order of the fields
+	expected.Errors = []graphqlerrors.GraphQLFormattedError{
+		nestErrors[0],
+		graphqlerrors.GraphQLFormattedError{
+			Message: nonNullPromiseError,
+			Locations: []location.SourceLocation{
+				location.SourceLocation{Line: 5, Column: 11},
+			},
+			Path: []interface{}{"nest", "nonNullPromise"},
+		},
+		graphqlerrors.GraphQLFormattedError{
+			Message: syncError,
+			Locations: []location.SourceLocation{
+				location.SourceLocation{Line: 6, Column: 11},
+			},
+			Path: []interface{}{"nest", "sync"},
+		},
+	}
+	ep.CollectAllErrors = true
+	result = testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestNonNull_CollectAllErrorsReportsTheErrorsOfTheSiblingsOfAFailedNonNullItem(t *testing.T) {
+	itemType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Item",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+				Resolve: func(p types.GQLFRParams) interface{} {
+					panic(fmt.Sprintf("item %v failed", p.Source))
+				},
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"items": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(types.NewGraphQLNonNull(itemType)),
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return []interface{}{1, 2}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `{ items { id } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			CollectAllErrors: true,
+		},
+	})
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"items": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: "item 1 failed",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 1, Column: 11},
+				},
+				Path: []interface{}{"items", 0, "id"},
+			},
+			graphqlerrors.GraphQLFormattedError{
+				Message: "item 2 failed",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 1, Column: 11},
+				},
+				Path: []interface{}{"items", 1, "id"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
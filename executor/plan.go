@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"fmt"
+
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/printer"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Plan is the tree of fields an operation would resolve, see ExecutePlan.
+type Plan struct {
+	Operation string       `json:"operation"`
+	RootType  string       `json:"rootType"`
+	Fields    []*PlanField `json:"fields"`
+}
+
+// PlanField is a field an operation would resolve. Sibling fields are
+// ordered as the fields of the query once its fragments are expanded, the
+// order in which they are executed.
+type PlanField struct {
+	ResponseName string `json:"responseName"`
+	FieldName    string `json:"fieldName"`
+	ParentType   string `json:"parentType"`
+	Type         string `json:"type"`
+	// The arguments of the field in the document, by name, printed as they
+	// are written rather than coerced, e.g. `640`, `"1"` or `$size`.
+	Arguments map[string]string `json:"arguments,omitempty"`
+	// Whether the field's resolve function would run, rather than the
+	// default resolver reading the property of the source.
+	HasResolveFn bool `json:"hasResolveFn"`
+	// The sub-fields of an object type.
+	Fields []*PlanField `json:"fields,omitempty"`
+	// The sub-fields of an interface or union type for each of its possible
+	// types, as the runtime type is only known once resolved.
+	PossibleTypes []*PlanPossibleType `json:"possibleTypes,omitempty"`
+}
+
+type PlanPossibleType struct {
+	Type   string       `json:"type"`
+	Fields []*PlanField `json:"fields"`
+}
+
+// ExecutePlan walks the operation of p against the schema the way Execute
+// does, expanding fragments and evaluating @skip and @include, but without
+// invoking any resolve functions. The returned plan helps to visualize what
+// an operation will do, e.g. to estimate its cost.
+//
+// The variables of the operation need not be provided: the fields whose
+// @skip or @include condition is a variable which is not provided are
+// planned, as they may be resolved.
+func ExecutePlan(p ExecuteParams) (*Plan, error) {
+	var result types.GraphQLResult
+	resultChan := make(chan *types.GraphQLResult, 1)
+	eCtx := buildExecutionContext(BuildExecutionCtxParams{
+		Schema:           p.Schema,
+		Root:             p.Root,
+		AST:              p.AST,
+		OperationName:    p.OperationName,
+		Args:             p.Args,
+		Result:           &result,
+		ResultChan:       resultChan,
+		PartialVariables: true,
+	})
+	if result.HasErrors() {
+		return nil, result.Errors[0]
+	}
+	operationType := getOperationRootType(eCtx.Schema, eCtx.Operation, resultChan)
+	if operationType == nil {
+		return nil, (<-resultChan).Errors[0]
+	}
+
+	responseNames := []string{}
+	fields := collectFields(CollectFieldsParams{
+		ExeContext:    eCtx,
+		OperationType: operationType,
+		SelectionSet:  eCtx.Operation.GetSelectionSet(),
+		ResponseNames: &responseNames,
+	})
+	return &Plan{
+		Operation: eCtx.Operation.GetOperation(),
+		RootType:  operationType.Name,
+		Fields:    planFields(eCtx, operationType, fields, responseNames),
+	}, nil
+}
+
+// Plans the given fields of the parent type, in the order of their response
+// names.
+func planFields(eCtx *ExecutionContext, parentType *types.GraphQLObjectType, fields map[string][]*ast.Field, responseNames []string) []*PlanField {
+	planned := []*PlanField{}
+	for _, responseName := range responseNames {
+		fieldASTs := fields[responseName]
+		fieldAST := fieldASTs[0]
+		fieldName := ""
+		if fieldAST.Name != nil {
+			fieldName = fieldAST.Name.Value
+		}
+		fieldDef := getFieldDef(eCtx.Schema, parentType, fieldName)
+		if fieldDef == nil {
+			continue
+		}
+		field := &PlanField{
+			ResponseName: responseName,
+			FieldName:    fieldName,
+			ParentType:   parentType.Name,
+			Type:         fmt.Sprintf("%v", fieldDef.Type),
+			Arguments:    planArguments(fieldAST.Arguments),
+			HasResolveFn: fieldDef.Resolve != nil,
+		}
+		switch namedType := types.GetNamedType(fieldDef.Type).(type) {
+		case *types.GraphQLObjectType:
+			subFields := collectOrderedSubFields(eCtx, namedType, fieldASTs)
+			field.Fields = planFields(eCtx, namedType, subFields.fields, subFields.responseNames)
+		case types.GraphQLAbstractType:
+			for _, possibleType := range eCtx.Schema.GetPossibleTypes(namedType.(types.GraphQLType)) {
+				subFields := collectOrderedSubFields(eCtx, possibleType, fieldASTs)
+				field.PossibleTypes = append(field.PossibleTypes, &PlanPossibleType{
+					Type:   possibleType.Name,
+					Fields: planFields(eCtx, possibleType, subFields.fields, subFields.responseNames),
+				})
+			}
+		}
+		planned = append(planned, field)
+	}
+	return planned
+}
+
+// Returns the printed values of the arguments, nil if there are none.
+func planArguments(argASTs []*ast.Argument) map[string]string {
+	if len(argASTs) == 0 {
+		return nil
+	}
+	args := map[string]string{}
+	for _, argAST := range argASTs {
+		if argAST.Name != nil {
+			args[argAST.Name.Value] = fmt.Sprintf("%v", printer.Print(argAST.Value))
+		}
+	}
+	return args
+}
@@ -0,0 +1,110 @@
+package executor_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/testutil"
+)
+
+func TestExecutePlan_ListsTheFieldsOfTheBlogQueryWithoutResolvingThem(t *testing.T) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	query := `
+      query Feed {
+        feed {
+          ...articleFields
+          author {
+            name
+            id @skip(if: true)
+          }
+          body @include(if: false)
+        }
+      }
+
+      fragment articleFields on Article {
+        __typename
+        id
+        headline: title
+      }
+	`
+	// the fields of the fragment come first, as they are executed
+	expected := &executor.Plan{
+		Operation: "query",
+		RootType:  "Query",
+		Fields: []*executor.PlanField{
+			&executor.PlanField{
+				ResponseName: "feed",
+				FieldName:    "feed",
+				ParentType:   "Query",
+				Type:         "[Article]",
+				HasResolveFn: true,
+				Fields: []*executor.PlanField{
+					&executor.PlanField{
+						ResponseName: "__typename",
+						FieldName:    "__typename",
+						ParentType:   "Article",
+						Type:         "String!",
+						HasResolveFn: true,
+					},
+					&executor.PlanField{
+						ResponseName: "id",
+						FieldName:    "id",
+						ParentType:   "Article",
+						Type:         "String!",
+					},
+					&executor.PlanField{
+						ResponseName: "headline",
+						FieldName:    "title",
+						ParentType:   "Article",
+						Type:         "String",
+					},
+					&executor.PlanField{
+						ResponseName: "author",
+						FieldName:    "author",
+						ParentType:   "Article",
+						Type:         "Author",
+						Fields: []*executor.PlanField{
+							&executor.PlanField{
+								ResponseName: "name",
+								FieldName:    "name",
+								ParentType:   "Author",
+								Type:         "String",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	plan, err := executor.ExecutePlan(executor.ExecuteParams{
+		Schema: blogSchema,
+		AST:    testutil.Parse(t, query),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if !reflect.DeepEqual(expected, plan) {
+		t.Fatalf("Unexpected plan, Diff: %v", testutil.Diff(expected, plan))
+	}
+}
+
+func TestExecutePlan_ReportsUnknownOperations(t *testing.T) {
+	blogSchema, err := blogFeedSchema()
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	_, err = executor.ExecutePlan(executor.ExecuteParams{
+		Schema:        blogSchema,
+		AST:           testutil.Parse(t, `query Feed { feed { id } }`),
+		OperationName: "Other",
+	})
+	expected := `Unknown operation named "Other".`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got: %v", expected, err)
+	}
+}
@@ -0,0 +1,93 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Tracer starts a span for each resolved field, see ExecuteOptions.Tracer. It
+// is implemented by an adapter to a tracing library, e.g. OpenTelemetry, so
+// that the executor doesn't depend on one.
+type Tracer interface {
+	// StartSpan starts a span named after the field, e.g. `Query.article`,
+	// as a child of the span carried by ctx, which is the execution's
+	// context. The returned context, carrying the new span, is passed to the
+	// field's resolve function.
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is the span of a resolved field.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	// RecordError sets the span's status to error.
+	RecordError(err error)
+	End()
+}
+
+// Wraps the resolve function so that each call is recorded in a span of the
+// execution's tracer, with the error of the resolver, if any. A thunk ends
+// its span once it is resolved.
+func tracedResolveFn(eCtx *ExecutionContext, resolveFn types.GraphQLFieldResolveFn, path []interface{}) types.GraphQLFieldResolveFn {
+	if eCtx.Tracer == nil {
+		return resolveFn
+	}
+	return func(p types.GQLFRParams) (result interface{}) {
+		ctx, span := eCtx.Tracer.StartSpan(p.Context, fmt.Sprintf("%v.%v", p.Info.ParentType, p.Info.FieldName))
+		span.SetAttribute("graphql.field.name", p.Info.FieldName)
+		span.SetAttribute("graphql.field.path", pathString(path))
+		span.SetAttribute("graphql.field.type", fmt.Sprintf("%v", p.Info.ReturnType))
+		span.SetAttribute("graphql.parent.type", fmt.Sprintf("%v", p.Info.ParentType))
+		defer func() {
+			if r := recover(); r != nil {
+				span.RecordError(spanError(r))
+				span.End()
+				panic(r)
+			}
+		}()
+		p.Context = ctx
+		result = resolveFn(p)
+		if thunk, ok := result.(Thunk); ok {
+			return Thunk(func() interface{} {
+				defer span.End()
+				defer func() {
+					if r := recover(); r != nil {
+						span.RecordError(spanError(r))
+						panic(r)
+					}
+				}()
+				value := thunk()
+				if err, ok := value.(error); ok {
+					span.RecordError(err)
+				}
+				return value
+			})
+		}
+		if err, ok := result.(error); ok {
+			span.RecordError(err)
+		}
+		span.End()
+		return result
+	}
+}
+
+func spanError(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return errors.New(fmt.Sprintf("%v", r))
+}
+
+// Formats the path of a field as in `articles.1.title`.
+func pathString(path []interface{}) string {
+	s := ""
+	for i, segment := range path {
+		if i > 0 {
+			s += "."
+		}
+		s += fmt.Sprintf("%v", segment)
+	}
+	return s
+}
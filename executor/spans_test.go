@@ -0,0 +1,163 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+type spansTestSpan struct {
+	Name       string
+	Parent     interface{}
+	Attributes map[string]interface{}
+	Err        string
+	Ended      bool
+}
+
+func (s *spansTestSpan) SetAttribute(key string, value interface{}) {
+	s.Attributes[key] = value
+}
+
+func (s *spansTestSpan) RecordError(err error) {
+	s.Err = err.Error()
+}
+
+func (s *spansTestSpan) End() {
+	s.Ended = true
+}
+
+type spansTestTracer struct {
+	mu    sync.Mutex
+	spans []*spansTestSpan
+}
+
+func (t *spansTestTracer) StartSpan(ctx context.Context, name string) (context.Context, executor.Span) {
+	span := &spansTestSpan{
+		Name:       name,
+		Parent:     ctx.Value("trace"),
+		Attributes: map[string]interface{}{},
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, "span", name), span
+}
+
+func TestSpans_RecordsASpanPerResolvedFieldWithItsError(t *testing.T) {
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					panic(errors.New("Title is unavailable"))
+				},
+			},
+			"body": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return errors.New("Body is unavailable")
+				},
+			},
+		},
+	})
+	var resolverSpan interface{}
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: articleType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						resolverSpan = p.Context.Value("span")
+						return map[string]interface{}{"id": "1"}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	tracer := &spansTestTracer{}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema:  schema,
+		AST:     testutil.Parse(t, `{ article { id, title, body } }`),
+		Context: context.WithValue(context.Background(), "trace", "request"),
+		ExecuteOptions: executor.ExecuteOptions{
+			Tracer: tracer,
+		},
+	})
+	if len(result.Errors) != 2 {
+		t.Fatalf("Expected the errors of title and body, got: %v", result.Errors)
+	}
+
+	sort.Slice(tracer.spans, func(i, j int) bool {
+		return tracer.spans[i].Name < tracer.spans[j].Name
+	})
+	expected := []*spansTestSpan{
+		&spansTestSpan{
+			Name:   "Article.body",
+			Parent: "request",
+			Attributes: map[string]interface{}{
+				"graphql.field.name":  "body",
+				"graphql.field.path":  "article.body",
+				"graphql.field.type":  "String",
+				"graphql.parent.type": "Article",
+			},
+			Err:   "Body is unavailable",
+			Ended: true,
+		},
+		&spansTestSpan{
+			Name:   "Article.id",
+			Parent: "request",
+			Attributes: map[string]interface{}{
+				"graphql.field.name":  "id",
+				"graphql.field.path":  "article.id",
+				"graphql.field.type":  "String",
+				"graphql.parent.type": "Article",
+			},
+			Ended: true,
+		},
+		&spansTestSpan{
+			Name:   "Article.title",
+			Parent: "request",
+			Attributes: map[string]interface{}{
+				"graphql.field.name":  "title",
+				"graphql.field.path":  "article.title",
+				"graphql.field.type":  "String",
+				"graphql.parent.type": "Article",
+			},
+			Err:   "Title is unavailable",
+			Ended: true,
+		},
+		&spansTestSpan{
+			Name:   "Query.article",
+			Parent: "request",
+			Attributes: map[string]interface{}{
+				"graphql.field.name":  "article",
+				"graphql.field.path":  "article",
+				"graphql.field.type":  "Article",
+				"graphql.parent.type": "Query",
+			},
+			Ended: true,
+		},
+	}
+	if !reflect.DeepEqual(expected, tracer.spans) {
+		t.Fatalf("Unexpected spans, Diff: %v", testutil.Diff(expected, tracer.spans))
+	}
+	if resolverSpan != "Query.article" {
+		t.Fatalf("Expected the resolver's context to carry its span, got: %v", resolverSpan)
+	}
+}
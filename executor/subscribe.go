@@ -0,0 +1,297 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Subscribe executes the subscription operation of p. The subscription field
+// returns a source stream of events, a channel or an iterator of the form
+// func(yield func(interface{}) bool), and each event is executed the way
+// Execute does with the event as the root value, the results are sent on the
+// returned channel. The channel is closed once the source is exhausted or
+// p.Context is cancelled.
+//
+// The source is returned by the field's Subscribe function, and each event is
+// then resolved by its resolve function. Without a Subscribe function, the
+// resolve function returns the source and each event is the field's value.
+// The resolve function returns types.SkipEvent to filter out an event.
+//
+// The loaders of p.Loaders, the tracing and the extensions are created for
+// each event, as for an execution, so that an event's result reports its own
+// data and the values loaded for an event are loaded again for the next.
+//
+// An error is returned if the request is not a subscription selecting a
+// single field, an error creating the source is sent as the single result of
+// the channel.
+func Subscribe(p ExecuteParams) (chan *types.GraphQLResult, error) {
+	var result types.GraphQLResult
+	resultChan := make(chan *types.GraphQLResult, 1)
+	eCtx := buildExecutionContext(BuildExecutionCtxParams{
+		Schema:        p.Schema,
+		Root:          p.Root,
+		AST:           p.AST,
+		OperationName: p.OperationName,
+		Args:          p.Args,
+		Result:        &result,
+		ResultChan:    resultChan,
+	})
+	if result.HasErrors() {
+		return nil, result.Errors[0]
+	}
+	if eCtx.Operation.GetOperation() != "subscription" {
+		return nil, graphqlerrors.NewGraphQLFormattedError("Can only subscribe to subscription operations")
+	}
+	subscriptionType := eCtx.Schema.GetSubscriptionType()
+	if subscriptionType == nil {
+		return nil, graphqlerrors.NewGraphQLFormattedError("Schema is not configured for subscriptions")
+	}
+	maxDepth, maxComplexity := operationLimits(eCtx, p)
+	if maxDepth > 0 {
+		if err := checkMaxDepth(eCtx, maxDepth, p.IgnoreIntrospectionDepth); err != nil {
+			return nil, err
+		}
+	}
+	if maxComplexity > 0 {
+		eCtx.MaxComplexity = maxComplexity
+		if err := checkMaxComplexity(eCtx); err != nil {
+			return nil, err
+		}
+	}
+	eCtx.applyOptions(p)
+
+	fields := collectFields(CollectFieldsParams{
+		ExeContext:    eCtx,
+		OperationType: subscriptionType,
+		SelectionSet:  eCtx.Operation.GetSelectionSet(),
+	})
+	// the root field is checked below, its sub-fields when they are completed
+	eCtx.StrictFields = p.StrictFields
+	if len(fields) != 1 {
+		if name := eCtx.Operation.(*ast.OperationDefinition).Name; name != nil && name.Value != "" {
+			return nil, graphqlerrors.NewGraphQLFormattedError(
+				fmt.Sprintf(`Subscription "%v" must select only one top level field.`, name.Value))
+		}
+		return nil, graphqlerrors.NewGraphQLFormattedError("Anonymous Subscription must select only one top level field.")
+	}
+	var responseName string
+	var fieldASTs []*ast.Field
+	for responseName, fieldASTs = range fields {
+	}
+	fieldName := ""
+	if fieldASTs[0].Name != nil {
+		fieldName = fieldASTs[0].Name.Value
+	}
+	fieldDef := getFieldDef(eCtx.Schema, subscriptionType, fieldName)
+	if fieldDef == nil {
+		return nil, graphqlerrors.FormatError(graphqlerrors.NewLocatedError(
+			fmt.Sprintf(`The subscription field "%v" is not defined.`, fieldName),
+			graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
+		))
+	}
+
+	subscriptionChan := make(chan *types.GraphQLResult)
+	go func() {
+		defer close(subscriptionChan)
+		send := func(result *types.GraphQLResult) bool {
+			if p.ErrorFormatter != nil {
+				formatResultErrors(p.ErrorFormatter, result)
+			}
+			select {
+			case subscriptionChan <- result:
+				return true
+			case <-eCtx.Context.Done():
+				return false
+			}
+		}
+		path := []interface{}{responseName}
+		source, err := createSourceEventStream(eCtx, subscriptionType, fieldDef, fieldASTs)
+		if err != nil {
+			send(&types.GraphQLResult{
+				Errors: []graphqlerrors.GraphQLFormattedError{withPath(graphqlerrors.FormatError(err), path)},
+			})
+			return
+		}
+		forEachEvent(eCtx.Context, source, func(event interface{}) bool {
+			result := executeSubscriptionEvent(eCtx, p, subscriptionType, fieldDef, fields, event)
+			if result == nil {
+				return true
+			}
+			return send(result)
+		})
+	}()
+	return subscriptionChan, nil
+}
+
+// Calls the subscription field's Subscribe function, or its resolve function
+// if it has none, to create the source stream of events.
+func createSourceEventStream(eCtx *ExecutionContext, parentType *types.GraphQLObjectType, fieldDef *types.GraphQLFieldDefinition, fieldASTs []*ast.Field) (source interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch r := r.(type) {
+			case graphqlerrors.GraphQLFormattedError:
+				err = r
+			case error:
+				err = graphqlerrors.NewLocatedError(r, graphqlerrors.FieldASTsToNodeASTs(fieldASTs))
+			default:
+				err = graphqlerrors.NewLocatedError(fmt.Sprintf("%v", r), graphqlerrors.FieldASTsToNodeASTs(fieldASTs))
+			}
+		}
+	}()
+
+	args, err := getArgumentValues(fieldDef.Args, fieldASTs[0].Arguments, eCtx.VariableValues)
+	if err != nil {
+		return nil, graphqlerrors.NewLocatedError(err, graphqlerrors.FieldASTsToNodeASTs(fieldASTs))
+	}
+	subscribeFn := fieldDef.Subscribe
+	if subscribeFn == nil {
+		subscribeFn = types.GraphQLFieldSubscribeFn(fieldDef.Resolve)
+	}
+	if subscribeFn == nil {
+		subscribeFn = types.GraphQLFieldSubscribeFn(defaultResolveFn)
+	}
+	source = subscribeFn(types.GQLFRParams{
+		Source: eCtx.Root,
+		Args:   args,
+		Info: types.GraphQLResolveInfo{
+			FieldName:      fieldDef.Name,
+			Path:           []interface{}{getFieldEntryKey(fieldASTs[0])},
+			FieldASTs:      fieldASTs,
+			ReturnType:     fieldDef.Type,
+			ParentType:     parentType,
+			Schema:         eCtx.Schema,
+			Fragments:      eCtx.Fragments,
+			RootValue:      eCtx.Root,
+			Operation:      eCtx.Operation,
+			VariableValues: eCtx.VariableValues,
+		},
+		Context: eCtx.Context,
+	})
+	if err, ok := source.(error); ok {
+		return nil, graphqlerrors.NewLocatedError(err, graphqlerrors.FieldASTsToNodeASTs(fieldASTs))
+	}
+	if !isEventStream(source) {
+		return nil, graphqlerrors.NewLocatedError(
+			fmt.Sprintf(`Subscription field "%v" must return a channel or an iterator, got: %T.`, fieldDef.Name, source),
+			graphqlerrors.FieldASTsToNodeASTs(fieldASTs),
+		)
+	}
+	return source, nil
+}
+
+func isEventStream(source interface{}) bool {
+	if _, ok := source.(func(yield func(interface{}) bool)); ok {
+		return true
+	}
+	sourceVal := reflect.ValueOf(source)
+	return sourceVal.IsValid() && sourceVal.Kind() == reflect.Chan && sourceVal.Type().ChanDir()&reflect.RecvDir != 0
+}
+
+// Calls fn with each event of the source until fn returns false, the source
+// is exhausted or ctx is cancelled.
+func forEachEvent(ctx context.Context, source interface{}, fn func(event interface{}) bool) {
+	if iterator, ok := source.(func(yield func(interface{}) bool)); ok {
+		iterator(func(event interface{}) bool {
+			return ctx.Err() == nil && fn(event)
+		})
+		return
+	}
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(source)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	}
+	for {
+		chosen, event, ok := reflect.Select(cases)
+		if chosen != 0 || !ok || !fn(event.Interface()) {
+			return
+		}
+	}
+}
+
+// Maps an event of the source stream, the source of p, to the value of the
+// subscription field. The event is the value unless the field has a Subscribe
+// function creating the source stream, its resolve function then maps the
+// event, and skipped is true if it returns types.SkipEvent.
+func resolveSubscriptionEvent(fieldDef *types.GraphQLFieldDefinition, p types.GQLFRParams) (value interface{}, skipped bool) {
+	if fieldDef.Subscribe == nil {
+		return p.Source, false
+	}
+	resolveFn := fieldDef.Resolve
+	if resolveFn == nil {
+		resolveFn = defaultResolveFn
+	}
+	value = resolveFn(p)
+	if value == types.SkipEvent {
+		return nil, true
+	}
+	return value, false
+}
+
+// Executes the operation for an event of the source stream with the loaders,
+// the tracing and the extensions of p created for the event, returns nil if
+// the event is skipped.
+func executeSubscriptionEvent(eCtx *ExecutionContext, p ExecuteParams, subscriptionType *types.GraphQLObjectType, fieldDef *types.GraphQLFieldDefinition, fields map[string][]*ast.Field, event interface{}) (result *types.GraphQLResult) {
+	eventCtx := *eCtx
+	eventCtx.Root = event
+	eventCtx.Errors = nil
+	eventCtx.deferred = nil
+	eventCtx.truncatedLists = nil
+	eventCtx.tracing = nil
+	if p.EnableTracing {
+		eventCtx.tracing = newTracing()
+	}
+	eventCtx.Extensions = newExtensions(p)
+	if len(p.Loaders) > 0 {
+		// shadow the loaders of the subscription's context
+		eventCtx.Context = WithLoaders(eventCtx.Context, p.Loaders)
+	}
+	skipped := false
+	eventCtx.eventResolveFn = func(p types.GQLFRParams) interface{} {
+		var value interface{}
+		value, skipped = resolveSubscriptionEvent(fieldDef, p)
+		return value
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(reportedError); !ok {
+				var err error
+				if r, ok := r.(error); ok {
+					err = r
+				} else {
+					err = fmt.Errorf("%v", r)
+				}
+				eventCtx.Errors = append(eventCtx.Errors, graphqlerrors.FormatError(err))
+			}
+			result = &types.GraphQLResult{Errors: eventCtx.Errors, Extensions: eventCtx.extensionsResult()}
+		}
+	}()
+
+	for _, extension := range eventCtx.Extensions {
+		extension.ExecutionDidStart(&eventCtx)
+	}
+
+	// a subscription selects a single field
+	responseNames := []string{}
+	for responseName := range fields {
+		responseNames = append(responseNames, responseName)
+	}
+	results := executeFields(ExecuteFieldsParams{
+		ExecutionContext: &eventCtx,
+		ParentType:       subscriptionType,
+		Source:           event,
+		Fields:           fields,
+		ResponseNames:    responseNames,
+	})
+	eventCtx.resolveDeferred()
+	if skipped {
+		return nil
+	}
+	results.Errors = eventCtx.Errors
+	results.Extensions = eventCtx.extensionsResult()
+	return &results
+}
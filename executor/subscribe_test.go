@@ -0,0 +1,295 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+var subscribeTestEmailType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Email",
+	Fields: types.GraphQLFieldConfigMap{
+		"from": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"subject": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+	},
+})
+
+// events of the ticks field, sent by the test of the cancellation
+var subscribeTestTicks = make(chan interface{})
+
+var subscribeTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"inbox": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	}),
+	Subscription: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Subscription",
+		Fields: types.GraphQLFieldConfigMap{
+			"importantEmail": &types.GraphQLFieldConfig{
+				Type: subscribeTestEmailType,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"priority": &types.GraphQLArgumentConfig{
+						Type:         types.GraphQLInt,
+						DefaultValue: 0,
+					},
+				},
+				Subscribe: func(p types.GQLFRParams) interface{} {
+					events := make(chan map[string]interface{}, 3)
+					events <- map[string]interface{}{"from": "a@example.com", "subject": "Hello", "priority": 2}
+					events <- map[string]interface{}{"from": "b@example.com", "subject": "Spam", "priority": 0}
+					events <- map[string]interface{}{"from": "c@example.com", "subject": "Urgent", "priority": 3}
+					close(events)
+					return events
+				},
+				Resolve: func(p types.GQLFRParams) interface{} {
+					event := p.Source.(map[string]interface{})
+					// below the threshold
+					if event["priority"].(int) < p.Args["priority"].(int) {
+						return types.SkipEvent
+					}
+					return map[string]interface{}{"from": event["from"], "subject": "Re: " + event["subject"].(string)}
+				},
+			},
+			"counter": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return func(yield func(interface{}) bool) {
+						for i := 1; i <= 3; i++ {
+							if !yield(i) {
+								return
+							}
+						}
+					}
+				},
+			},
+			"ticks": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return subscribeTestTicks
+				},
+			},
+			"version": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Subscribe: func(p types.GQLFRParams) interface{} {
+					releases := make(chan interface{}, 2)
+					releases <- "release"
+					releases <- "release"
+					close(releases)
+					return releases
+				},
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return executor.LoaderFrom(p.Context, "version").Load("latest")
+				},
+			},
+			"unauthorized": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+				Subscribe: func(p types.GQLFRParams) interface{} {
+					return errors.New("not authorized")
+				},
+			},
+		},
+	}),
+})
+
+func collectSubscriptionResults(t *testing.T, ep executor.ExecuteParams) []*types.GraphQLResult {
+	resultChan, err := executor.Subscribe(ep)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	results := []*types.GraphQLResult{}
+	for result := range resultChan {
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestSubscribe_ResolvesTheEventsOfTheSource(t *testing.T) {
+	type Test struct {
+		query    string
+		expected []*types.GraphQLResult
+	}
+	emailResult := func(from, subject string) *types.GraphQLResult {
+		return &types.GraphQLResult{
+			Data: map[string]interface{}{
+				"importantEmail": map[string]interface{}{
+					"from":    from,
+					"subject": subject,
+				},
+			},
+		}
+	}
+	tests := []Test{
+		// each event of the Subscribe function's source is resolved
+		Test{
+			query: `subscription S { importantEmail { from subject } }`,
+			expected: []*types.GraphQLResult{
+				emailResult("a@example.com", "Re: Hello"),
+				emailResult("b@example.com", "Re: Spam"),
+				emailResult("c@example.com", "Re: Urgent"),
+			},
+		},
+		// the events resolved to SkipEvent are skipped
+		Test{
+			query: `subscription S { importantEmail(priority: 1) { from subject } }`,
+			expected: []*types.GraphQLResult{
+				emailResult("a@example.com", "Re: Hello"),
+				emailResult("c@example.com", "Re: Urgent"),
+			},
+		},
+		// the events of an iterator returned by the resolver are the values
+		// of the field
+		Test{
+			query: `subscription S { count: counter }`,
+			expected: []*types.GraphQLResult{
+				&types.GraphQLResult{Data: map[string]interface{}{"count": 1}},
+				&types.GraphQLResult{Data: map[string]interface{}{"count": 2}},
+				&types.GraphQLResult{Data: map[string]interface{}{"count": 3}},
+			},
+		},
+		// an error creating the source is sent as a single result
+		Test{
+			query: `subscription S { unauthorized }`,
+			expected: []*types.GraphQLResult{
+				&types.GraphQLResult{
+					Errors: []graphqlerrors.GraphQLFormattedError{
+						graphqlerrors.GraphQLFormattedError{
+							Message: "not authorized",
+							Locations: []location.SourceLocation{
+								location.SourceLocation{Line: 1, Column: 18},
+							},
+							Path: []interface{}{"unauthorized"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		results := collectSubscriptionResults(t, executor.ExecuteParams{
+			Schema: subscribeTestSchema,
+			AST:    testutil.Parse(t, test.query),
+		})
+		for _, result := range results {
+			for i := range result.Errors {
+				result.Errors[i].OriginalError = nil
+			}
+		}
+		if !reflect.DeepEqual(test.expected, results) {
+			t.Fatalf("Unexpected results of %q, Diff: %v", test.query, testutil.Diff(test.expected, results))
+		}
+	}
+}
+
+func TestSubscribe_ClosesTheResultChannelOnceTheContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	resultChan, err := executor.Subscribe(executor.ExecuteParams{
+		Schema:  subscribeTestSchema,
+		AST:     testutil.Parse(t, `subscription S { ticks }`),
+		Context: ctx,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	subscribeTestTicks <- 1
+	expected := &types.GraphQLResult{Data: map[string]interface{}{"ticks": 1}}
+	if result := <-resultChan; !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	cancel()
+	if result, ok := <-resultChan; ok {
+		t.Fatalf("Expected the result channel to be closed, got: %v", result)
+	}
+}
+
+func TestSubscribe_CreatesTheLoadersTracingAndExtensionsOfEachEvent(t *testing.T) {
+	versions := 0
+	results := collectSubscriptionResults(t, executor.ExecuteParams{
+		Schema: subscribeTestSchema,
+		AST:    testutil.Parse(t, `subscription S { version }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			Loaders: map[string]executor.BatchFn{
+				"version": func(keys []interface{}) []interface{} {
+					versions++
+					return []interface{}{fmt.Sprintf("v%v", versions)}
+				},
+			},
+			EnableTracing: true,
+			Extensions: []executor.ExtensionFactory{
+				func() executor.Extension { return &testCountingExtension{} },
+			},
+		},
+	})
+
+	// the value loaded for the first event is loaded again for the second
+	expectedData := []interface{}{
+		map[string]interface{}{"version": "v1"},
+		map[string]interface{}{"version": "v2"},
+	}
+	expectedCounting := map[string]interface{}{
+		"inits":          1,
+		"executions":     1,
+		"resolvedFields": []string{"version"},
+	}
+	if len(results) != len(expectedData) {
+		t.Fatalf("Expected %v results, got: %v", len(expectedData), results)
+	}
+	for i, result := range results {
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		if !reflect.DeepEqual(expectedData[i], result.Data) {
+			t.Fatalf("Unexpected data of event %v, Diff: %v", i, testutil.Diff(expectedData[i], result.Data))
+		}
+		if !reflect.DeepEqual(expectedCounting, result.Extensions["counting"]) {
+			t.Fatalf("Unexpected extension of event %v, Diff: %v", i, testutil.Diff(expectedCounting, result.Extensions["counting"]))
+		}
+		tracing, ok := result.Extensions["tracing"].(*executor.Tracing)
+		if !ok || len(tracing.Execution.Resolvers) != 1 {
+			t.Fatalf("Expected the tracing of the event's single field, got: %v", result.Extensions["tracing"])
+		}
+	}
+}
+
+func TestSubscribe_ReturnsAnErrorIfTheSubscriptionDoesNotSelectASingleField(t *testing.T) {
+	tests := []struct {
+		query   string
+		message string
+	}{
+		{
+			query:   `subscription Counters { a: counter b: counter }`,
+			message: `Subscription "Counters" must select only one top level field.`,
+		},
+		{
+			query:   `{ inbox }`,
+			message: `Can only subscribe to subscription operations`,
+		},
+	}
+	for _, test := range tests {
+		_, err := executor.Subscribe(executor.ExecuteParams{
+			Schema: subscribeTestSchema,
+			AST:    testutil.Parse(t, test.query),
+		})
+		if err == nil || err.Error() != test.message {
+			t.Fatalf("Expected error %q, got: %v", test.message, err)
+		}
+		if _, ok := err.(graphqlerrors.GraphQLFormattedError); !ok {
+			t.Fatalf("Expected a GraphQLFormattedError, got: %T", err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package executor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Tracing is the timing data reported under the result's `tracing`
+// extension when ExecuteOptions.EnableTracing is set, following the Apollo
+// tracing format. Durations and offsets are in nanoseconds.
+type Tracing struct {
+	Version   int              `json:"version"`
+	StartTime time.Time        `json:"startTime"`
+	EndTime   time.Time        `json:"endTime"`
+	Duration  int64            `json:"duration"`
+	Execution TracingExecution `json:"execution"`
+}
+
+type TracingExecution struct {
+	Resolvers []*TracingResolver `json:"resolvers"`
+}
+
+// TracingResolver is the timing of a single resolved field, StartOffset is
+// relative to the start of the execution.
+type TracingResolver struct {
+	Path        []interface{} `json:"path"`
+	ParentType  string        `json:"parentType"`
+	FieldName   string        `json:"fieldName"`
+	ReturnType  string        `json:"returnType"`
+	StartOffset int64         `json:"startOffset"`
+	Duration    int64         `json:"duration"`
+}
+
+func newTracing() *Tracing {
+	return &Tracing{
+		Version:   1,
+		StartTime: time.Now(),
+		Execution: TracingExecution{
+			Resolvers: []*TracingResolver{},
+		},
+	}
+}
+
+// Records the start of the field's resolve function, the returned function
+// records its end.
+func (tracing *Tracing) resolveField(info types.GraphQLResolveInfo, path []interface{}) func() {
+	start := time.Now()
+	resolver := &TracingResolver{
+		Path:        path,
+		ParentType:  fmt.Sprintf("%v", info.ParentType),
+		FieldName:   info.FieldName,
+		ReturnType:  fmt.Sprintf("%v", info.ReturnType),
+		StartOffset: start.Sub(tracing.StartTime).Nanoseconds(),
+	}
+	tracing.Execution.Resolvers = append(tracing.Execution.Resolvers, resolver)
+	return func() {
+		resolver.Duration = time.Since(start).Nanoseconds()
+	}
+}
+
+// Records the end of the execution.
+func (tracing *Tracing) result() *Tracing {
+	tracing.EndTime = time.Now()
+	tracing.Duration = tracing.EndTime.Sub(tracing.StartTime).Nanoseconds()
+	return tracing
+}
@@ -0,0 +1,109 @@
+package executor_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+var tracingTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"a": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return "Apple"
+				},
+			},
+			"list": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLList(types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+					Name: "Item",
+					Fields: types.GraphQLFieldConfigMap{
+						"b": &types.GraphQLFieldConfig{
+							Type: types.GraphQLString,
+						},
+					},
+				})),
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return []interface{}{
+						map[string]interface{}{"b": "Banana"},
+					}
+				},
+			},
+		},
+	}),
+})
+
+func TestTracing_ReportsTheTimingOfEachResolvedField(t *testing.T) {
+	ep := executor.ExecuteParams{
+		Schema: tracingTestSchema,
+		AST:    testutil.Parse(t, `{ a, list { b } }`),
+		ExecuteOptions: executor.ExecuteOptions{
+			EnableTracing: true,
+		},
+	}
+	result := testutil.Execute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	tracing, ok := result.Extensions["tracing"].(*executor.Tracing)
+	if !ok {
+		t.Fatalf("Expected tracing extension, got: %v", result.Extensions)
+	}
+	if tracing.Version != 1 {
+		t.Fatalf("Unexpected tracing version: %v", tracing.Version)
+	}
+	if tracing.EndTime.Before(tracing.StartTime) || tracing.Duration != tracing.EndTime.Sub(tracing.StartTime).Nanoseconds() {
+		t.Fatalf("Unexpected tracing times: %v, %v, %v", tracing.StartTime, tracing.EndTime, tracing.Duration)
+	}
+
+	expected := map[string]executor.TracingResolver{
+		"[a]": executor.TracingResolver{
+			Path:       []interface{}{"a"},
+			ParentType: "Query",
+			FieldName:  "a",
+			ReturnType: "String!",
+		},
+		"[list]": executor.TracingResolver{
+			Path:       []interface{}{"list"},
+			ParentType: "Query",
+			FieldName:  "list",
+			ReturnType: "[Item]",
+		},
+		"[list 0 b]": executor.TracingResolver{
+			Path:       []interface{}{"list", 0, "b"},
+			ParentType: "Item",
+			FieldName:  "b",
+			ReturnType: "String",
+		},
+	}
+	resolvers := map[string]executor.TracingResolver{}
+	for _, resolver := range tracing.Execution.Resolvers {
+		if resolver.StartOffset < 0 || resolver.Duration < 0 ||
+			resolver.StartOffset+resolver.Duration > tracing.Duration {
+			t.Fatalf("Unexpected resolver timing: %+v", resolver)
+		}
+		traced := *resolver
+		traced.StartOffset, traced.Duration = 0, 0
+		resolvers[fmt.Sprintf("%v", resolver.Path)] = traced
+	}
+	if !reflect.DeepEqual(expected, resolvers) {
+		t.Fatalf("Unexpected resolvers, Diff: %v", testutil.Diff(expected, resolvers))
+	}
+}
+
+func TestTracing_IsNotReportedUnlessEnabled(t *testing.T) {
+	ep := executor.ExecuteParams{
+		Schema: tracingTestSchema,
+		AST:    testutil.Parse(t, `{ a }`),
+	}
+	result := testutil.Execute(t, ep)
+	if result.Extensions != nil {
+		t.Fatalf("Unexpected extensions: %v", result.Extensions)
+	}
+}
@@ -570,3 +570,54 @@ func TestUnionIntersectionTypes_GetsExecutionInfoInResolver(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestUnionIntersectionTypes_ResolvesTypenameThroughAliasesAndFragments(t *testing.T) {
+	doc := `
+      {
+        kind: __typename
+        friends {
+          __typename
+          ...NamedFields
+        }
+        pets {
+          ... on Dog { dogKind: __typename }
+          ... on Cat { catKind: __typename }
+        }
+      }
+      fragment NamedFields on Named {
+        kind: __typename
+      }
+	`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"kind": "Person",
+			"friends": []interface{}{
+				map[string]interface{}{
+					"__typename": "Person",
+					"kind":       "Person",
+				},
+				map[string]interface{}{
+					"__typename": "Dog",
+					"kind":       "Dog",
+				},
+			},
+			"pets": []interface{}{
+				map[string]interface{}{
+					"catKind": "Cat",
+				},
+				map[string]interface{}{
+					"dogKind": "Dog",
+				},
+			},
+		},
+	}
+	ep := executor.ExecuteParams{
+		Schema: unionInterfaceTestSchema,
+		AST:    testutil.Parse(t, doc),
+		Root:   john,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
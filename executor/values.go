@@ -10,25 +10,64 @@ import (
 	"github.com/chris-ramon/graphql-go/types"
 	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
-// Prepares an object map of variableValues of the correct type based on the
-// provided variable definitions and arbitrary input. If the input cannot be
-// parsed to match the variable definitions, a GraphQLError will be returned.
-func getVariableValues(schema types.GraphQLSchema, definitionASTs []*ast.VariableDefinition, inputs map[string]interface{}) (map[string]interface{}, error) {
+// CoerceVariableValues returns the values of the variables of an operation
+// coerced to the types the operation declares, as Execute does before
+// executing the operation, e.g. for a gateway to reject invalid variables
+// beforehand. The default value of a variable which is not provided is used,
+// a variable which is neither provided nor defaulted is left out, and an
+// explicit null is kept as nil. A GraphQLError is returned for each of the
+// variables whose input cannot be coerced, or of a non-null type but not
+// provided.
+func CoerceVariableValues(schema types.GraphQLSchema, operation *ast.OperationDefinition, inputs map[string]interface{}) (map[string]interface{}, []error) {
 	values := map[string]interface{}{}
-	for _, defAST := range definitionASTs {
+	errs := []error{}
+	if operation == nil {
+		return values, errs
+	}
+	for _, defAST := range operation.VariableDefinitions {
 		if defAST == nil || defAST.Variable == nil || defAST.Variable.Name == nil {
 			continue
 		}
 		varName := defAST.Variable.Name.Value
-		varValue, err := getVariableValue(schema, defAST, inputs[varName])
+		input, provided := inputs[varName]
+		varValue, err := getVariableValue(schema, defAST, input)
 		if err != nil {
-			return values, err
+			errs = append(errs, err)
+			continue
+		}
+		// Variables which are neither provided nor defaulted are left out, so
+		// that their usages are undefined rather than null.
+		if !provided && varValue == nil {
+			continue
+		}
+		// an explicit null overrides the default value
+		if provided && input == nil {
+			varValue = nil
 		}
 		values[varName] = varValue
 	}
-	return values, nil
+	return values, errs
+}
+
+// Returns a copy of the operation declaring only the variables which are
+// provided by inputs or have a default value.
+func withProvidedVariables(operation *ast.OperationDefinition, inputs map[string]interface{}) *ast.OperationDefinition {
+	provided := *operation
+	provided.VariableDefinitions = []*ast.VariableDefinition{}
+	for _, defAST := range operation.VariableDefinitions {
+		if defAST == nil || defAST.Variable == nil || defAST.Variable.Name == nil {
+			continue
+		}
+		if _, ok := inputs[defAST.Variable.Name.Value]; ok || defAST.DefaultValue != nil {
+			provided.VariableDefinitions = append(provided.VariableDefinitions, defAST)
+		}
+	}
+	return &provided
 }
 
 // Prepares an object map of argument values given a list of argument
@@ -49,8 +88,20 @@ func getArgumentValues(argDefs []*types.GraphQLArgument, argASTs []*ast.Argument
 		if argAST, ok := argASTMap[name]; ok {
 			valueAST = argAST.Value
 		}
-		value := valueFromAST(valueAST, argDef.Type, variableVariables)
-		if isNullish(value) {
+		value, invalid := valueFromASTAtPath(valueAST, argDef.Type, variableVariables, []string{name})
+		// an invalid value, e.g. an out of range Int or a list given to an
+		// input object argument, is reported rather than replaced by the
+		// argument's default value
+		if invalid != nil {
+			return results, invalidArgumentError(name, invalid)
+		}
+		// an explicit null, as opposed to a missing argument, is passed to
+		// the resolver as such
+		if value == null {
+			results[name] = nil
+			continue
+		}
+		if value == undefined || isNullish(value) {
 			value = argDef.DefaultValue
 		}
 		if !isNullish(value) {
@@ -60,13 +111,43 @@ func getArgumentValues(argDefs []*types.GraphQLArgument, argASTs []*ast.Argument
 	return results, nil
 }
 
+// Returns the error reporting the invalid literal of an argument, located at
+// the literal, or at each of the invalid fields of an input object.
+func invalidArgumentError(name string, invalid *invalidLiteral) *graphqlerrors.GraphQLError {
+	if len(invalid.Problems) == 0 {
+		at := ""
+		if len(invalid.Path) > 1 {
+			at = fmt.Sprintf(` at "%v"`, strings.Join(invalid.Path, "."))
+		}
+		err := graphqlerrors.NewGraphQLError(
+			fmt.Sprintf(`Argument "%v" has invalid value%v: %v.`, name, at, invalid.describe()),
+			[]ast.Node{invalid.ValueAST},
+			"",
+			nil,
+			[]int{},
+		)
+		err.ArgumentPath = invalid.Path
+		return err
+	}
+	lines := []string{fmt.Sprintf(`Argument "%v" has %v invalid values:`, name, len(invalid.Problems))}
+	nodes := []ast.Node{}
+	located := map[ast.Value]bool{}
+	for _, problem := range invalid.Problems {
+		lines = append(lines, fmt.Sprintf(`At "%v": %v.`, strings.Join(problem.Path, "."), problem.describe()))
+		if !located[problem.ValueAST] {
+			located[problem.ValueAST] = true
+			nodes = append(nodes, problem.ValueAST)
+		}
+	}
+	err := graphqlerrors.NewGraphQLError(strings.Join(lines, "\n"), nodes, "", nil, []int{})
+	err.ArgumentPath = invalid.Path
+	return err
+}
+
 // Given a variable definition, and any value of input, return a value which
 // adheres to the variable definition, or throw an error.
 func getVariableValue(schema types.GraphQLSchema, definitionAST *ast.VariableDefinition, input interface{}) (interface{}, error) {
-	ttype, err := typeFromAST(schema, definitionAST.Type)
-	if err != nil {
-		return nil, err
-	}
+	ttype := types.TypeFromAST(schema, definitionAST.Type)
 	variable := definitionAST.Variable
 
 	if ttype == nil || !types.IsInputType(ttype) {
@@ -86,6 +167,9 @@ func getVariableValue(schema types.GraphQLSchema, definitionAST *ast.VariableDef
 			if defaultValue != nil {
 				variables := map[string]interface{}{}
 				val := valueFromAST(defaultValue, ttype, variables)
+				if val == undefined {
+					return nil, nil
+				}
 				return val, nil
 			}
 		}
@@ -106,9 +190,13 @@ func getVariableValue(schema types.GraphQLSchema, definitionAST *ast.VariableDef
 	if err == nil {
 		inputStr = string(b)
 	}
+	nullAt := ""
+	if path, itemType := nullItemPath(input, ttype, []string{}); path != nil {
+		nullAt = fmt.Sprintf(` Item at "%v" of type "%v" must not be null.`, strings.Join(path, "."), itemType)
+	}
 	return "", graphqlerrors.NewGraphQLError(
 		fmt.Sprintf(`Variable "$%v" expected value of type `+
-			`"%v" but got: %v.`, variable.Name.Value, printer.Print(definitionAST.Type), inputStr),
+			`"%v" but got: %v.%v`, variable.Name.Value, printer.Print(definitionAST.Type), inputStr, nullAt),
 		[]ast.Node{definitionAST},
 		"",
 		nil,
@@ -148,7 +236,12 @@ func coerceValue(ttype types.GraphQLInputType, value interface{}) interface{} {
 
 		obj := map[string]interface{}{}
 		for fieldName, field := range ttype.GetFields() {
-			value, _ := valueMap[fieldName]
+			value, ok := valueMap[fieldName]
+			// an explicit null overrides the field's default value
+			if ok && value == nil {
+				obj[fieldName] = nil
+				continue
+			}
 			fieldValue := coerceValue(field.Type, value)
 			if isNullish(fieldValue) {
 				fieldValue = field.DefaultValue
@@ -160,50 +253,12 @@ func coerceValue(ttype types.GraphQLInputType, value interface{}) interface{} {
 		return obj
 	}
 
-	switch ttype := ttype.(type) {
-	case *types.GraphQLScalarType:
-		parsed := ttype.ParseValue(value)
-		if !isNullish(parsed) {
-			return parsed
-		}
-	case *types.GraphQLEnumType:
-		parsed := ttype.ParseValue(value)
-		if !isNullish(parsed) {
-			return parsed
-		}
+	if parsed := parseValue(ttype, value); !isNullish(parsed) {
+		return parsed
 	}
 	return nil
 }
 
-// graphql-js/src/utilities.js`
-// TODO: figure out where to organize utils
-
-func typeFromAST(schema types.GraphQLSchema, inputTypeAST ast.Type) (types.GraphQLType, error) {
-	switch inputTypeAST := inputTypeAST.(type) {
-	case *ast.ListType:
-		innerType, err := typeFromAST(schema, inputTypeAST.Type)
-		if err != nil {
-			return nil, err
-		}
-		return types.NewGraphQLList(innerType), nil
-	case *ast.NonNullType:
-		innerType, err := typeFromAST(schema, inputTypeAST.Type)
-		if err != nil {
-			return nil, err
-		}
-		return types.NewGraphQLNonNull(innerType), nil
-	case *ast.NamedType:
-		nameValue := ""
-		if inputTypeAST.Name != nil {
-			nameValue = inputTypeAST.Name.Value
-		}
-		ttype := schema.GetType(nameValue)
-		return ttype, nil
-	default:
-		return nil, invariant(inputTypeAST.GetKind() == kinds.NamedType, "Must be a named type.")
-	}
-}
-
 // isValidInputValue alias isValidJSValue
 // Given a value and a GraphQL type, determine if the value will be
 // accepted for that type. This is primarily useful for validating the
@@ -261,17 +316,68 @@ func isValidInputValue(value interface{}, ttype types.GraphQLInputType) bool {
 		return true
 	}
 
-	switch ttype := ttype.(type) {
-	case *types.GraphQLScalarType:
-		parsedVal := ttype.ParseValue(value)
-		return !isNullish(parsedVal)
-	case *types.GraphQLEnumType:
-		parsedVal := ttype.ParseValue(value)
-		return !isNullish(parsedVal)
+	// A list can't be coerced to a single value, while a single value is
+	// coerced to a list of it.
+	if valType := reflect.ValueOf(value); valType.Kind() == reflect.Slice {
+		return false
+	}
+	switch ttype.(type) {
+	case *types.GraphQLScalarType, *types.GraphQLEnumType:
+		return !isNullish(parseValue(ttype, value))
 	}
 	return false
 }
 
+// Returns the path within a value to its first null item of a list of
+// non-null items, and the type of the item, nil if there is none.
+func nullItemPath(value interface{}, ttype types.GraphQLInputType, path []string) ([]string, types.GraphQLInputType) {
+	if nonNull, ok := ttype.(*types.GraphQLNonNull); ok {
+		ttype = nonNull.OfType
+	}
+	if isNullish(value) {
+		return nil, nil
+	}
+	switch ttype := ttype.(type) {
+	case *types.GraphQLList:
+		valType := reflect.ValueOf(value)
+		if valType.Kind() == reflect.Ptr {
+			valType = valType.Elem()
+		}
+		if valType.Kind() != reflect.Slice {
+			return nil, nil
+		}
+		_, itemNonNull := ttype.OfType.(*types.GraphQLNonNull)
+		for i := 0; i < valType.Len(); i++ {
+			itemPath := append(append([]string{}, path...), strconv.Itoa(i))
+			item := valType.Index(i).Interface()
+			if itemNonNull && isNullish(item) {
+				return itemPath, ttype.OfType
+			}
+			if nullPath, itemType := nullItemPath(item, ttype.OfType, itemPath); nullPath != nil {
+				return nullPath, itemType
+			}
+		}
+	case *types.GraphQLInputObjectType:
+		valueMap, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		fields := ttype.GetFields()
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			fieldPath := append(append([]string{}, path...), fieldName)
+			if nullPath, itemType := nullItemPath(valueMap[fieldName], fields[fieldName].Type, fieldPath); nullPath != nil {
+				return nullPath, itemType
+			}
+		}
+	}
+	return nil, nil
+}
+
 // Returns true if a value is null, undefined, or NaN.
 func isNullish(value interface{}) bool {
 	if value, ok := value.(string); ok {
@@ -289,6 +395,12 @@ func isNullish(value interface{}) bool {
 	return value == nil
 }
 
+// Returns true if the value is a typed nil slice, e.g. []string(nil).
+func isNilSlice(value interface{}) bool {
+	valueVal := reflect.ValueOf(value)
+	return valueVal.IsValid() && valueVal.Kind() == reflect.Slice && valueVal.IsNil()
+}
+
 /**
  * Produces a value given a GraphQL Value AST.
  *
@@ -303,94 +415,295 @@ func isNullish(value interface{}) bool {
  * | String / Enum Value  | String        |
  * | Int / Float          | Number        |
  *
+ * A missing value AST or a variable missing from the variables produces
+ * undefined, so that the caller can apply its default value.
  */
 func valueFromAST(valueAST ast.Value, ttype types.GraphQLInputType, variables map[string]interface{}) interface{} {
+	value, _ := valueFromASTAtPath(valueAST, ttype, variables, []string{})
+	if value == null {
+		return nil
+	}
+	return value
+}
 
-	if ttype, ok := ttype.(*types.GraphQLNonNull); ok {
-		val := valueFromAST(valueAST, ttype.OfType, variables)
-		return val
+type undefinedValue struct{}
+
+// undefined is the value of a literal which is not provided, i.e. a missing
+// value AST or a variable missing from the variables, as opposed to a null
+// value. The default value of the argument or input object field applies to
+// it.
+var undefined = &undefinedValue{}
+
+type nullValue struct{}
+
+// null is the value of a `null` literal, or of a variable provided as null,
+// as opposed to an undefined value. The default value of the argument or
+// input object field does not apply to it.
+var null = &nullValue{}
+
+// Describes a literal that could not be coerced to its expected type, and
+// where it sits within the value being coerced.
+type invalidLiteral struct {
+	Path     []string
+	Type     types.GraphQLInputType
+	ValueAST ast.Value
+	// Why the literal is invalid, e.g. an unknown input object field, if it
+	// is not a value of the wrong type.
+	Reason string
+	// The invalid literals within an input object, in the order of its
+	// fields, if there are more than one.
+	Problems []*invalidLiteral
+	// The error a scalar returned or panicked with when parsing the literal.
+	Cause error
+}
+
+// Describes the problem with the literal, e.g. `Expected type "Int", found
+// "abc"`.
+func (invalid *invalidLiteral) describe() string {
+	if invalid.Reason != "" {
+		return invalid.Reason
+	}
+	description := fmt.Sprintf(`Expected type "%v", found %v`, invalid.Type, printer.Print(invalid.ValueAST))
+	if invalid.Cause != nil {
+		description += ": " + invalid.Cause.Error()
 	}
+	return description
+}
 
-	if valueAST == nil {
+// Parses a literal of a scalar or enum type, returns nil and the error the
+// type returned or panicked with, if any, when the literal is invalid.
+func parseLiteral(ttype types.GraphQLInputType, valueAST ast.Value, variables map[string]interface{}) (parsed interface{}, cause error) {
+	defer func() {
+		if r := recover(); r != nil {
+			parsed, cause = nil, parseError(r)
+		}
+	}()
+	switch ttype := ttype.(type) {
+	case *types.GraphQLScalarType:
+		parsed = ttype.ParseLiteralWithVariables(valueAST, variables)
+	case *types.GraphQLEnumType:
+		parsed = ttype.ParseLiteral(valueAST)
+	}
+	if err, ok := parsed.(error); ok {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// Parses a runtime value of a scalar or enum type, returns nil when the
+// value is invalid, including when the type returns or panics with an error.
+func parseValue(ttype types.GraphQLInputType, value interface{}) (parsed interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			parsed = nil
+		}
+	}()
+	switch ttype := ttype.(type) {
+	case *types.GraphQLScalarType:
+		parsed = ttype.ParseValue(value)
+	case *types.GraphQLEnumType:
+		parsed = ttype.ParseValue(value)
+	}
+	if _, ok := parsed.(error); ok {
 		return nil
 	}
+	return parsed
+}
+
+func parseError(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
+
+// Same as valueFromAST, but threads the path to the value being coerced so
+// that the literal which cannot be coerced can be reported, or all the
+// invalid fields of an input object: fields of the wrong type, unknown
+// fields and missing non-null fields.
+func valueFromASTAtPath(valueAST ast.Value, ttype types.GraphQLInputType, variables map[string]interface{}, path []string) (interface{}, *invalidLiteral) {
+
+	if nonNull, ok := ttype.(*types.GraphQLNonNull); ok {
+		value, invalid := valueFromASTAtPath(valueAST, nonNull.OfType, variables, path)
+		if invalid == nil && value == null {
+			return nil, &invalidLiteral{
+				Path:     path,
+				Type:     ttype,
+				ValueAST: valueAST,
+			}
+		}
+		return value, invalid
+	}
+
+	if valueAST == nil {
+		return undefined, nil
+	}
+
+	if _, ok := valueAST.(*ast.NullValue); ok {
+		return null, nil
+	}
 
 	if valueAST, ok := valueAST.(*ast.Variable); ok && valueAST.Kind == kinds.Variable {
 		if valueAST.Name == nil {
-			return nil
-		}
-		if variables == nil {
-			return nil
+			return undefined, nil
 		}
 		variableName := valueAST.Name.Value
 		variableVal, ok := variables[variableName]
 		if !ok {
-			return nil
+			return undefined, nil
+		}
+		if variableVal == nil {
+			return null, nil
 		}
 		// Note: we're not doing any checking that this variable is correct. We're
 		// assuming that this query has been validated and the variable usage here
 		// is of the correct type.
-		return variableVal
+		return variableVal, nil
+	}
+
+	invalid := &invalidLiteral{
+		Path:     path,
+		Type:     ttype,
+		ValueAST: valueAST,
 	}
 
 	if ttype, ok := ttype.(*types.GraphQLList); ok {
 		itemType := ttype.OfType
 		if valueAST, ok := valueAST.(*ast.ListValue); ok && valueAST.Kind == kinds.ListValue {
 			values := []interface{}{}
-			for _, itemAST := range valueAST.Values {
-				v := valueFromAST(itemAST, itemType, variables)
+			for i, itemAST := range valueAST.Values {
+				itemPath := append(append([]string{}, path...), strconv.Itoa(i))
+				v, invalid := valueFromASTAtPath(itemAST, itemType, variables, itemPath)
+				if invalid != nil {
+					return nil, invalid
+				}
+				// e.g. a missing variable within a list of non-null items, an
+				// empty string coerced to nil is not null
+				if _, ok := itemType.(*types.GraphQLNonNull); ok && (v == undefined || v == null) {
+					return nil, &invalidLiteral{
+						Path:     itemPath,
+						Type:     itemType,
+						ValueAST: itemAST,
+					}
+				}
+				// an undefined item is null, the list keeps its length
+				if v == undefined || v == null {
+					v = nil
+				}
 				values = append(values, v)
 			}
-			return values
+			return values, nil
+		}
+		v, invalid := valueFromASTAtPath(valueAST, itemType, variables, path)
+		if invalid != nil || v == undefined || v == null {
+			return v, invalid
 		}
-		v := valueFromAST(valueAST, itemType, variables)
-		return []interface{}{v}
+		return []interface{}{v}, nil
 	}
 
 	if ttype, ok := ttype.(*types.GraphQLInputObjectType); ok {
 		valueAST, ok := valueAST.(*ast.ObjectValue)
 		if !ok {
-			return nil
+			return nil, invalid
+		}
+		// All the invalid fields are collected so that they are reported at
+		// once.
+		problems := []*invalidLiteral{}
+		addProblem := func(problem *invalidLiteral) {
+			if len(problem.Problems) > 0 {
+				problems = append(problems, problem.Problems...)
+				return
+			}
+			problems = append(problems, problem)
 		}
-		fieldASTs := map[string]*ast.ObjectField{}
+		fields := ttype.GetFields()
+		obj := map[string]interface{}{}
+		provided := map[string]bool{}
 		for _, fieldAST := range valueAST.Fields {
-			if fieldAST.Name == nil {
+			if fieldAST == nil || fieldAST.Name == nil {
 				continue
 			}
 			fieldName := fieldAST.Name.Value
-			fieldASTs[fieldName] = fieldAST
-
-		}
-		obj := map[string]interface{}{}
-		for fieldName, field := range ttype.GetFields() {
-			fieldAST, ok := fieldASTs[fieldName]
-			if !ok || fieldAST == nil {
+			fieldPath := append(append([]string{}, path...), fieldName)
+			field, ok := fields[fieldName]
+			if !ok {
+				addProblem(&invalidLiteral{
+					Path:     fieldPath,
+					Type:     ttype,
+					ValueAST: fieldAST.Value,
+					Reason:   fmt.Sprintf(`Field "%v" is not defined by type "%v"`, fieldName, ttype),
+				})
 				continue
 			}
-			fieldValue := valueFromAST(fieldAST.Value, field.Type, variables)
-			if isNullish(fieldValue) {
+			provided[fieldName] = true
+			fieldValue, invalid := valueFromASTAtPath(fieldAST.Value, field.Type, variables, fieldPath)
+			if invalid != nil {
+				addProblem(invalid)
+				continue
+			}
+			if fieldValue == null {
+				obj[fieldName] = nil
+				continue
+			}
+			// e.g. a missing variable for a non-null field, an empty string
+			// coerced to nil is not missing
+			if _, ok := field.Type.(*types.GraphQLNonNull); ok && fieldValue == undefined && isNullish(field.DefaultValue) {
+				addProblem(&invalidLiteral{
+					Path:     fieldPath,
+					Type:     field.Type,
+					ValueAST: fieldAST.Value,
+				})
+				continue
+			}
+			if fieldValue == undefined || isNullish(fieldValue) {
 				fieldValue = field.DefaultValue
 			}
 			if !isNullish(fieldValue) {
 				obj[fieldName] = fieldValue
 			}
 		}
-		return obj
-	}
-
-	switch ttype := ttype.(type) {
-	case *types.GraphQLScalarType:
-		parsed := ttype.ParseLiteral(valueAST)
-		if !isNullish(parsed) {
-			return parsed
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
 		}
-	case *types.GraphQLEnumType:
-		parsed := ttype.ParseLiteral(valueAST)
-		if !isNullish(parsed) {
-			return parsed
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			field := fields[fieldName]
+			if provided[fieldName] {
+				continue
+			}
+			if !isNullish(field.DefaultValue) {
+				obj[fieldName] = field.DefaultValue
+				continue
+			}
+			if _, ok := field.Type.(*types.GraphQLNonNull); ok {
+				addProblem(&invalidLiteral{
+					Path:     append(append([]string{}, path...), fieldName),
+					Type:     field.Type,
+					ValueAST: valueAST,
+					Reason:   fmt.Sprintf(`Field "%v" of required type "%v" was not provided`, fieldName, field.Type),
+				})
+			}
+		}
+		switch len(problems) {
+		case 0:
+			return obj, nil
+		case 1:
+			return nil, problems[0]
 		}
+		invalid.Problems = problems
+		return nil, invalid
 	}
-	return nil
+
+	parsed, cause := parseLiteral(ttype, valueAST, variables)
+	if parsed == nil {
+		invalid.Cause = cause
+		return nil, invalid
+	}
+	if !isNullish(parsed) {
+		return parsed, nil
+	}
+	return nil, nil
 }
 
 func invariant(condition bool, message string) error {
@@ -2,6 +2,7 @@ package executor_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/chris-ramon/graphql-go/errors"
 	"github.com/chris-ramon/graphql-go/executor"
 	"github.com/chris-ramon/graphql-go/language/ast"
@@ -9,6 +10,7 @@ import (
 	"github.com/chris-ramon/graphql-go/testutil"
 	"github.com/chris-ramon/graphql-go/types"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -132,6 +134,15 @@ var testType *types.GraphQLObjectType = types.NewGraphQLObjectType(types.GraphQL
 			},
 			Resolve: inputResolved,
 		},
+		"nestedList": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+			Args: types.GraphQLFieldConfigArgumentMap{
+				"input": &types.GraphQLArgumentConfig{
+					Type: types.NewGraphQLList(types.NewGraphQLList(types.GraphQLString)),
+				},
+			},
+			Resolve: inputResolved,
+		},
 		"nnListNN": &types.GraphQLFieldConfig{
 			Type: types.GraphQLString,
 			Args: types.GraphQLFieldConfigArgumentMap{
@@ -212,6 +223,16 @@ func TestVariables_ObjectsAndNullability_UsingInlineStructs_DoesNotUseIncorrectV
 		Data: map[string]interface{}{
 			"fieldWithObjectInput": nil,
 		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Argument "input" has invalid value: Expected type "TestInputObject", found ["foo", "bar", "baz"].`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 3, Column: 39},
+				},
+				Path:         []interface{}{"fieldWithObjectInput"},
+				ArgumentPath: []string{"input"},
+			},
+		},
 	}
 	// parse query
 	ast := testutil.Parse(t, doc)
@@ -222,9 +243,6 @@ func TestVariables_ObjectsAndNullability_UsingInlineStructs_DoesNotUseIncorrectV
 		AST:    ast,
 	}
 	result := testutil.Execute(t, ep)
-	if len(result.Errors) > 0 {
-		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
-	}
 	if !reflect.DeepEqual(expected, result) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
@@ -602,7 +620,7 @@ func TestVariables_NullableScalars_AllowsNullableInputsToBeSetToNullInAVariable(
 	}
 	expected := &types.GraphQLResult{
 		Data: map[string]interface{}{
-			"fieldWithNullableStringInput": nil,
+			"fieldWithNullableStringInput": "null",
 		},
 	}
 
@@ -869,7 +887,7 @@ func TestVariables_ListsAndNullability_AllowsListsToBeNull(t *testing.T) {
 
 	expected := &types.GraphQLResult{
 		Data: map[string]interface{}{
-			"list": nil,
+			"list": "null",
 		},
 	}
 	ast := testutil.Parse(t, doc)
@@ -1055,7 +1073,7 @@ func TestVariables_ListsAndNullability_AllowsListsOfNonNullsToBeNull(t *testing.
 	}
 	expected := &types.GraphQLResult{
 		Data: map[string]interface{}{
-			"listNN": nil,
+			"listNN": "null",
 		},
 	}
 	ast := testutil.Parse(t, doc)
@@ -1118,7 +1136,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowListOfNonNullsToContainNull(t
 		Errors: []graphqlerrors.GraphQLFormattedError{
 			graphqlerrors.GraphQLFormattedError{
 				Message: `Variable "$input" expected value of type "[String!]" but got: ` +
-					`["A",null,"B"].`,
+					`["A",null,"B"]. Item at "1" of type "String!" must not be null.`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{
 						Line: 2, Column: 17,
@@ -1225,7 +1243,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToContai
 		Errors: []graphqlerrors.GraphQLFormattedError{
 			graphqlerrors.GraphQLFormattedError{
 				Message: `Variable "$input" expected value of type "[String!]!" but got: ` +
-					`["A",null,"B"].`,
+					`["A",null,"B"]. Item at "1" of type "String!" must not be null.`,
 				Locations: []location.SourceLocation{
 					location.SourceLocation{
 						Line: 2, Column: 17,
@@ -1250,6 +1268,98 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToContai
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+func TestVariables_ListsAndNullability_CoercesSingleValuesToLists(t *testing.T) {
+	doc := `
+        query q($input: [String], $nested: [[String]]) {
+          fromLiteral: list(input: "A")
+          fromVariable: list(input: $input)
+          nestedFromLiteral: nestedList(input: "A")
+          nestedFromVariable: nestedList(input: $nested)
+        }
+	`
+	params := map[string]interface{}{
+		"input":  "B",
+		"nested": "C",
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"fromLiteral":        `["A"]`,
+			"fromVariable":       `["B"]`,
+			"nestedFromLiteral":  `[["A"]]`,
+			"nestedFromVariable": `[["C"]]`,
+		},
+	}
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+		Args:   params,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_ListsAndNullability_DoesNotAllowListsToBeUsedAsScalars(t *testing.T) {
+	doc := `
+        query q($input: String) {
+          fieldWithNullableStringInput(input: $input)
+        }
+	`
+	params := map[string]interface{}{
+		"input": []interface{}{"A", "B"},
+	}
+	expected := &types.GraphQLResult{
+		Data: nil,
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Variable "$input" expected value of type "String" but got: ["A","B"].`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{
+						Line: 2, Column: 17,
+					},
+				},
+			},
+		},
+	}
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+		Args:   params,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_ListsAndNullability_DoesNotCoerceListLiteralsToScalars(t *testing.T) {
+	doc := `
+        {
+          fieldWithNullableStringInput(input: ["A", "B"])
+        }
+	`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"fieldWithNullableStringInput": nil,
+		},
+	}
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
 func TestVariables_ListsAndNullability_DoesNotAllowInvalidTypesToBeUsedAsValues(t *testing.T) {
 	doc := `
         query q($input: TestType!) {
@@ -1407,3 +1517,680 @@ func TestVariables_UsesArgumentDefaultValues_WhenArgumentProvidedCannotBeParsed(
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestVariables_ObjectsAndNullability_UsingInlineStructs_ReportsPathToInvalidNestedValue(t *testing.T) {
+	authorInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "AuthorInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"id": &types.InputObjectFieldConfig{
+				Type: types.GraphQLInt,
+			},
+		},
+	})
+	articleInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "ArticleInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"title": &types.InputObjectFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"author": &types.InputObjectFieldConfig{
+				Type: authorInput,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"input": &types.GraphQLArgumentConfig{
+							Type: articleInput,
+						},
+					},
+					Resolve: inputResolved,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	doc := `{
+        article(input: {title: "foo", author: {id: "abc"}})
+      }`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Argument "input" has invalid value at "input.author.id": Expected type "Int", found "abc".`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 2, Column: 52},
+				},
+				Path:         []interface{}{"article"},
+				ArgumentPath: []string{"input", "author", "id"},
+			},
+		},
+	}
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: schema,
+		AST:    ast,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_ObjectsAndNullability_UsingInlineStructs_ReportsAllInvalidFields(t *testing.T) {
+	authorInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "AuthorInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"id": &types.InputObjectFieldConfig{
+				Type: types.GraphQLInt,
+			},
+		},
+	})
+	articleInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "ArticleInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"title": &types.InputObjectFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+			},
+			"author": &types.InputObjectFieldConfig{
+				Type: authorInput,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"input": &types.GraphQLArgumentConfig{
+							Type: articleInput,
+						},
+					},
+					Resolve: inputResolved,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	doc := `{
+        article(input: {author: {id: "abc"}, draft: true})
+      }`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Argument "input" has 3 invalid values:
+At "input.author.id": Expected type "Int", found "abc".
+At "input.draft": Field "draft" is not defined by type "ArticleInput".
+At "input.title": Field "title" of required type "String!" was not provided.`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 2, Column: 38},
+					location.SourceLocation{Line: 2, Column: 53},
+					location.SourceLocation{Line: 2, Column: 24},
+				},
+				Path:         []interface{}{"article"},
+				ArgumentPath: []string{"input"},
+			},
+		},
+	}
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: schema,
+		AST:    ast,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+func TestVariables_ReportsAllInvalidVariablesAsRequestErrors(t *testing.T) {
+	doc := `
+        query q($value: String!, $input: TestInputObject) {
+          fieldWithNonNullableStringInput(input: $value)
+          fieldWithObjectInput(input: $input)
+        }
+	`
+	params := map[string]interface{}{
+		"input": "foo bar",
+	}
+	expected := &types.GraphQLResult{
+		Data: nil,
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Variable "$value" of required type "String!" was not provided.`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 2, Column: 17},
+				},
+			},
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Variable "$input" expected value of type "TestInputObject" but ` +
+					`got: "foo bar".`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 2, Column: 34},
+				},
+			},
+		},
+	}
+
+	ep := executor.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    testutil.Parse(t, doc),
+		Args:   params,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// a request error has no data
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	expectedJSON := `{"errors":[` +
+		`{"message":"Variable \"$value\" of required type \"String!\" was not provided.","locations":[{"line":2,"column":17}]},` +
+		`{"message":"Variable \"$input\" expected value of type \"TestInputObject\" but got: \"foo bar\".","locations":[{"line":2,"column":34}]}` +
+		`]}`
+	if string(b) != expectedJSON {
+		t.Fatalf("Unexpected JSON, expected: %v, got: %v", expectedJSON, string(b))
+	}
+}
+
+func TestVariables_ObjectsAndNullability_ExplicitNullOverridesNestedDefaultValues(t *testing.T) {
+	settingsInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "SettingsInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"published": &types.InputObjectFieldConfig{
+				Type:         types.GraphQLBoolean,
+				DefaultValue: true,
+			},
+		},
+	})
+	articleInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "ArticleInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"title": &types.InputObjectFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"settings": &types.InputObjectFieldConfig{
+				Type: settingsInput,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"input": &types.GraphQLArgumentConfig{
+							Type: articleInput,
+						},
+					},
+					Resolve: inputResolved,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	doc := `
+        query q($absent: ArticleInput, $explicitNull: ArticleInput) {
+          literal: article(input: {title: "a", settings: {}})
+          absent: article(input: $absent)
+          explicitNull: article(input: $explicitNull)
+        }
+	`
+	params := map[string]interface{}{
+		"absent": map[string]interface{}{
+			"title":    "b",
+			"settings": map[string]interface{}{},
+		},
+		"explicitNull": map[string]interface{}{
+			"title": "c",
+			"settings": map[string]interface{}{
+				"published": nil,
+			},
+		},
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"literal":      `{"settings":{"published":true},"title":"a"}`,
+			"absent":       `{"settings":{"published":true},"title":"b"}`,
+			"explicitNull": `{"settings":{"published":null},"title":"c"}`,
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, doc),
+		Args:   params,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_ObjectsAndNullability_CoercesVariablesWithinNestedInputObjectLiterals(t *testing.T) {
+	settingsInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "SettingsInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"published": &types.InputObjectFieldConfig{
+				Type:         types.GraphQLBoolean,
+				DefaultValue: true,
+			},
+			"tags": &types.InputObjectFieldConfig{
+				Type: types.NewGraphQLList(types.GraphQLString),
+			},
+		},
+	})
+	articleInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "ArticleInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"title": &types.InputObjectFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"settings": &types.InputObjectFieldConfig{
+				Type: settingsInput,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"input": &types.GraphQLArgumentConfig{
+							Type: articleInput,
+						},
+					},
+					Resolve: inputResolved,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	// $published and $tag are not provided: the default value of the field
+	// applies to $published, and $tag is a null item of the list.
+	doc := `
+        query q($title: String, $published: Boolean, $tag: String) {
+          article(input: {title: $title, settings: {published: $published, tags: ["go", $tag]}})
+        }
+	`
+	params := map[string]interface{}{
+		"title": "a",
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": `{"settings":{"published":true,"tags":["go",null]},"title":"a"}`,
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, doc),
+		Args:   params,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_ListsAndNullability_DoesNotAllowListLiteralOfNonNullsToContainNull(t *testing.T) {
+	doc := `
+        query q($b: String) {
+          listNN(input: ["A", $b, "C"])
+        }
+	`
+	params := map[string]interface{}{
+		"b": nil,
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"listNN": nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Argument "input" has invalid value at "input.1": Expected type "String!", found $b.`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{
+						Line: 3, Column: 31,
+					},
+				},
+				Path:         []interface{}{"listNN"},
+				ArgumentPath: []string{"input", "1"},
+			},
+		},
+	}
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+		Args:   params,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_NullLiterals_DistinguishesNullFromMissingArguments(t *testing.T) {
+	argResolved := func(p types.GQLFRParams) interface{} {
+		value, ok := p.Args["isPublished"]
+		if !ok {
+			return "absent"
+		}
+		return fmt.Sprintf("%v", value)
+	}
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"publish": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"isPublished": &types.GraphQLArgumentConfig{
+							Type:         types.GraphQLBoolean,
+							DefaultValue: true,
+						},
+					},
+					Resolve: argResolved,
+				},
+				"update": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"isPublished": &types.GraphQLArgumentConfig{
+							Type: types.GraphQLBoolean,
+						},
+					},
+					Resolve: argResolved,
+				},
+				"require": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"isPublished": &types.GraphQLArgumentConfig{
+							Type: types.NewGraphQLNonNull(types.GraphQLBoolean),
+						},
+					},
+					Resolve: argResolved,
+				},
+				"article": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"input": &types.GraphQLArgumentConfig{
+							Type: types.NewGraphQLInputObjectType(types.InputObjectConfig{
+								Name: "ArticleInput",
+								Fields: types.InputObjectConfigFieldMap{
+									"title": &types.InputObjectFieldConfig{
+										Type: types.GraphQLString,
+									},
+									"isPublished": &types.InputObjectFieldConfig{
+										Type:         types.GraphQLBoolean,
+										DefaultValue: true,
+									},
+								},
+							}),
+						},
+					},
+					Resolve: inputResolved,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	doc := `query q($null: Boolean, $absent: Boolean) {
+        defaulted: publish
+        value: publish(isPublished: false)
+        null: publish(isPublished: null)
+        nullVariable: publish(isPublished: $null)
+        absentVariable: publish(isPublished: $absent)
+        absent: update
+        nullUpdate: update(isPublished: null)
+        nullField: article(input: {title: "a", isPublished: null})
+        defaultedField: article(input: {title: "a"})
+        required: require(isPublished: null)
+      }`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"defaulted":      "true",
+			"value":          "false",
+			"null":           "<nil>",
+			"nullVariable":   "<nil>",
+			"absentVariable": "true",
+			"absent":         "absent",
+			"nullUpdate":     "<nil>",
+			"nullField":      `{"isPublished":null,"title":"a"}`,
+			"defaultedField": `{"isPublished":true,"title":"a"}`,
+			"required":       nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Argument "isPublished" has invalid value: Expected type "Boolean!", found null.`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 11, Column: 40},
+				},
+				Path:         []interface{}{"required"},
+				ArgumentPath: []string{"isPublished"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, doc),
+		Args: map[string]interface{}{
+			"null": nil,
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_CustomScalars_ReportsWhyALiteralIsInvalid(t *testing.T) {
+	parseEmail := func(value interface{}) interface{} {
+		email, ok := value.(string)
+		if !ok {
+			panic(fmt.Errorf("an email must be a string"))
+		}
+		if !strings.Contains(email, "@") {
+			return fmt.Errorf(`missing "@"`)
+		}
+		return email
+	}
+	emailType := types.NewGraphQLScalarType(types.GraphQLScalarTypeConfig{
+		Name: "Email",
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+		ParseValue: parseEmail,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if valueAST, ok := valueAST.(*ast.StringValue); ok {
+				return parseEmail(valueAST.Value)
+			}
+			panic(fmt.Errorf("an email must be a string"))
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"invite": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"email": &types.GraphQLArgumentConfig{
+							Type: emailType,
+						},
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return fmt.Sprintf("invited %v", p.Args["email"])
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	doc := `{
+        valid: invite(email: "jane@example.com")
+        malformed: invite(email: "jane.example.com")
+        number: invite(email: 42)
+      }`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"valid":     "invited jane@example.com",
+			"malformed": nil,
+			"number":    nil,
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Argument "email" has invalid value: Expected type "Email", found "jane.example.com": missing "@".`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 3, Column: 34},
+				},
+				Path:         []interface{}{"malformed"},
+				ArgumentPath: []string{"email"},
+			},
+			graphqlerrors.GraphQLFormattedError{
+				Message: `Argument "email" has invalid value: Expected type "Email", found 42: an email must be a string.`,
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 4, Column: 31},
+				},
+				Path:         []interface{}{"number"},
+				ArgumentPath: []string{"email"},
+			},
+		},
+	}
+	result := testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, doc),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// a variable the scalar panics upon is invalid too
+	result = testutil.Execute(t, executor.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.Parse(t, `query q($email: Email) { invite(email: $email) }`),
+		Args: map[string]interface{}{
+			"email": 42,
+		},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Variable "$email" expected value of type "Email" but got: 42.` {
+		t.Fatalf("Unexpected result, got %v", result)
+	}
+}
+
+func TestVariables_CoerceVariableValues_CoercesTheVariablesOfAnOperation(t *testing.T) {
+	doc := testutil.Parse(t, `
+        query q($value: String!, $name: String = "default", $input: TestInputObject, $list: [String]) {
+          fieldWithNonNullableStringInput(input: $value)
+        }
+	`)
+	operation := doc.Definitions[0].(*ast.OperationDefinition)
+
+	values, errs := executor.CoerceVariableValues(variablesTestSchema, operation, map[string]interface{}{
+		"value": "a",
+		"list":  "b",
+		"input": nil,
+	})
+	expected := map[string]interface{}{
+		"value": "a",
+		"name":  "default",
+		"list":  []interface{}{"b"},
+		"input": nil,
+	}
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	if !reflect.DeepEqual(expected, values) {
+		t.Fatalf("Unexpected values, Diff: %v", testutil.Diff(expected, values))
+	}
+
+	_, errs = executor.CoerceVariableValues(variablesTestSchema, operation, map[string]interface{}{})
+	message := `Variable "$value" of required type "String!" was not provided.`
+	if len(errs) != 1 || errs[0].Error() != message {
+		t.Fatalf("Expected error %q, got: %v", message, errs)
+	}
+}
+
+func TestVariables_ListsAndNullability_AllowsListLiteralOfNonNullsToContainEmptyStrings(t *testing.T) {
+	doc := `
+        {
+          listNN(input: ["A", ""])
+        }
+	`
+	// the empty string is nullish, coerced to nil, but not a null item
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"listNN": `["A",null]`,
+		},
+	}
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_ObjectsAndNullability_AllowsEmptyStringsForNonNullFields(t *testing.T) {
+	doc := `
+        {
+          fieldWithObjectInput(input: {a: "foo", c: ""})
+        }
+	`
+	// the empty string is nullish, left out of the input object as an empty
+	// string of a nullable field, but not reported as a missing field
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"fieldWithObjectInput": `{"a":"foo"}`,
+		},
+	}
+	ast := testutil.Parse(t, doc)
+
+	// execute
+	ep := executor.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.Execute(t, ep)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
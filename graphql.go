@@ -1,20 +1,33 @@
 package gql
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
 	"github.com/chris-ramon/graphql-go/errors"
 	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/ast"
 	"github.com/chris-ramon/graphql-go/language/parser"
+	"github.com/chris-ramon/graphql-go/language/printer"
 	"github.com/chris-ramon/graphql-go/language/source"
 	"github.com/chris-ramon/graphql-go/types"
 	"github.com/chris-ramon/graphql-go/validator"
 )
 
 type GraphqlParams struct {
-	Schema         types.GraphQLSchema
-	RequestString  string
+	Schema        types.GraphQLSchema
+	RequestString string
+	// A parsed request, executed instead of parsing RequestString, e.g. a
+	// persisted query looked up by its QueryHash.
+	Document       *ast.Document
 	RootObject     map[string]interface{}
 	VariableValues map[string]interface{}
 	OperationName  string
+	Context        context.Context
+	// The options of the execution, see executor.ExecuteOptions. Its
+	// ErrorFormatter formats the parse and validation errors too.
+	executor.ExecuteOptions
 }
 
 func Graphql(p GraphqlParams, resultChannel chan *types.GraphQLResult) {
@@ -22,31 +35,85 @@ func Graphql(p GraphqlParams, resultChannel chan *types.GraphQLResult) {
 		Body: p.RequestString,
 		Name: "GraphQL request",
 	})
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
-	if err != nil {
-		result := types.GraphQLResult{
-			Errors: graphqlerrors.FormatErrors(err),
+	AST := p.Document
+	if AST == nil {
+		var err error
+		AST, err = parser.Parse(parser.ParseParams{Source: source})
+		if err != nil {
+			result := types.GraphQLResult{
+				Errors: p.ErrorFormatter.FormatErrors(graphqlerrors.FormatErrors(err)),
+			}
+			resultChannel <- &result
+			return
 		}
-		resultChannel <- &result
-		return
 	}
 	validationResult := validator.ValidateDocument(p.Schema, AST)
 
 	if !validationResult.IsValid {
 		result := types.GraphQLResult{
-			Errors: validationResult.Errors,
+			Errors: p.ErrorFormatter.FormatErrors(validationResult.Errors),
 		}
 		resultChannel <- &result
 		return
 	} else {
 		ep := executor.ExecuteParams{
-			Schema:        p.Schema,
-			Root:          p.RootObject,
-			AST:           AST,
-			OperationName: p.OperationName,
-			Args:          p.VariableValues,
+			Schema:         p.Schema,
+			Root:           p.RootObject,
+			AST:            AST,
+			OperationName:  p.OperationName,
+			Args:           p.VariableValues,
+			Context:        p.Context,
+			ExecuteOptions: p.ExecuteOptions,
 		}
 		executor.Execute(ep, resultChannel)
 		return
 	}
 }
+
+// QueryHash returns the hex encoded SHA-256 of the printed document, so that
+// a server may map the hash to a stored document and skip parsing it. The
+// documents of requests differing only in whitespace, commas and comments
+// have the same hash.
+func QueryHash(doc *ast.Document) string {
+	printed, _ := printer.Print(doc).(string)
+	sum := sha256.Sum256([]byte(printed))
+	return hex.EncodeToString(sum[:])
+}
+
+// ExecuteOption sets an optional parameter of ExecuteString.
+type ExecuteOption func(p *GraphqlParams)
+
+// WithVariables sets the values of the variables of the operation.
+func WithVariables(variables map[string]interface{}) ExecuteOption {
+	return func(p *GraphqlParams) {
+		p.VariableValues = variables
+	}
+}
+
+// WithOperationName selects the operation to execute among those of the
+// query.
+func WithOperationName(operationName string) ExecuteOption {
+	return func(p *GraphqlParams) {
+		p.OperationName = operationName
+	}
+}
+
+// ExecuteString parses, validates and executes the query against the schema
+// and returns its result, the parse and validation errors are reported in
+// the errors of the result. It is the simplest way to execute a query, see
+// Graphql for the other parameters of a request.
+//
+//	result := gql.ExecuteString(schema, `query Hero($episode: Episode) { hero(episode: $episode) { name } }`,
+//		gql.WithVariables(map[string]interface{}{"episode": "JEDI"}))
+func ExecuteString(schema types.GraphQLSchema, query string, options ...ExecuteOption) *types.GraphQLResult {
+	p := GraphqlParams{
+		Schema:        schema,
+		RequestString: query,
+	}
+	for _, option := range options {
+		option(&p)
+	}
+	resultChannel := make(chan *types.GraphQLResult, 1)
+	Graphql(p, resultChannel)
+	return <-resultChannel
+}
@@ -2,8 +2,11 @@ package gql
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/executor"
 	"github.com/chris-ramon/graphql-go/types"
 
 	"./testutil"
@@ -134,3 +137,105 @@ func TestBasicGraphQLExample(t *testing.T) {
 	}
 
 }
+
+func TestQueryHash_IsInsensitiveToFormattingButNotToStructure(t *testing.T) {
+	hash := QueryHash(testutil.Parse(t, `query HeroNameQuery { hero { id, name } }`))
+	equivalent := []string{
+		`query HeroNameQuery{hero{id name}}`,
+		`
+		# the hero of the saga
+		query HeroNameQuery {
+			hero {
+				id
+				name
+			}
+		}
+		`,
+	}
+	for _, query := range equivalent {
+		if QueryHash(testutil.Parse(t, query)) != hash {
+			t.Fatalf("Expected the hash of %q to be %v", query, hash)
+		}
+	}
+	different := []string{
+		`query HeroNameQuery { hero { name id } }`,
+		`query HeroNameQuery { hero { id name friends { name } } }`,
+		`query HeroQuery { hero { id, name } }`,
+	}
+	for _, query := range different {
+		if QueryHash(testutil.Parse(t, query)) == hash {
+			t.Fatalf("Expected the hash of %q to differ from %v", query, hash)
+		}
+	}
+	if len(hash) != 64 {
+		t.Fatalf("Expected a hex encoded SHA-256, got: %v", hash)
+	}
+}
+
+func TestQuery_ExecutesAParsedDocument(t *testing.T) {
+	test := Tests[0]
+	graphqlParams := GraphqlParams{
+		Schema:   test.Schema,
+		Document: testutil.Parse(t, test.Query),
+	}
+	testGraphql(test, graphqlParams, t)
+}
+
+func TestExecuteString_ExecutesTheSelectedOperationWithItsVariables(t *testing.T) {
+	query := `
+		query HeroNameQuery { hero { name } }
+		query HeroIDQuery($episode: Episode) { hero(episode: $episode) { id } }
+	`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"id": "1000",
+			},
+		},
+	}
+	result := ExecuteString(testutil.StarWarsSchema, query,
+		WithVariables(map[string]interface{}{"episode": "EMPIRE"}),
+		WithOperationName("HeroIDQuery"))
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExecuteString_ReportsParseAndValidationErrors(t *testing.T) {
+	queries := []string{
+		`{ hero { name }`,
+		`{ hero { name } } { hero { id } }`,
+	}
+	for _, query := range queries {
+		result := ExecuteString(testutil.StarWarsSchema, query)
+		if len(result.Errors) == 0 || result.Data != nil {
+			t.Fatalf("Expected only errors for %q, got: %v", query, result)
+		}
+	}
+}
+
+func TestGraphql_FormatsTheParseAndValidationErrors(t *testing.T) {
+	masked := func(err graphqlerrors.GraphQLFormattedError) graphqlerrors.GraphQLFormattedError {
+		err.Message = "masked: " + err.Message
+		return err
+	}
+	for _, query := range []string{`{ hero { name }`, `{ hero { name } } { hero { id } }`} {
+		resultChannel := make(chan *types.GraphQLResult, 1)
+		Graphql(GraphqlParams{
+			Schema:        testutil.StarWarsSchema,
+			RequestString: query,
+			ExecuteOptions: executor.ExecuteOptions{
+				ErrorFormatter: masked,
+			},
+		}, resultChannel)
+		result := <-resultChannel
+		if len(result.Errors) == 0 {
+			t.Fatalf("Expected errors for %q", query)
+		}
+		for _, err := range result.Errors {
+			if !strings.HasPrefix(err.Message, "masked: ") {
+				t.Fatalf("Expected the error of %q to be formatted, got: %v", query, err.Message)
+			}
+		}
+	}
+}
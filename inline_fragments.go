@@ -0,0 +1,197 @@
+package gql
+
+import (
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/visitor"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// InlineFragments returns a copy of a document in which the fragment
+// spreads are replaced by the selections of their fragments, and the
+// fragment definitions are removed. The selections of a fragment are
+// inlined directly into the selection set of the spread when the type
+// condition of the fragment is the type of the selection set, and wrapped
+// in an inline fragment on the type condition otherwise, or when the spread
+// or the fragment has directives, e.g. @include, which are moved onto the
+// inline fragment, those of the spread first. Spreads of unknown fragments,
+// and of fragments spreading themselves, are removed. The document itself
+// is left unchanged.
+func InlineFragments(doc *ast.Document, schema types.GraphQLSchema) *ast.Document {
+	inliner := &fragmentsInliner{
+		schema:    &schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		spreading: map[string]bool{},
+		inlined:   map[*ast.InlineFragment]string{},
+		spliced:   map[*ast.InlineFragment]bool{},
+	}
+	definitions := []ast.Node{}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			if fragment.Name != nil {
+				inliner.fragments[fragment.Name.Value] = fragment
+			}
+			continue
+		}
+		definitions = append(definitions, definition)
+	}
+	for i, definition := range definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			definitions[i] = visitor.VisitNode(operation, inliner)
+		}
+	}
+	return ast.NewDocument(&ast.Document{
+		Loc:         doc.Loc,
+		Definitions: definitions,
+	})
+}
+
+// Replaces the fragment spreads of the visited operation. The nodes leading
+// to a spread are copied upon entering them, so that the replacements, which
+// the visitor applies in place, leave the original nodes unchanged.
+type fragmentsInliner struct {
+	schema    *types.GraphQLSchema
+	fragments map[string]*ast.FragmentDefinition
+	// the types of the selection sets being visited, nil if unknown
+	parentTypes []types.GraphQLType
+	// the names of the fragments being inlined, guarding against cycles
+	spreading map[string]bool
+	// the inline fragments replacing spreads, by the name of their fragment
+	inlined map[*ast.InlineFragment]string
+	// the inline fragments whose selections are spliced into their parent
+	spliced map[*ast.InlineFragment]bool
+}
+
+func (v *fragmentsInliner) Enter(node ast.Node) (string, ast.Node) {
+	switch node := node.(type) {
+	case *ast.OperationDefinition:
+		v.pushType(v.rootType(node.Operation))
+		operation := *node
+		return visitor.ActionUpdate, &operation
+	case *ast.Field:
+		var fieldType types.GraphQLType
+		if node.Name != nil {
+			fieldType = v.fieldType(v.parentType(), node.Name.Value)
+		}
+		v.pushType(fieldType)
+		field := *node
+		return visitor.ActionUpdate, &field
+	case *ast.InlineFragment:
+		v.pushType(v.conditionType(node.TypeCondition))
+		fragment := *node
+		return visitor.ActionUpdate, &fragment
+	case *ast.SelectionSet:
+		selectionSet := *node
+		return visitor.ActionUpdate, &selectionSet
+	case *ast.FragmentSpread:
+		if node.Name == nil {
+			return visitor.ActionUpdate, nil
+		}
+		name := node.Name.Value
+		fragment, ok := v.fragments[name]
+		if !ok || v.spreading[name] {
+			return visitor.ActionUpdate, nil
+		}
+		inline := ast.NewInlineFragment(&ast.InlineFragment{
+			Loc:           node.Loc,
+			TypeCondition: fragment.TypeCondition,
+			Directives:    append(append([]*ast.Directive{}, node.Directives...), fragment.Directives...),
+			SelectionSet:  fragment.SelectionSet,
+		})
+		conditionType := v.conditionType(fragment.TypeCondition)
+		if len(inline.Directives) == 0 && conditionType != nil && conditionType == v.parentType() {
+			v.spliced[inline] = true
+		}
+		v.pushType(conditionType)
+		v.spreading[name] = true
+		v.inlined[inline] = name
+		return visitor.ActionUpdate, inline
+	}
+	return visitor.ActionNoChange, nil
+}
+
+func (v *fragmentsInliner) Leave(node ast.Node) (string, ast.Node) {
+	switch node := node.(type) {
+	case *ast.OperationDefinition, *ast.Field:
+		v.popType()
+	case *ast.InlineFragment:
+		v.popType()
+		if name, ok := v.inlined[node]; ok {
+			delete(v.spreading, name)
+			delete(v.inlined, node)
+		}
+	case *ast.SelectionSet:
+		selections := []ast.Selection{}
+		for _, selection := range node.Selections {
+			inline, ok := selection.(*ast.InlineFragment)
+			if ok && v.spliced[inline] {
+				delete(v.spliced, inline)
+				if inline.SelectionSet != nil {
+					selections = append(selections, inline.SelectionSet.Selections...)
+				}
+				continue
+			}
+			selections = append(selections, selection)
+		}
+		node.Selections = selections
+	}
+	return visitor.ActionNoChange, nil
+}
+
+func (v *fragmentsInliner) pushType(ttype types.GraphQLType) {
+	v.parentTypes = append(v.parentTypes, ttype)
+}
+
+func (v *fragmentsInliner) popType() {
+	v.parentTypes = v.parentTypes[:len(v.parentTypes)-1]
+}
+
+func (v *fragmentsInliner) parentType() types.GraphQLType {
+	if len(v.parentTypes) == 0 {
+		return nil
+	}
+	return v.parentTypes[len(v.parentTypes)-1]
+}
+
+func (v *fragmentsInliner) rootType(operation string) types.GraphQLType {
+	var rootType *types.GraphQLObjectType
+	switch operation {
+	case "mutation":
+		rootType = v.schema.GetMutationType()
+	case "subscription":
+		rootType = v.schema.GetSubscriptionType()
+	default:
+		rootType = v.schema.GetQueryType()
+	}
+	if rootType == nil {
+		return nil
+	}
+	return rootType
+}
+
+// Returns the type of a type condition, or the parent type if there is no
+// type condition.
+func (v *fragmentsInliner) conditionType(condition *ast.NamedType) types.GraphQLType {
+	if condition == nil {
+		return v.parentType()
+	}
+	if condition.Name == nil {
+		return nil
+	}
+	return v.schema.GetType(condition.Name.Value)
+}
+
+// Returns the named type of the field of the given type, nil if unknown.
+func (v *fragmentsInliner) fieldType(parentType types.GraphQLType, name string) types.GraphQLType {
+	var fields types.GraphQLFieldDefinitionMap
+	switch parentType := parentType.(type) {
+	case *types.GraphQLObjectType:
+		fields = parentType.GetFields()
+	case *types.GraphQLInterfaceType:
+		fields = parentType.GetFields()
+	}
+	field, ok := fields[name]
+	if !ok || field == nil {
+		return nil
+	}
+	return types.GetNamedType(field.Type)
+}
@@ -0,0 +1,136 @@
+package gql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/language/printer"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+var inlineFragmentsNodeInterface = types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+	Name: "Node",
+	Fields: types.GraphQLFieldConfigMap{
+		"id": &types.GraphQLFieldConfig{Type: types.GraphQLString},
+	},
+})
+
+var inlineFragmentsAuthorType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Author",
+	Fields: types.GraphQLFieldConfigMap{
+		"id":   &types.GraphQLFieldConfig{Type: types.GraphQLString},
+		"name": &types.GraphQLFieldConfig{Type: types.GraphQLString},
+	},
+	Interfaces: []*types.GraphQLInterfaceType{inlineFragmentsNodeInterface},
+	IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
+		return false
+	},
+})
+
+var inlineFragmentsArticleType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Article",
+	Fields: types.GraphQLFieldConfigMap{
+		"id":     &types.GraphQLFieldConfig{Type: types.GraphQLString},
+		"title":  &types.GraphQLFieldConfig{Type: types.GraphQLString},
+		"author": &types.GraphQLFieldConfig{Type: inlineFragmentsAuthorType},
+	},
+	Interfaces: []*types.GraphQLInterfaceType{inlineFragmentsNodeInterface},
+	IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
+		return true
+	},
+})
+
+var inlineFragmentsTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"article": &types.GraphQLFieldConfig{Type: inlineFragmentsArticleType},
+			"feed":    &types.GraphQLFieldConfig{Type: types.NewGraphQLList(inlineFragmentsArticleType)},
+			"node":    &types.GraphQLFieldConfig{Type: inlineFragmentsNodeInterface},
+		},
+	}),
+})
+
+func TestInlineFragments_InlinesTheFragmentAtEachSpread(t *testing.T) {
+	query := `
+      query Blog($withFeed: Boolean) {
+        article {
+          ...articleFields
+          author { name }
+        }
+        feed {
+          ...articleFields @include(if: $withFeed)
+        }
+        node {
+          id
+          ...articleFields
+        }
+      }
+
+      fragment articleFields on Article {
+        id
+        title
+        ...authorFields
+      }
+
+      fragment authorFields on Article {
+        author { id }
+      }
+	`
+	doc := testutil.Parse(t, query)
+	original := printer.Print(doc)
+	expected := "query Blog($withFeed: Boolean) {\n" +
+		"  article {\n" +
+		"    id\n" +
+		"    title\n" +
+		"    author {\n      id\n    }\n" +
+		"    author {\n      name\n    }\n" +
+		"  }\n" +
+		"  feed {\n" +
+		"    ... on Article @include(if: $withFeed) {\n" +
+		"      id\n" +
+		"      title\n" +
+		"      author {\n        id\n      }\n" +
+		"    }\n" +
+		"  }\n" +
+		"  node {\n" +
+		"    id\n" +
+		"    ... on Article {\n" +
+		"      id\n" +
+		"      title\n" +
+		"      author {\n        id\n      }\n" +
+		"    }\n" +
+		"  }\n" +
+		"}\n"
+	inlined := printer.Print(InlineFragments(doc, inlineFragmentsTestSchema))
+	if !reflect.DeepEqual(expected, inlined) {
+		t.Fatalf("Unexpected document, Diff: %v", testutil.Diff(expected, inlined))
+	}
+	if printed := printer.Print(doc); printed != original {
+		t.Fatalf("Expected the document to be left unchanged, Diff: %v", testutil.Diff(original, printed))
+	}
+}
+
+func TestInlineFragments_RemovesCyclicSpreads(t *testing.T) {
+	doc := testutil.Parse(t, `
+      {
+        article { ...A }
+      }
+
+      fragment A on Article {
+        id
+        ...B
+      }
+
+      fragment B on Article {
+        title
+        ...A
+      }
+	`)
+	expected := "{\n  article {\n    id\n    title\n  }\n}\n"
+	inlined := printer.Print(InlineFragments(doc, inlineFragmentsTestSchema))
+	if !reflect.DeepEqual(expected, inlined) {
+		t.Fatalf("Unexpected document, Diff: %v", testutil.Diff(expected, inlined))
+	}
+}
@@ -23,11 +23,12 @@ var _ TypeDefinition = (*TypeExtensionDefinition)(nil)
 
 // ObjectTypeDefinition implements Node, TypeDefinition
 type ObjectTypeDefinition struct {
-	Kind       string
-	Loc        *Location
-	Name       *Name
-	Interfaces []*NamedType
-	Fields     []*FieldDefinition
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Interfaces  []*NamedType
+	Fields      []*FieldDefinition
 }
 
 func NewObjectTypeDefinition(def *ObjectTypeDefinition) *ObjectTypeDefinition {
@@ -35,11 +36,12 @@ func NewObjectTypeDefinition(def *ObjectTypeDefinition) *ObjectTypeDefinition {
 		def = &ObjectTypeDefinition{}
 	}
 	return &ObjectTypeDefinition{
-		Kind:       kinds.ObjectTypeDefinition,
-		Loc:        def.Loc,
-		Name:       def.Name,
-		Interfaces: def.Interfaces,
-		Fields:     def.Fields,
+		Kind:        kinds.ObjectTypeDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Interfaces:  def.Interfaces,
+		Fields:      def.Fields,
 	}
 }
 
@@ -69,11 +71,13 @@ func (def *ObjectTypeDefinition) GetOperation() string {
 
 // FieldDefinition implements Node
 type FieldDefinition struct {
-	Kind      string
-	Loc       *Location
-	Name      *Name
-	Arguments []*InputValueDefinition
-	Type      Type
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Arguments   []*InputValueDefinition
+	Type        Type
+	Directives  []*Directive
 }
 
 func NewFieldDefinition(def *FieldDefinition) *FieldDefinition {
@@ -81,11 +85,13 @@ func NewFieldDefinition(def *FieldDefinition) *FieldDefinition {
 		def = &FieldDefinition{}
 	}
 	return &FieldDefinition{
-		Kind:      kinds.FieldDefinition,
-		Loc:       def.Loc,
-		Name:      def.Name,
-		Arguments: def.Arguments,
-		Type:      def.Type,
+		Kind:        kinds.FieldDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Arguments:   def.Arguments,
+		Type:        def.Type,
+		Directives:  def.Directives,
 	}
 }
 
@@ -101,6 +107,7 @@ func (def *FieldDefinition) GetLoc() *Location {
 type InputValueDefinition struct {
 	Kind         string
 	Loc          *Location
+	Description  *StringValue
 	Name         *Name
 	Type         Type
 	DefaultValue Value
@@ -113,6 +120,7 @@ func NewInputValueDefinition(def *InputValueDefinition) *InputValueDefinition {
 	return &InputValueDefinition{
 		Kind:         kinds.InputValueDefinition,
 		Loc:          def.Loc,
+		Description:  def.Description,
 		Name:         def.Name,
 		Type:         def.Type,
 		DefaultValue: def.DefaultValue,
@@ -129,10 +137,11 @@ func (def *InputValueDefinition) GetLoc() *Location {
 
 // InterfaceTypeDefinition implements Node, TypeDefinition
 type InterfaceTypeDefinition struct {
-	Kind   string
-	Loc    *Location
-	Name   *Name
-	Fields []*FieldDefinition
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Fields      []*FieldDefinition
 }
 
 func NewInterfaceTypeDefinition(def *InterfaceTypeDefinition) *InterfaceTypeDefinition {
@@ -140,10 +149,11 @@ func NewInterfaceTypeDefinition(def *InterfaceTypeDefinition) *InterfaceTypeDefi
 		def = &InterfaceTypeDefinition{}
 	}
 	return &InterfaceTypeDefinition{
-		Kind:   kinds.InterfaceTypeDefinition,
-		Loc:    def.Loc,
-		Name:   def.Name,
-		Fields: def.Fields,
+		Kind:        kinds.InterfaceTypeDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Fields:      def.Fields,
 	}
 }
 
@@ -173,10 +183,11 @@ func (def *InterfaceTypeDefinition) GetOperation() string {
 
 // UnionTypeDefinition implements Node, TypeDefinition
 type UnionTypeDefinition struct {
-	Kind  string
-	Loc   *Location
-	Name  *Name
-	Types []*NamedType
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Types       []*NamedType
 }
 
 func NewUnionTypeDefinition(def *UnionTypeDefinition) *UnionTypeDefinition {
@@ -184,10 +195,11 @@ func NewUnionTypeDefinition(def *UnionTypeDefinition) *UnionTypeDefinition {
 		def = &UnionTypeDefinition{}
 	}
 	return &UnionTypeDefinition{
-		Kind:  kinds.UnionTypeDefinition,
-		Loc:   def.Loc,
-		Name:  def.Name,
-		Types: def.Types,
+		Kind:        kinds.UnionTypeDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Types:       def.Types,
 	}
 }
 
@@ -217,9 +229,11 @@ func (def *UnionTypeDefinition) GetOperation() string {
 
 // ScalarTypeDefinition implements Node, TypeDefinition
 type ScalarTypeDefinition struct {
-	Kind string
-	Loc  *Location
-	Name *Name
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
 }
 
 func NewScalarTypeDefinition(def *ScalarTypeDefinition) *ScalarTypeDefinition {
@@ -227,9 +241,11 @@ func NewScalarTypeDefinition(def *ScalarTypeDefinition) *ScalarTypeDefinition {
 		def = &ScalarTypeDefinition{}
 	}
 	return &ScalarTypeDefinition{
-		Kind: kinds.ScalarTypeDefinition,
-		Loc:  def.Loc,
-		Name: def.Name,
+		Kind:        kinds.ScalarTypeDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Directives:  def.Directives,
 	}
 }
 
@@ -259,10 +275,11 @@ func (def *ScalarTypeDefinition) GetOperation() string {
 
 // EnumTypeDefinition implements Node, TypeDefinition
 type EnumTypeDefinition struct {
-	Kind   string
-	Loc    *Location
-	Name   *Name
-	Values []*EnumValueDefinition
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Values      []*EnumValueDefinition
 }
 
 func NewEnumTypeDefinition(def *EnumTypeDefinition) *EnumTypeDefinition {
@@ -270,10 +287,11 @@ func NewEnumTypeDefinition(def *EnumTypeDefinition) *EnumTypeDefinition {
 		def = &EnumTypeDefinition{}
 	}
 	return &EnumTypeDefinition{
-		Kind:   kinds.EnumTypeDefinition,
-		Loc:    def.Loc,
-		Name:   def.Name,
-		Values: def.Values,
+		Kind:        kinds.EnumTypeDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Values:      def.Values,
 	}
 }
 
@@ -303,9 +321,11 @@ func (def *EnumTypeDefinition) GetOperation() string {
 
 // EnumValueDefinition implements Node, TypeDefinition
 type EnumValueDefinition struct {
-	Kind string
-	Loc  *Location
-	Name *Name
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
 }
 
 func NewEnumValueDefinition(def *EnumValueDefinition) *EnumValueDefinition {
@@ -313,9 +333,11 @@ func NewEnumValueDefinition(def *EnumValueDefinition) *EnumValueDefinition {
 		def = &EnumValueDefinition{}
 	}
 	return &EnumValueDefinition{
-		Kind: kinds.EnumValueDefinition,
-		Loc:  def.Loc,
-		Name: def.Name,
+		Kind:        kinds.EnumValueDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Directives:  def.Directives,
 	}
 }
 
@@ -329,10 +351,11 @@ func (def *EnumValueDefinition) GetLoc() *Location {
 
 // InputObjectTypeDefinition implements Node, TypeDefinition
 type InputObjectTypeDefinition struct {
-	Kind   string
-	Loc    *Location
-	Name   *Name
-	Fields []*InputValueDefinition
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Fields      []*InputValueDefinition
 }
 
 func NewInputObjectTypeDefinition(def *InputObjectTypeDefinition) *InputObjectTypeDefinition {
@@ -340,10 +363,11 @@ func NewInputObjectTypeDefinition(def *InputObjectTypeDefinition) *InputObjectTy
 		def = &InputObjectTypeDefinition{}
 	}
 	return &InputObjectTypeDefinition{
-		Kind:   kinds.InputObjectTypeDefinition,
-		Loc:    def.Loc,
-		Name:   def.Name,
-		Fields: def.Fields,
+		Kind:        kinds.InputObjectTypeDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Fields:      def.Fields,
 	}
 }
 
@@ -22,6 +22,7 @@ const (
 	StringValue               = "StringValue"
 	BooleanValue              = "BooleanValue"
 	EnumValue                 = "EnumValue"
+	NullValue                 = "NullValue"
 	ListValue                 = "ListValue"
 	ObjectValue               = "ObjectValue"
 	ObjectField               = "ObjectField"
@@ -2,6 +2,8 @@ package lexer
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/chris-ramon/graphql-go/errors"
 	"github.com/chris-ramon/graphql-go/language/source"
@@ -27,6 +29,7 @@ const (
 	INT
 	FLOAT
 	STRING
+	BLOCK_STRING
 )
 
 var TokenKind map[int]int
@@ -54,6 +57,7 @@ func init() {
 	TokenKind[INT] = INT
 	TokenKind[FLOAT] = FLOAT
 	TokenKind[STRING] = STRING
+	TokenKind[BLOCK_STRING] = BLOCK_STRING
 	tokenDescription[TokenKind[EOF]] = "EOF"
 	tokenDescription[TokenKind[BANG]] = "!"
 	tokenDescription[TokenKind[DOLLAR]] = "$"
@@ -73,6 +77,7 @@ func init() {
 	tokenDescription[TokenKind[INT]] = "Int"
 	tokenDescription[TokenKind[FLOAT]] = "Float"
 	tokenDescription[TokenKind[STRING]] = "String"
+	tokenDescription[TokenKind[BLOCK_STRING]] = "BlockString"
 }
 
 type Token struct {
@@ -219,7 +224,7 @@ func readString(s *source.Source, start int) (Token, error) {
 	for {
 		code = charCodeAt(body, position)
 		if position < len(body) && code != 34 && code != 10 && code != 13 && code != 0x2028 && code != 0x2029 {
-			position += 1
+			position += charLengthAt(body, position)
 			if code == 92 { // \
 				value += body[chunkStart : position-1]
 				code = charCodeAt(body, position)
@@ -279,6 +284,75 @@ func readString(s *source.Source, start int) (Token, error) {
 	return makeToken(TokenKind[STRING], start, position+1, value), nil
 }
 
+// Reads a block string from the source, delimited by triple quotes. Within a
+// block string only \""" is escaped, and the common indentation of its lines
+// is removed by blockStringValue.
+func readBlockString(s *source.Source, start int) (Token, error) {
+	body := s.Body
+	position := start + 3
+	chunkStart := position
+	var rawValue string
+	for position < len(body) {
+		code := charCodeAt(body, position)
+		if code == 34 && charCodeAt(body, position+1) == 34 && charCodeAt(body, position+2) == 34 {
+			rawValue += body[chunkStart:position]
+			return makeToken(TokenKind[BLOCK_STRING], start, position+3, blockStringValue(rawValue)), nil
+		}
+		if code == 92 && // \
+			charCodeAt(body, position+1) == 34 &&
+			charCodeAt(body, position+2) == 34 &&
+			charCodeAt(body, position+3) == 34 {
+			rawValue += body[chunkStart:position] + `"""`
+			position += 4
+			chunkStart = position
+			continue
+		}
+		position += charLengthAt(body, position)
+	}
+	return Token{}, graphqlerrors.NewSyntaxError(s, position, "Unterminated string.")
+}
+
+// Implements the BlockStringValue algorithm of the spec: the common
+// indentation of the lines following the first one is removed, and so are
+// the leading and trailing blank lines.
+func blockStringValue(rawValue string) string {
+	lines := strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(rawValue), "\n")
+
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		indent := leadingWhitespace(line)
+		if indent < len(line) && (commonIndent < 0 || indent < commonIndent) {
+			commonIndent = indent
+		}
+	}
+	if commonIndent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) < commonIndent {
+				lines[i] = ""
+			} else {
+				lines[i] = lines[i][commonIndent:]
+			}
+		}
+	}
+
+	for len(lines) > 0 && leadingWhitespace(lines[0]) == len(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && leadingWhitespace(lines[len(lines)-1]) == len(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the number of spaces and tabs the line starts with.
+func leadingWhitespace(line string) int {
+	i := 0
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	return i
+}
+
 // Converts four hexidecimal chars to the integer that the
 // string represents. For example, uniCharCode('0','0','0','f')
 // will return 15, and uniCharCode('0','0','f','f') returns 255.
@@ -381,6 +455,9 @@ func readToken(s *source.Source, fromPosition int) (Token, error) {
 		}
 	// "
 	case 34:
+		if charCodeAt(body, position+1) == 34 && charCodeAt(body, position+2) == 34 {
+			return readBlockString(s, position)
+		}
 		token, err := readString(s, position)
 		if err != nil {
 			return token, err
@@ -391,13 +468,24 @@ func readToken(s *source.Source, fromPosition int) (Token, error) {
 	return Token{}, graphqlerrors.NewSyntaxError(s, position, description)
 }
 
+// Returns the character at the byte offset position of body, 0 past its end.
+// The positions of the tokens are byte offsets, see location.GetLocation.
 func charCodeAt(body string, position int) rune {
-	r := []rune(body)
-	if len(r) > position {
-		return r[position]
-	} else {
+	if position >= len(body) {
 		return 0
 	}
+	r, _ := utf8.DecodeRuneInString(body[position:])
+	return r
+}
+
+// Returns the length in bytes of the character at the byte offset position
+// of body, 1 past its end.
+func charLengthAt(body string, position int) int {
+	if position >= len(body) {
+		return 1
+	}
+	_, size := utf8.DecodeRuneInString(body[position:])
+	return size
 }
 
 // Reads from body starting at startPosition until it finds a non-whitespace
@@ -415,14 +503,14 @@ func positionAfterWhitespace(body string, startPosition int) int {
 				code == 0x2028 || // line separator
 				code == 0x2029 || // paragraph separator
 				code > 8 && code < 14 { // whitespace
-				position += 1
+				position += charLengthAt(body, position)
 			} else if code == 35 { // #
 				position += 1
 				for {
 					code := charCodeAt(body, position)
 					if position < bodyLength &&
 						code != 10 && code != 13 && code != 0x2028 && code != 0x2029 {
-						position += 1
+						position += charLengthAt(body, position)
 						continue
 					} else {
 						break
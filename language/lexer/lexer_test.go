@@ -262,6 +262,146 @@ func TestLexReportsUsefulStringErrors(t *testing.T) {
 	}
 }
 
+func TestLexesBlockStrings(t *testing.T) {
+	tests := []Test{
+		Test{
+			Body: `"""simple"""`,
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   12,
+				Value: "simple",
+			},
+		},
+		Test{
+			Body: `""" white space """`,
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   19,
+				Value: " white space ",
+			},
+		},
+		Test{
+			Body: `"""contains " quote"""`,
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   22,
+				Value: `contains " quote`,
+			},
+		},
+		Test{
+			Body: `"""contains \""" triplequote"""`,
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   31,
+				Value: `contains """ triplequote`,
+			},
+		},
+		Test{
+			Body: "\"\"\"multi\nline\"\"\"",
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   16,
+				Value: "multi\nline",
+			},
+		},
+		Test{
+			Body: "\"\"\"multi\rline\r\nnormalized\"\"\"",
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   28,
+				Value: "multi\nline\nnormalized",
+			},
+		},
+		Test{
+			Body: `"""unescaped \n\r\b\t\f\u1234"""`,
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   32,
+				Value: `unescaped \n\r\b\t\f\u1234`,
+			},
+		},
+		Test{
+			Body: `"""slashes \\ \/"""`,
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   19,
+				Value: `slashes \\ \/`,
+			},
+		},
+		Test{
+			Body: `"""
+
+        spans
+          multiple
+            lines
+
+        """`,
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   68,
+				Value: "spans\n  multiple\n    lines",
+			},
+		},
+		Test{
+			Body: "\"\"\"    first line keeps its indentation\n\t\tsecond\n\t\t\tthird\n  \"\"\"",
+			Expected: Token{
+				Kind:  TokenKind[BLOCK_STRING],
+				Start: 0,
+				End:   63,
+				Value: "    first line keeps its indentation\nsecond\n\tthird",
+			},
+		},
+	}
+	for _, test := range tests {
+		token, err := Lex(&source.Source{Body: test.Body})(0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(token, test.Expected) {
+			t.Fatalf("unexpected token, expected: %v, got: %v", test.Expected, token)
+		}
+	}
+}
+
+func TestLexReportsUsefulBlockStringErrors(t *testing.T) {
+	tests := []Test{
+		Test{
+			Body: `"""`,
+			Expected: `Syntax Error GraphQL (1:4) Unterminated string.
+
+1: """
+      ^
+`,
+		},
+		Test{
+			Body: `"""no end quote`,
+			Expected: `Syntax Error GraphQL (1:16) Unterminated string.
+
+1: """no end quote
+                  ^
+`,
+		},
+	}
+	for _, test := range tests {
+		_, err := Lex(createSource(test.Body))(0)
+		if err == nil {
+			t.Fatalf("unexpected nil error\nexpected:\n%v\n\ngot:\n%v", test.Expected, err)
+		}
+		if err.Error() != test.Expected {
+			t.Fatalf("unexpected error.\nexpected:\n%v\n\ngot:\n%v", test.Expected, err.Error())
+		}
+	}
+}
+
 func TestLexesNumbers(t *testing.T) {
 	tests := []Test{
 		Test{
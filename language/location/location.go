@@ -1,34 +1,44 @@
 package location
 
 import (
-	"regexp"
-
-	"github.com/chris-ramon/graphql-go/language/source"
+	"strings"
+	"unicode/utf8"
 )
 
 type SourceLocation struct {
-	Line   int
-	Column int
+	Line   int `json:"line"`
+	Column int `json:"column"`
 }
 
-func GetLocation(s *source.Source, position int) SourceLocation {
-	body := ""
-	if s != nil {
-		body = s.Body
-	}
+// GetLocation converts a byte offset within a source body into a 1-based
+// line and column. Lines are broken by "\n", "\r\n", "\r", U+2028 and
+// U+2029, and columns count the runes preceding the offset on its line, not
+// the bytes, so that a character encoded over several bytes in UTF-8 moves
+// the column by one. An offset past the end of the body counts a column per
+// byte past the end.
+func GetLocation(body string, position int) SourceLocation {
 	line := 1
-	column := position + 1
-	lineRegexp := regexp.MustCompile("\r\n|[\n\r\u2028\u2029]")
-	matches := lineRegexp.FindAllStringIndex(body, -1)
-	for _, match := range matches {
-		matchIndex := match[0]
-		if matchIndex < position {
-			line += 1
-			l := len(s.Body[match[0]:match[1]])
-			column = position + 1 - (matchIndex + l)
-			continue
+	lineStart := 0
+	for i := 0; i < len(body) && i < position; {
+		r, size := utf8.DecodeRuneInString(body[i:])
+		switch r {
+		case '\r':
+			if strings.HasPrefix(body[i+size:], "\n") {
+				size++
+			}
+			fallthrough
+		case '\n', '\u2028', '\u2029':
+			line++
+			lineStart = i + size
+		}
+		i += size
+	}
+	column := 1
+	if position > lineStart {
+		if position <= len(body) {
+			column += utf8.RuneCountInString(body[lineStart:position])
 		} else {
-			break
+			column += utf8.RuneCountInString(body[lineStart:]) + position - len(body)
 		}
 	}
 	return SourceLocation{Line: line, Column: column}
@@ -0,0 +1,40 @@
+package location_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/language/location"
+)
+
+func TestGetLocation_CountsTheColumnsInRunes(t *testing.T) {
+	body := "{\n  hero {\n    name(lang: \"日本\") ok\n  }\n}"
+	position := strings.Index(body, "ok")
+	// "日本" is 6 bytes long in UTF-8, but moves the column by 2, not 6
+	expected := location.SourceLocation{Line: 3, Column: 22}
+	if loc := location.GetLocation(body, position); !reflect.DeepEqual(expected, loc) {
+		t.Fatalf("Expected %v, got %v", expected, loc)
+	}
+}
+
+func TestGetLocation_BreaksLines(t *testing.T) {
+	tests := []struct {
+		body     string
+		position int
+		expected location.SourceLocation
+	}{
+		{"abc", 0, location.SourceLocation{Line: 1, Column: 1}},
+		{"abc", 2, location.SourceLocation{Line: 1, Column: 3}},
+		{"a\nb", 1, location.SourceLocation{Line: 1, Column: 2}},
+		{"a\nb", 2, location.SourceLocation{Line: 2, Column: 1}},
+		{"a\r\nb", 3, location.SourceLocation{Line: 2, Column: 1}},
+		{"a\rb\u2028c", 6, location.SourceLocation{Line: 3, Column: 1}},
+		{"a\nb", 4, location.SourceLocation{Line: 2, Column: 3}},
+	}
+	for _, test := range tests {
+		if loc := location.GetLocation(test.body, test.position); !reflect.DeepEqual(test.expected, loc) {
+			t.Errorf("Expected %v at %v of %q, got %v", test.expected, test.position, test.body, loc)
+		}
+	}
+}
@@ -107,14 +107,31 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
 		if skip(parser, lexer.TokenKind[lexer.EOF]) {
 			break
 		}
-		if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+		// Type definitions may be preceded by a description, the definition
+		// is determined by the keyword following it.
+		keyword := parser.Token
+		hasDescription := peekDescription(parser)
+		if hasDescription {
+			token, err := lookahead(parser)
+			if err != nil {
+				return nil, err
+			}
+			keyword = token
+		}
+		if keyword.Kind == lexer.TokenKind[lexer.BRACE_L] && !hasDescription {
 			node, err := parseOperationDefinition(parser)
 			if err != nil {
 				return nil, err
 			}
 			nodes = append(nodes, node)
-		} else if peek(parser, lexer.TokenKind[lexer.NAME]) {
-			switch parser.Token.Value {
+		} else if keyword.Kind == lexer.TokenKind[lexer.NAME] {
+			switch keyword.Value {
+			case "query", "mutation", "subscription", "fragment", "extend":
+				if hasDescription {
+					return nil, unexpected(parser, lexer.Token{})
+				}
+			}
+			switch keyword.Value {
 			case "query":
 				fallthrough
 			case "mutation":
@@ -520,7 +537,7 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 			Value: token.Value,
 			Loc:   loc(parser, token.Start),
 		}), nil
-	case lexer.TokenKind[lexer.STRING]:
+	case lexer.TokenKind[lexer.STRING], lexer.TokenKind[lexer.BLOCK_STRING]:
 		advance(parser)
 		return ast.NewStringValue(&ast.StringValue{
 			Value: token.Value,
@@ -537,13 +554,17 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 				Value: value,
 				Loc:   loc(parser, token.Start),
 			}), nil
-		} else if token.Value != "null" {
+		} else if token.Value == "null" {
 			advance(parser)
-			return ast.NewEnumValue(&ast.EnumValue{
-				Value: token.Value,
-				Loc:   loc(parser, token.Start),
+			return ast.NewNullValue(&ast.NullValue{
+				Loc: loc(parser, token.Start),
 			}), nil
 		}
+		advance(parser)
+		return ast.NewEnumValue(&ast.EnumValue{
+			Value: token.Value,
+			Loc:   loc(parser, token.Start),
+		}), nil
 	case lexer.TokenKind[lexer.DOLLAR]:
 		if !isConst {
 			return parseVariable(parser)
@@ -730,7 +751,11 @@ func parseNamedType(parser *Parser) (*ast.NamedType, error) {
 
 func parseObjectTypeDefinition(parser *Parser) (*ast.ObjectTypeDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "type")
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "type")
 	if err != nil {
 		return nil, err
 	}
@@ -753,10 +778,11 @@ func parseObjectTypeDefinition(parser *Parser) (*ast.ObjectTypeDefinition, error
 		}
 	}
 	return ast.NewObjectTypeDefinition(&ast.ObjectTypeDefinition{
-		Name:       name,
-		Loc:        loc(parser, start),
-		Interfaces: interfaces,
-		Fields:     fields,
+		Name:        name,
+		Description: description,
+		Loc:         loc(parser, start),
+		Interfaces:  interfaces,
+		Fields:      fields,
 	}), nil
 }
 
@@ -780,6 +806,10 @@ func parseImplementsInterfaces(parser *Parser) ([]*ast.NamedType, error) {
 
 func parseFieldDefinition(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
@@ -796,11 +826,17 @@ func parseFieldDefinition(parser *Parser) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewFieldDefinition(&ast.FieldDefinition{
-		Name:      name,
-		Arguments: args,
-		Type:      ttype,
-		Loc:       loc(parser, start),
+		Name:        name,
+		Description: description,
+		Arguments:   args,
+		Type:        ttype,
+		Directives:  directives,
+		Loc:         loc(parser, start),
 	}), nil
 }
 
@@ -824,6 +860,10 @@ func parseArgumentDefs(parser *Parser) ([]*ast.InputValueDefinition, error) {
 
 func parseInputValueDef(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
@@ -848,6 +888,7 @@ func parseInputValueDef(parser *Parser) (interface{}, error) {
 	}
 	return ast.NewInputValueDefinition(&ast.InputValueDefinition{
 		Name:         name,
+		Description:  description,
 		Type:         ttype,
 		DefaultValue: defaultValue,
 		Loc:          loc(parser, start),
@@ -856,7 +897,11 @@ func parseInputValueDef(parser *Parser) (interface{}, error) {
 
 func parseInterfaceTypeDefinition(parser *Parser) (*ast.InterfaceTypeDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "interface")
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "interface")
 	if err != nil {
 		return nil, err
 	}
@@ -875,15 +920,20 @@ func parseInterfaceTypeDefinition(parser *Parser) (*ast.InterfaceTypeDefinition,
 		}
 	}
 	return ast.NewInterfaceTypeDefinition(&ast.InterfaceTypeDefinition{
-		Name:   name,
-		Loc:    loc(parser, start),
-		Fields: fields,
+		Name:        name,
+		Description: description,
+		Loc:         loc(parser, start),
+		Fields:      fields,
 	}), nil
 }
 
 func parseUnionTypeDefinition(parser *Parser) (*ast.UnionTypeDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "union")
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "union")
 	if err != nil {
 		return nil, err
 	}
@@ -900,9 +950,10 @@ func parseUnionTypeDefinition(parser *Parser) (*ast.UnionTypeDefinition, error)
 		return nil, err
 	}
 	return ast.NewUnionTypeDefinition(&ast.UnionTypeDefinition{
-		Name:  name,
-		Loc:   loc(parser, start),
-		Types: types,
+		Name:        name,
+		Description: description,
+		Loc:         loc(parser, start),
+		Types:       types,
 	}), nil
 }
 
@@ -923,7 +974,11 @@ func parseUnionMembers(parser *Parser) ([]*ast.NamedType, error) {
 
 func parseScalarTypeDefinition(parser *Parser) (*ast.ScalarTypeDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "scalar")
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "scalar")
 	if err != nil {
 		return nil, err
 	}
@@ -931,16 +986,26 @@ func parseScalarTypeDefinition(parser *Parser) (*ast.ScalarTypeDefinition, error
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	def := ast.NewScalarTypeDefinition(&ast.ScalarTypeDefinition{
-		Name: name,
-		Loc:  loc(parser, start),
+		Name:        name,
+		Description: description,
+		Directives:  directives,
+		Loc:         loc(parser, start),
 	})
 	return def, nil
 }
 
 func parseEnumTypeDefinition(parser *Parser) (*ast.EnumTypeDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "enum")
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "enum")
 	if err != nil {
 		return nil, err
 	}
@@ -959,27 +1024,42 @@ func parseEnumTypeDefinition(parser *Parser) (*ast.EnumTypeDefinition, error) {
 		}
 	}
 	return ast.NewEnumTypeDefinition(&ast.EnumTypeDefinition{
-		Name:   name,
-		Loc:    loc(parser, start),
-		Values: values,
+		Name:        name,
+		Description: description,
+		Loc:         loc(parser, start),
+		Values:      values,
 	}), nil
 }
 
 func parseEnumValueDefinition(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
-		Name: name,
-		Loc:  loc(parser, start),
+		Name:        name,
+		Description: description,
+		Directives:  directives,
+		Loc:         loc(parser, start),
 	}), nil
 }
 
 func parseInputObjectTypeDefinition(parser *Parser) (*ast.InputObjectTypeDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "input")
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "input")
 	if err != nil {
 		return nil, err
 	}
@@ -998,9 +1078,10 @@ func parseInputObjectTypeDefinition(parser *Parser) (*ast.InputObjectTypeDefinit
 		}
 	}
 	return ast.NewInputObjectTypeDefinition(&ast.InputObjectTypeDefinition{
-		Name:   name,
-		Loc:    loc(parser, start),
-		Fields: fields,
+		Name:        name,
+		Description: description,
+		Loc:         loc(parser, start),
+		Fields:      fields,
 	}), nil
 }
 
@@ -1021,6 +1102,24 @@ func parseTypeExtensionDefinition(parser *Parser) (*ast.TypeExtensionDefinition,
 	}), nil
 }
 
+// Parses the optional description preceding a definition, a string or a
+// block string literal.
+func parseDescription(parser *Parser) (*ast.StringValue, error) {
+	if !peekDescription(parser) {
+		return nil, nil
+	}
+	value, err := parseValueLiteral(parser, true)
+	if err != nil {
+		return nil, err
+	}
+	description, _ := value.(*ast.StringValue)
+	return description, nil
+}
+
+func peekDescription(parser *Parser) bool {
+	return peek(parser, lexer.TokenKind[lexer.STRING]) || peek(parser, lexer.TokenKind[lexer.BLOCK_STRING])
+}
+
 /* Core parsing utility functions */
 
 // Returns a location object, used to identify the place in
@@ -1054,6 +1153,11 @@ func advance(parser *Parser) error {
 	return nil
 }
 
+// Returns the token following the current one without advancing the parser.
+func lookahead(parser *Parser) (lexer.Token, error) {
+	return parser.LexToken(parser.Token.End)
+}
+
 // Determines if the next token is of a given kind
 func peek(parser *Parser, Kind int) bool {
 	return parser.Token.Kind == Kind
@@ -132,6 +132,44 @@ func TestParseProvidesUsefulErrorsWhenUsingSource(t *testing.T) {
 	testGraphQLErrorMessage(t, test)
 }
 
+func TestParseLocatesErrorsFollowingMultiByteCharacters(t *testing.T) {
+	testErrorMessagesTable := []errorMessageTest{
+		{
+			`{ a(s: "aaaaa") ? }`,
+			`Syntax Error GraphQL (1:15) Expected Name, found )`,
+			false,
+		},
+		{
+			`{ a(s: "ééééé") ? }`,
+			`Syntax Error GraphQL (1:15) Expected Name, found )`,
+			false,
+		},
+		{
+			`{ a(s: "ééééé") 1 }`,
+			`Syntax Error GraphQL (1:17) Expected Name, found Int "1"`,
+			false,
+		},
+		{
+			"# 日本\n{ a(s: \"\"\"日本\"\"\") 1 }",
+			`Syntax Error GraphQL (2:18) Expected Name, found Int "1"`,
+			false,
+		},
+	}
+	for _, test := range testErrorMessagesTable {
+		_, err := Parse(ParseParams{Source: test.source})
+		checkGraphQLErrorMessage(t, err, test.expectedMessage)
+	}
+
+	document, err := Parse(ParseParams{Source: `{ a(s: "ééééé") }`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	field := document.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	if value := field.Arguments[0].Value.(*ast.StringValue).Value; value != "ééééé" {
+		t.Fatalf("unexpected value, expected: %q, got: %q", "ééééé", value)
+	}
+}
+
 func TestParsesVariableInlineValues(t *testing.T) {
 	source := `{ field(complex: { a: { b: [ $var ] } }) }`
 	// should not return error
@@ -141,6 +179,26 @@ func TestParsesVariableInlineValues(t *testing.T) {
 	}
 }
 
+func TestParsesBlockStringsAsStringValues(t *testing.T) {
+	source := `{ field(arg: """
+      block \""" string
+        indented
+    """) }`
+	document, err := Parse(ParseParams{Source: source})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	field := document.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	value, ok := field.Arguments[0].Value.(*ast.StringValue)
+	if !ok {
+		t.Fatalf("expected a StringValue, got: %T", field.Arguments[0].Value)
+	}
+	expected := "block \"\"\" string\n  indented"
+	if value.Value != expected {
+		t.Fatalf("unexpected value, expected: %q, got: %q", expected, value.Value)
+	}
+}
+
 func TestParsesConstantDefaultValues(t *testing.T) {
 	test := errorMessageTest{
 		`query Foo($x: Complex = { a: { b: [ $var ] } }) { field }`,
@@ -177,13 +235,22 @@ func TestDoesNotAcceptFragmentsSpreadOfOn(t *testing.T) {
 	testGraphQLErrorMessage(t, test)
 }
 
-func TestDoesNotAllowNullAsValue(t *testing.T) {
-	test := errorMessageTest{
-		`{ fieldWithNullableStringInput(input: null) }'`,
-		`Syntax Error GraphQL (1:39) Unexpected Name "null"`,
-		false,
+func TestParsesNullAsValue(t *testing.T) {
+	document, err := Parse(ParseParams{Source: `{ fieldWithNullableStringInput(input: null) }`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	field := document.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	expected := &ast.NullValue{
+		Kind: "NullValue",
+		Loc: &ast.Location{
+			Start: 38, End: 42,
+			Source: field.Loc.Source,
+		},
+	}
+	if !reflect.DeepEqual(field.Arguments[0].Value, expected) {
+		t.Fatalf("unexpected value, expected: %v, got: %v", expected, field.Arguments[0].Value)
 	}
-	testGraphQLErrorMessage(t, test)
 }
 
 func TestParsesKitchenSink(t *testing.T) {
@@ -48,7 +48,8 @@ type Hello {
 				Interfaces: []*ast.NamedType{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: loc(16, 29),
+						Directives: []*ast.Directive{},
+						Loc:        loc(16, 29),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   loc(16, 21),
@@ -92,7 +93,8 @@ extend type Hello {
 					Interfaces: []*ast.NamedType{},
 					Fields: []*ast.FieldDefinition{
 						ast.NewFieldDefinition(&ast.FieldDefinition{
-							Loc: loc(23, 36),
+							Directives: []*ast.Directive{},
+							Loc:        loc(23, 36),
 							Name: ast.NewName(&ast.Name{
 								Value: "world",
 								Loc:   loc(23, 28),
@@ -135,7 +137,8 @@ type Hello {
 				Interfaces: []*ast.NamedType{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: loc(16, 30),
+						Directives: []*ast.Directive{},
+						Loc:        loc(16, 30),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   loc(16, 21),
@@ -243,6 +246,7 @@ func TestSchemaParser_SingleValueEnum(t *testing.T) {
 				}),
 				Values: []*ast.EnumValueDefinition{
 					ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+						Directives: []*ast.Directive{},
 						Name: ast.NewName(&ast.Name{
 							Value: "WORLD",
 							Loc:   loc(13, 18),
@@ -272,6 +276,7 @@ func TestSchemaParser_DoubleValueEnum(t *testing.T) {
 				}),
 				Values: []*ast.EnumValueDefinition{
 					ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+						Directives: []*ast.Directive{},
 						Name: ast.NewName(&ast.Name{
 							Value: "WO",
 							Loc:   loc(13, 15),
@@ -279,6 +284,7 @@ func TestSchemaParser_DoubleValueEnum(t *testing.T) {
 						Loc: loc(13, 15),
 					}),
 					ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+						Directives: []*ast.Directive{},
 						Name: ast.NewName(&ast.Name{
 							Value: "RLD",
 							Loc:   loc(17, 20),
@@ -311,7 +317,8 @@ interface Hello {
 				}),
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: loc(21, 34),
+						Directives: []*ast.Directive{},
+						Loc:        loc(21, 34),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   loc(21, 26),
@@ -352,7 +359,8 @@ type Hello {
 				Interfaces: []*ast.NamedType{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: loc(16, 44),
+						Directives: []*ast.Directive{},
+						Loc:        loc(16, 44),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   loc(16, 21),
@@ -409,7 +417,8 @@ type Hello {
 				Interfaces: []*ast.NamedType{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: loc(16, 51),
+						Directives: []*ast.Directive{},
+						Loc:        loc(16, 51),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   loc(16, 21),
@@ -469,7 +478,8 @@ type Hello {
 				Interfaces: []*ast.NamedType{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: loc(16, 47),
+						Directives: []*ast.Directive{},
+						Loc:        loc(16, 47),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   loc(16, 21),
@@ -529,7 +539,8 @@ type Hello {
 				Interfaces: []*ast.NamedType{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: loc(16, 59),
+						Directives: []*ast.Directive{},
+						Loc:        loc(16, 59),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   loc(16, 21),
@@ -660,6 +671,7 @@ func TestSchemaParser_Scalar(t *testing.T) {
 					Value: "Hello",
 					Loc:   loc(7, 12),
 				}),
+				Directives: []*ast.Directive{},
 			}),
 		},
 	})
@@ -757,3 +769,61 @@ input Hello {
 		t.Fatalf("unexpected document, expected: %v, got: %v", expectedError, err)
 	}
 }
+
+func TestSchemaParser_DescriptionsPrecedingDefinitions(t *testing.T) {
+	body := `
+"""
+A story of the feed.
+"""
+type Story {
+  "The title of the story."
+  title(
+    "Whether to shout it."
+    loud: Boolean
+  ): String
+}
+
+"The kinds of sites."
+enum Site {
+  "Mobile sites."
+  MOBILE
+}`
+	astDoc := parse(t, body)
+	story := astDoc.Definitions[0].(*ast.ObjectTypeDefinition)
+	title := story.Fields[0]
+	site := astDoc.Definitions[1].(*ast.EnumTypeDefinition)
+	descriptions := []*ast.StringValue{
+		story.Description,
+		title.Description,
+		title.Arguments[0].Description,
+		site.Description,
+		site.Values[0].Description,
+	}
+	expected := []string{
+		"A story of the feed.",
+		"The title of the story.",
+		"Whether to shout it.",
+		"The kinds of sites.",
+		"Mobile sites.",
+	}
+	for i, description := range descriptions {
+		if description == nil || description.Value != expected[i] {
+			t.Fatalf("unexpected description, expected: %v, got: %v", expected[i], description)
+		}
+	}
+	if story.Loc.Start != 1 {
+		t.Fatalf("expected the definition to start at its description, got: %v", story.Loc.Start)
+	}
+}
+
+func TestSchemaParser_RejectsDescriptionsOfOperations(t *testing.T) {
+	_, err := parser.Parse(parser.ParseParams{
+		Source: `"Hello" query Hello { world }`,
+	})
+	if err == nil {
+		t.Fatalf("expected error, got: nil")
+	}
+	if err, ok := err.(*graphqlerrors.GraphQLError); !ok || !reflect.DeepEqual(err.Locations, []location.SourceLocation{{Line: 1, Column: 1}}) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/chris-ramon/graphql-go/language/ast"
 	"github.com/chris-ramon/graphql-go/language/visitor"
@@ -102,6 +103,65 @@ func indent(maybeString interface{}) string {
 	return ""
 }
 
+// Prints the description of a definition on the lines preceding it.
+func description(node map[string]interface{}) string {
+	return wrap("", getMapValueString(node, "Description"), "\n")
+}
+
+// Prints a value as a block string, multi-line values and those a closing
+// quote would run into open and close on their own lines.
+func blockString(value string) string {
+	escaped := strings.Replace(value, `"""`, `\"""`, -1)
+	if strings.Contains(value, "\n") || strings.HasSuffix(value, `"`) || strings.HasSuffix(value, `\`) {
+		return `"""` + "\n" + escaped + "\n" + `"""`
+	}
+	return `"""` + escaped + `"""`
+}
+
+// Prints a string value as a string literal, escaping the quotes,
+// backslashes and control characters.
+func quotedString(value string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// Prints argument definitions on a single line, or one per line if any of
+// them is described.
+func argumentDefs(args []string) string {
+	for _, arg := range args {
+		if strings.Contains(arg, "\n") {
+			return "(" + indent("\n"+join(args, "\n")) + "\n)"
+		}
+	}
+	return wrap("(", join(args, ", "), ")")
+}
+
 var printDocASTReducer = map[string]visitor.VisitFunc{
 	"Name": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
@@ -251,7 +311,10 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 	"StringValue": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
 		case map[string]interface{}:
-			return visitor.ActionUpdate, `"` + getMapValueString(node, "Value") + `"`
+			if p.Key == "Description" {
+				return visitor.ActionUpdate, blockString(getMapValueString(node, "Value"))
+			}
+			return visitor.ActionUpdate, quotedString(getMapValueString(node, "Value"))
 		}
 		return visitor.ActionNoChange, nil
 	},
@@ -269,6 +332,13 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		}
 		return visitor.ActionNoChange, nil
 	},
+	"NullValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+		switch p.Node.(type) {
+		case map[string]interface{}:
+			return visitor.ActionUpdate, "null"
+		}
+		return visitor.ActionNoChange, nil
+	},
 	"ListValue": func(p visitor.VisitFuncParams) (string, interface{}) {
 		switch node := p.Node.(type) {
 		case map[string]interface{}:
@@ -331,7 +401,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			name := getMapValueString(node, "Name")
 			interfaces := toSliceString(getMapValue(node, "Interfaces"))
 			fields := getMapValue(node, "Fields")
-			str := "type " + name + " " + wrap("implements ", join(interfaces, ", "), " ") + block(fields)
+			str := description(node) + "type " + name + " " + wrap("implements ", join(interfaces, ", "), " ") + block(fields)
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -342,7 +412,8 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			name := getMapValueString(node, "Name")
 			ttype := getMapValueString(node, "Type")
 			args := toSliceString(getMapValue(node, "Arguments"))
-			str := name + wrap("(", join(args, ", "), ")") + ": " + ttype
+			directives := toSliceString(getMapValue(node, "Directives"))
+			str := description(node) + name + argumentDefs(args) + ": " + ttype + wrap(" ", join(directives, " "), "")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -353,7 +424,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			name := getMapValueString(node, "Name")
 			ttype := getMapValueString(node, "Type")
 			defaultValue := getMapValueString(node, "DefaultValue")
-			str := name + ": " + ttype + wrap(" = ", defaultValue, "")
+			str := description(node) + name + ": " + ttype + wrap(" = ", defaultValue, "")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -363,7 +434,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
 			fields := getMapValue(node, "Fields")
-			str := "interface " + name + " " + block(fields)
+			str := description(node) + "interface " + name + " " + block(fields)
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -373,7 +444,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
 			types := toSliceString(getMapValue(node, "Types"))
-			str := "union " + name + " = " + join(types, " | ")
+			str := description(node) + "union " + name + " = " + join(types, " | ")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -382,7 +453,8 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		switch node := p.Node.(type) {
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
-			str := "scalar " + name
+			directives := toSliceString(getMapValue(node, "Directives"))
+			str := description(node) + "scalar " + name + wrap(" ", join(directives, " "), "")
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -392,7 +464,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
 			values := getMapValue(node, "Values")
-			str := "enum " + name + " " + block(values)
+			str := description(node) + "enum " + name + " " + block(values)
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
@@ -401,7 +473,8 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		switch node := p.Node.(type) {
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
-			return visitor.ActionUpdate, name
+			directives := toSliceString(getMapValue(node, "Directives"))
+			return visitor.ActionUpdate, description(node) + name + wrap(" ", join(directives, " "), "")
 		}
 		return visitor.ActionNoChange, nil
 	},
@@ -410,7 +483,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
 			fields := getMapValue(node, "Fields")
-			return visitor.ActionUpdate, "input " + name + " " + block(fields)
+			return visitor.ActionUpdate, description(node) + "input " + name + " " + block(fields)
 		}
 		return visitor.ActionNoChange, nil
 	},
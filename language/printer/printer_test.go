@@ -104,3 +104,15 @@ fragment frag on Follower {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(results, expected))
 	}
 }
+
+func TestPrinter_PrintsNullValues(t *testing.T) {
+	astDoc := parse(t, `{ update(isPublished: null, input: {tags: [null, "a"]}) }`)
+	results := printer.Print(astDoc)
+	expected := `{
+  update(isPublished: null, input: {tags: [null, "a"]})
+}
+`
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
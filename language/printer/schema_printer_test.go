@@ -89,3 +89,65 @@ extend type Foo {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(results, expected))
 	}
 }
+
+func TestSchemaPrinter_PrintsScalarWithSpecifiedByDirective(t *testing.T) {
+	query := `scalar URL @specifiedBy(url: "https://tools.ietf.org/html/rfc3986")`
+	astDoc := parse(t, query)
+	results := printer.Print(astDoc)
+	expected := query + "\n"
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+
+	// printed document parses back to the same definition
+	if reparsed := printer.Print(parse(t, results.(string))); !reflect.DeepEqual(expected, reparsed) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, reparsed))
+	}
+}
+
+func TestSchemaPrinter_PrintsDescriptionsAsBlockStrings(t *testing.T) {
+	query := `"""
+A story of the feed.
+Stories are published daily.
+"""
+type Story {
+  """The title of the story."""
+  title(
+    """Whether to shout it."""
+    loud: Boolean
+    times: Int
+  ): String
+  body: String
+}
+
+"""The kinds of sites."""
+enum Site {
+  """Mobile sites."""
+  MOBILE
+  DESKTOP
+}
+`
+	astDoc := parse(t, query)
+	results := printer.Print(astDoc)
+	if !reflect.DeepEqual(results, query) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(query, results))
+	}
+}
+
+func TestSchemaPrinter_PrintsDeprecatedFieldsAndEnumValues(t *testing.T) {
+	query := `type Article {
+  title: String
+  headline: String @deprecated(reason: "Use title.")
+}
+
+enum Status {
+  DRAFT
+  PENDING @deprecated
+}
+`
+	astDoc := parse(t, query)
+	results := printer.Print(astDoc)
+	if !reflect.DeepEqual(query, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(query, results))
+	}
+}
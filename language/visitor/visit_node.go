@@ -0,0 +1,151 @@
+package visitor
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/chris-ramon/graphql-go/language/ast"
+)
+
+// Visitor is called upon entering and leaving each node traversed by
+// VisitNode. Returning ActionSkip upon entering a node skips its children,
+// ActionBreak stops the traversal, and ActionUpdate replaces the node by the
+// returned node, or removes it if nil.
+type Visitor interface {
+	Enter(node ast.Node) (string, ast.Node)
+	Leave(node ast.Node) (string, ast.Node)
+}
+
+// VisitNode traverses the AST depth-first, visiting the children of a node in
+// the order of QueryDocumentKeys. Unlike Visit, it traverses the AST nodes
+// themselves and applies the replacements in place, returning the root, or
+// its replacement.
+func VisitNode(root ast.Node, v Visitor) ast.Node {
+	if isNilASTNode(root) {
+		return root
+	}
+	root, _ = visitNode(root, v)
+	return root
+}
+
+// Visits the node and its children, returns the node or its replacement and
+// whether the traversal was stopped.
+func visitNode(node ast.Node, v Visitor) (ast.Node, bool) {
+	action, value := v.Enter(node)
+	switch action {
+	case ActionBreak:
+		return node, true
+	case ActionSkip:
+		return node, false
+	case ActionUpdate:
+		if isNilASTNode(value) {
+			return nil, false
+		}
+		node = value
+	}
+	if visitChildren(node, v) {
+		return node, true
+	}
+	action, value = v.Leave(node)
+	switch action {
+	case ActionBreak:
+		return node, true
+	case ActionUpdate:
+		if isNilASTNode(value) {
+			return nil, false
+		}
+		return value, false
+	}
+	return node, false
+}
+
+func visitChildren(node ast.Node, v Visitor) bool {
+	nodeVal := reflect.ValueOf(node)
+	if nodeVal.Kind() != reflect.Ptr || nodeVal.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	for _, key := range QueryDocumentKeys[node.GetKind()] {
+		field := nodeVal.Elem().FieldByName(key)
+		if !field.IsValid() {
+			continue
+		}
+		if field.Kind() == reflect.Slice {
+			if visitSlice(field, v) {
+				return true
+			}
+			continue
+		}
+		child, ok := asNode(field)
+		if !ok {
+			continue
+		}
+		edited, stop := visitNode(child, v)
+		if edited != child {
+			field.Set(nodeValue(edited, field.Type()))
+		}
+		if stop {
+			return true
+		}
+	}
+	return false
+}
+
+// Visits the nodes of a slice, the slice is only set if a node is replaced
+// or removed.
+func visitSlice(field reflect.Value, v Visitor) bool {
+	items := reflect.MakeSlice(field.Type(), 0, field.Len())
+	edited := false
+	stop := false
+	for i := 0; i < field.Len(); i++ {
+		item := field.Index(i)
+		child, ok := asNode(item)
+		if !ok || stop {
+			items = reflect.Append(items, item)
+			continue
+		}
+		var editedChild ast.Node
+		editedChild, stop = visitNode(child, v)
+		if editedChild == child {
+			items = reflect.Append(items, item)
+			continue
+		}
+		edited = true
+		if editedChild != nil {
+			items = reflect.Append(items, nodeValue(editedChild, item.Type()))
+		}
+	}
+	if edited {
+		field.Set(items)
+	}
+	return stop
+}
+
+func asNode(value reflect.Value) (ast.Node, bool) {
+	if !value.CanInterface() {
+		return nil, false
+	}
+	node, ok := value.Interface().(ast.Node)
+	if !ok || isNilASTNode(node) {
+		return nil, false
+	}
+	return node, true
+}
+
+func nodeValue(node ast.Node, ttype reflect.Type) reflect.Value {
+	if node == nil {
+		return reflect.Zero(ttype)
+	}
+	value := reflect.ValueOf(node)
+	if !value.Type().AssignableTo(ttype) {
+		panic(fmt.Sprintf("Cannot replace a node of type %v by a %v", ttype, value.Type()))
+	}
+	return value
+}
+
+func isNilASTNode(node ast.Node) bool {
+	if node == nil {
+		return true
+	}
+	value := reflect.ValueOf(node)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
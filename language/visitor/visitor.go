@@ -65,6 +65,7 @@ var QueryDocumentKeys KeyMap = KeyMap{
 	"StringValue":  []string{},
 	"BooleanValue": []string{},
 	"EnumValue":    []string{},
+	"NullValue":    []string{},
 	"ListValue":    []string{"Values"},
 	"ObjectValue":  []string{"Fields"},
 	"ObjectField": []string{
@@ -82,35 +83,51 @@ var QueryDocumentKeys KeyMap = KeyMap{
 	"NonNullType": []string{"Type"},
 
 	"ObjectTypeDefinition": []string{
+		"Description",
 		"Name",
 		"Interfaces",
 		"Fields",
 	},
 	"FieldDefinition": []string{
+		"Description",
 		"Name",
 		"Arguments",
 		"Type",
+		"Directives",
 	},
 	"InputValueDefinition": []string{
+		"Description",
 		"Name",
 		"Type",
 		"DefaultValue",
 	},
 	"InterfaceTypeDefinition": []string{
+		"Description",
 		"Name",
 		"Fields",
 	},
 	"UnionTypeDefinition": []string{
+		"Description",
 		"Name",
 		"Types",
 	},
-	"ScalarTypeDefinition": []string{"Name"},
+	"ScalarTypeDefinition": []string{
+		"Description",
+		"Name",
+		"Directives",
+	},
 	"EnumTypeDefinition": []string{
+		"Description",
 		"Name",
 		"Values",
 	},
-	"EnumValueDefinition": []string{"Name"},
+	"EnumValueDefinition": []string{
+		"Description",
+		"Name",
+		"Directives",
+	},
 	"InputObjectTypeDefinition": []string{
+		"Description",
 		"Name",
 		"Fields",
 	},
@@ -3,6 +3,7 @@ package visitor_test
 import (
 	"github.com/chris-ramon/graphql-go/language/ast"
 	"github.com/chris-ramon/graphql-go/language/parser"
+	"github.com/chris-ramon/graphql-go/language/printer"
 	"github.com/chris-ramon/graphql-go/language/visitor"
 	"github.com/chris-ramon/graphql-go/testutil"
 	"io/ioutil"
@@ -537,3 +538,129 @@ func TestVisitor_ProducesHelpfulErrorMessages(t *testing.T) {
 	}
 	_ = visitor.Visit(astDoc, nil, nil)
 }
+
+type nodeVisitor struct {
+	enter func(node ast.Node) (string, ast.Node)
+	leave func(node ast.Node) (string, ast.Node)
+}
+
+func (v *nodeVisitor) Enter(node ast.Node) (string, ast.Node) {
+	if v.enter == nil {
+		return visitor.ActionNoChange, nil
+	}
+	return v.enter(node)
+}
+func (v *nodeVisitor) Leave(node ast.Node) (string, ast.Node) {
+	if v.leave == nil {
+		return visitor.ActionNoChange, nil
+	}
+	return v.leave(node)
+}
+
+func visitedNode(node ast.Node) []interface{} {
+	switch node := node.(type) {
+	case *ast.Name:
+		return []interface{}{node.Kind, node.Value}
+	case *ast.IntValue:
+		return []interface{}{node.Kind, node.Value}
+	}
+	return []interface{}{node.GetKind()}
+}
+
+func TestVisitNode_AllowsSkippingASubTree(t *testing.T) {
+	visited := []interface{}{}
+	expectedVisited := []interface{}{
+		[]interface{}{"enter", "Document"},
+		[]interface{}{"enter", "OperationDefinition"},
+		[]interface{}{"enter", "SelectionSet"},
+		[]interface{}{"enter", "Field"},
+		[]interface{}{"enter", "Name", "a"},
+		[]interface{}{"leave", "Name", "a"},
+		[]interface{}{"leave", "Field"},
+		[]interface{}{"enter", "Field"},
+		[]interface{}{"enter", "Field"},
+		[]interface{}{"enter", "Name", "c"},
+		[]interface{}{"leave", "Name", "c"},
+		[]interface{}{"leave", "Field"},
+		[]interface{}{"leave", "SelectionSet"},
+		[]interface{}{"leave", "OperationDefinition"},
+		[]interface{}{"leave", "Document"},
+	}
+	astDoc := parse(t, `{ a, b { x }, c }`)
+	visitor.VisitNode(astDoc, &nodeVisitor{
+		enter: func(node ast.Node) (string, ast.Node) {
+			visited = append(visited, append([]interface{}{"enter"}, visitedNode(node)...))
+			if field, ok := node.(*ast.Field); ok && field.Name.Value == "b" {
+				return visitor.ActionSkip, nil
+			}
+			return visitor.ActionNoChange, nil
+		},
+		leave: func(node ast.Node) (string, ast.Node) {
+			visited = append(visited, append([]interface{}{"leave"}, visitedNode(node)...))
+			return visitor.ActionNoChange, nil
+		},
+	})
+	if !reflect.DeepEqual(visited, expectedVisited) {
+		t.Fatalf("Unexpected visited nodes, Diff: %v", testutil.Diff(expectedVisited, visited))
+	}
+}
+
+func TestVisitNode_AllowsEarlyExit(t *testing.T) {
+	visited := []interface{}{}
+	expectedVisited := []interface{}{
+		[]interface{}{"enter", "Document"},
+		[]interface{}{"enter", "OperationDefinition"},
+		[]interface{}{"enter", "SelectionSet"},
+		[]interface{}{"enter", "Field"},
+		[]interface{}{"enter", "Name", "a"},
+		[]interface{}{"leave", "Name", "a"},
+		[]interface{}{"leave", "Field"},
+		[]interface{}{"enter", "Field"},
+		[]interface{}{"enter", "Name", "b"},
+	}
+	astDoc := parse(t, `{ a, b { x }, c }`)
+	visitor.VisitNode(astDoc, &nodeVisitor{
+		enter: func(node ast.Node) (string, ast.Node) {
+			visited = append(visited, append([]interface{}{"enter"}, visitedNode(node)...))
+			if name, ok := node.(*ast.Name); ok && name.Value == "b" {
+				return visitor.ActionBreak, nil
+			}
+			return visitor.ActionNoChange, nil
+		},
+		leave: func(node ast.Node) (string, ast.Node) {
+			visited = append(visited, append([]interface{}{"leave"}, visitedNode(node)...))
+			return visitor.ActionNoChange, nil
+		},
+	})
+	if !reflect.DeepEqual(visited, expectedVisited) {
+		t.Fatalf("Unexpected visited nodes, Diff: %v", testutil.Diff(expectedVisited, visited))
+	}
+}
+
+func TestVisitNode_AllowsEditingTheASTDuringTraversal(t *testing.T) {
+	astDoc := parse(t, `{ a(limit: 10), b { x }, c }`)
+	edited := visitor.VisitNode(astDoc, &nodeVisitor{
+		enter: func(node ast.Node) (string, ast.Node) {
+			if field, ok := node.(*ast.Field); ok && field.Name.Value == "b" {
+				// removes the field
+				return visitor.ActionUpdate, nil
+			}
+			return visitor.ActionNoChange, nil
+		},
+		leave: func(node ast.Node) (string, ast.Node) {
+			switch node := node.(type) {
+			case *ast.Name:
+				if node.Value == "c" {
+					return visitor.ActionUpdate, ast.NewName(&ast.Name{Value: "renamed"})
+				}
+			case *ast.IntValue:
+				return visitor.ActionUpdate, ast.NewIntValue(&ast.IntValue{Value: "5"})
+			}
+			return visitor.ActionNoChange, nil
+		},
+	})
+	expected := "{\n  a(limit: 5)\n  renamed\n}\n"
+	if printed := printer.Print(edited.(*ast.Document)); !reflect.DeepEqual(printed, expected) {
+		t.Fatalf("Unexpected edited AST, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
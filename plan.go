@@ -0,0 +1,21 @@
+package gql
+
+import (
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Plan returns the tree of fields the operation of a document would resolve,
+// with their types and arguments, without resolving them, e.g. for tooling
+// estimating the cost of the operation. Fragments are expanded and the
+// fields skipped by a constant @skip or @include condition are left out,
+// see executor.ExecutePlan. The operation name may be "" when the document
+// has a single operation.
+func Plan(schema types.GraphQLSchema, doc *ast.Document, operationName string) (*executor.Plan, error) {
+	return executor.ExecutePlan(executor.ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		OperationName: operationName,
+	})
+}
@@ -0,0 +1,119 @@
+package gql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/executor"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestPlan_ListsTheFieldsOfTheBlogQuery(t *testing.T) {
+	schema, err := types.BuildSchema(`
+type Query {
+  article(id: ID): Article
+}
+
+type Article {
+  id: ID
+  title: String
+  body: String
+  author: Author
+}
+
+type Author {
+  name: String
+  pic(width: Int, height: Int): Image
+}
+
+type Image {
+  url: String
+}
+`)
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	query := `
+      query Blog($id: ID!, $withAuthor: Boolean!) {
+        article(id: $id) {
+          author @include(if: $withAuthor) {
+            name
+            pic(width: 640, height: 480) {
+              url
+            }
+          }
+          body @skip(if: true)
+          ...articleFields
+        }
+      }
+
+      fragment articleFields on Article {
+        id
+        title
+      }
+	`
+	expected := &executor.Plan{
+		Operation: "query",
+		RootType:  "Query",
+		Fields: []*executor.PlanField{
+			&executor.PlanField{
+				ResponseName: "article",
+				FieldName:    "article",
+				ParentType:   "Query",
+				Type:         "Article",
+				Arguments:    map[string]string{"id": "$id"},
+				Fields: []*executor.PlanField{
+					&executor.PlanField{
+						ResponseName: "author",
+						FieldName:    "author",
+						ParentType:   "Article",
+						Type:         "Author",
+						Fields: []*executor.PlanField{
+							&executor.PlanField{
+								ResponseName: "name",
+								FieldName:    "name",
+								ParentType:   "Author",
+								Type:         "String",
+							},
+							&executor.PlanField{
+								ResponseName: "pic",
+								FieldName:    "pic",
+								ParentType:   "Author",
+								Type:         "Image",
+								Arguments:    map[string]string{"width": "640", "height": "480"},
+								Fields: []*executor.PlanField{
+									&executor.PlanField{
+										ResponseName: "url",
+										FieldName:    "url",
+										ParentType:   "Image",
+										Type:         "String",
+									},
+								},
+							},
+						},
+					},
+					&executor.PlanField{
+						ResponseName: "id",
+						FieldName:    "id",
+						ParentType:   "Article",
+						Type:         "ID",
+					},
+					&executor.PlanField{
+						ResponseName: "title",
+						FieldName:    "title",
+						ParentType:   "Article",
+						Type:         "String",
+					},
+				},
+			},
+		},
+	}
+	plan, err := Plan(schema, testutil.Parse(t, query), "Blog")
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if !reflect.DeepEqual(expected, plan) {
+		t.Fatalf("Unexpected plan, Diff: %v", testutil.Diff(expected, plan))
+	}
+}
@@ -0,0 +1,104 @@
+package gql
+
+import (
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/visitor"
+)
+
+// SeparateOperations splits a document into a document per operation, keyed
+// by the operation's name, or by "" for an anonymous operation. Each document
+// contains the operation and the fragments it references, directly or from
+// other fragments, in the order of the original document. The documents
+// share the nodes of the original document.
+func SeparateOperations(doc *ast.Document) map[string]*ast.Document {
+	operations := []*ast.OperationDefinition{}
+	// the fragments spread by each operation and fragment, by name
+	dependencies := map[string][]string{}
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			operations = append(operations, definition)
+			dependencies[operationKey(definition)] = collectSpreadNames(definition)
+		case *ast.FragmentDefinition:
+			if definition.Name != nil {
+				dependencies[fragmentKey(definition.Name.Value)] = collectSpreadNames(definition)
+			}
+		}
+	}
+
+	separated := map[string]*ast.Document{}
+	for _, operation := range operations {
+		key := operationKey(operation)
+		fragmentNames := map[string]bool{}
+		collectTransitiveDependencies(fragmentNames, dependencies, key)
+		definitions := []ast.Node{}
+		for _, definition := range doc.Definitions {
+			switch definition := definition.(type) {
+			case *ast.OperationDefinition:
+				if definition == operation {
+					definitions = append(definitions, definition)
+				}
+			case *ast.FragmentDefinition:
+				if definition.Name != nil && fragmentNames[definition.Name.Value] {
+					definitions = append(definitions, definition)
+				}
+			}
+		}
+		separated[operationName(operation)] = ast.NewDocument(&ast.Document{
+			Loc:         doc.Loc,
+			Definitions: definitions,
+		})
+	}
+	return separated
+}
+
+// Operations and fragments may share names, so the dependencies of a fragment
+// are keyed apart from those of an operation.
+func operationKey(operation *ast.OperationDefinition) string {
+	return "operation " + operationName(operation)
+}
+
+func fragmentKey(name string) string {
+	return "fragment " + name
+}
+
+func operationName(operation *ast.OperationDefinition) string {
+	if operation.Name == nil {
+		return ""
+	}
+	return operation.Name.Value
+}
+
+// Adds the names of the fragments the definition depends on to collected,
+// fragments already collected are not followed again.
+func collectTransitiveDependencies(collected map[string]bool, dependencies map[string][]string, key string) {
+	for _, fragmentName := range dependencies[key] {
+		if collected[fragmentName] {
+			continue
+		}
+		collected[fragmentName] = true
+		collectTransitiveDependencies(collected, dependencies, fragmentKey(fragmentName))
+	}
+}
+
+type spreadNamesCollector struct {
+	names []string
+}
+
+func (c *spreadNamesCollector) Enter(node ast.Node) (string, ast.Node) {
+	if spread, ok := node.(*ast.FragmentSpread); ok && spread.Name != nil {
+		c.names = append(c.names, spread.Name.Value)
+	}
+	return visitor.ActionNoChange, nil
+}
+
+func (c *spreadNamesCollector) Leave(node ast.Node) (string, ast.Node) {
+	return visitor.ActionNoChange, nil
+}
+
+// Returns the names of the fragments spread within the definition.
+func collectSpreadNames(definition ast.Node) []string {
+	collector := &spreadNamesCollector{}
+	visitor.VisitNode(definition, collector)
+	return collector.names
+}
@@ -0,0 +1,91 @@
+package gql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/language/printer"
+
+	"github.com/chris-ramon/graphql-go/testutil"
+)
+
+func TestSeparateOperations_SeparatesOperationsWithTheFragmentsTheyReference(t *testing.T) {
+	doc := testutil.Parse(t, `
+      {
+        ...Y
+        ...X
+      }
+
+      query One {
+        foo
+        bar
+        ...A
+        ...X
+      }
+
+      fragment A on T {
+        field
+        ...B
+      }
+
+      fragment X on T {
+        fieldX
+      }
+
+      fragment B on T {
+        something
+      }
+
+      query Two {
+        ...A
+        ...Y
+        baz
+      }
+
+      fragment Y on T {
+        fieldY
+      }
+	`)
+	expected := map[string]interface{}{
+		"": "{\n  ...Y\n  ...X\n}\n\n" +
+			"fragment X on T {\n  fieldX\n}\n\n" +
+			"fragment Y on T {\n  fieldY\n}\n",
+		"One": "query One {\n  foo\n  bar\n  ...A\n  ...X\n}\n\n" +
+			"fragment A on T {\n  field\n  ...B\n}\n\n" +
+			"fragment X on T {\n  fieldX\n}\n\n" +
+			"fragment B on T {\n  something\n}\n",
+		"Two": "fragment A on T {\n  field\n  ...B\n}\n\n" +
+			"fragment B on T {\n  something\n}\n\n" +
+			"query Two {\n  ...A\n  ...Y\n  baz\n}\n\n" +
+			"fragment Y on T {\n  fieldY\n}\n",
+	}
+	printed := map[string]interface{}{}
+	for name, operationDoc := range SeparateOperations(doc) {
+		printed[name] = printer.Print(operationDoc)
+	}
+	if !reflect.DeepEqual(expected, printed) {
+		t.Fatalf("Unexpected documents, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
+
+func TestSeparateOperations_FollowsCyclicFragmentsOnce(t *testing.T) {
+	doc := testutil.Parse(t, `
+      query One {
+        ...A
+      }
+
+      fragment A on T {
+        ...B
+      }
+
+      fragment B on T {
+        ...A
+      }
+	`)
+	expected := "query One {\n  ...A\n}\n\n" +
+		"fragment A on T {\n  ...B\n}\n\n" +
+		"fragment B on T {\n  ...A\n}\n"
+	if printed := printer.Print(SeparateOperations(doc)["One"]); !reflect.DeepEqual(expected, printed) {
+		t.Fatalf("Unexpected document, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
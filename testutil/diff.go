@@ -0,0 +1,166 @@
+package testutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Diff returns the differences between two values, e.g. an expected and an
+// actual *types.GraphQLResult, one per line, "" if they are deeply equal.
+// Each line reads `path: a != b`, the path leading to the differing value
+// through struct fields, map keys and list indices, e.g.
+// `Data.hero.friends[1].name: "Leia" != "Han"`. A key or list item missing
+// from one of the values is reported as <missing>, and values of different
+// types are reported with their types.
+func Diff(a, b interface{}) string {
+	d := &differ{visited: map[visit]bool{}}
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+	if len(d.lines) == 0 {
+		return ""
+	}
+	return "\n" + strings.Join(d.lines, "\n")
+}
+
+type differ struct {
+	lines   []string
+	visited map[visit]bool
+}
+
+// A pair of pointers already compared, guards against cyclic values such as
+// the types of a schema.
+type visit struct {
+	a, b  uintptr
+	ttype reflect.Type
+}
+
+func (d *differ) report(path string, a, b string) {
+	if path == "" {
+		path = "(root)"
+	}
+	d.lines = append(d.lines, fmt.Sprintf("%v: %v != %v", path, a, b))
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	// Interfaces are compared by the values they hold, e.g. the items of an
+	// []interface{}.
+	for a.IsValid() && a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	for b.IsValid() && b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.report(path, formatValue(a), formatValue(b))
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		d.report(path, formatTypedValue(a), formatTypedValue(b))
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.report(path, formatValue(a), formatValue(b))
+			}
+			return
+		}
+		if d.seen(a, b) {
+			return
+		}
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			d.diff(joinPath(path, a.Type().Field(i).Name), a.Field(i), b.Field(i))
+		}
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() {
+			d.report(path, formatValue(a), formatValue(b))
+			return
+		}
+		if d.seen(a, b) {
+			return
+		}
+		keys := map[string]reflect.Value{}
+		for _, key := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[fmt.Sprintf("%v", key)] = key
+		}
+		names := []string{}
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			key := keys[name]
+			keyPath := joinPath(path, name)
+			aItem, bItem := a.MapIndex(key), b.MapIndex(key)
+			switch {
+			case !aItem.IsValid():
+				d.report(keyPath, "<missing>", formatValue(bItem))
+			case !bItem.IsValid():
+				d.report(keyPath, formatValue(aItem), "<missing>")
+			default:
+				d.diff(keyPath, aItem, bItem)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() {
+			d.report(path, formatValue(a), formatValue(b))
+			return
+		}
+		for i := 0; i < a.Len() || i < b.Len(); i++ {
+			itemPath := fmt.Sprintf("%v[%v]", path, i)
+			switch {
+			case i >= a.Len():
+				d.report(itemPath, "<missing>", formatValue(b.Index(i)))
+			case i >= b.Len():
+				d.report(itemPath, formatValue(a.Index(i)), "<missing>")
+			default:
+				d.diff(itemPath, a.Index(i), b.Index(i))
+			}
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			d.report(path, formatValue(a), formatValue(b))
+		}
+	default:
+		if formatValue(a) != formatValue(b) {
+			d.report(path, formatValue(a), formatValue(b))
+		}
+	}
+}
+
+func (d *differ) seen(a, b reflect.Value) bool {
+	v := visit{a.Pointer(), b.Pointer(), a.Type()}
+	if d.visited[v] {
+		return true
+	}
+	d.visited[v] = true
+	return false
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+func formatTypedValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%#v (%v)", v, v.Type())
+}
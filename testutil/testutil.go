@@ -10,7 +10,6 @@ import (
 	"github.com/chris-ramon/graphql-go/language/ast"
 	"github.com/chris-ramon/graphql-go/language/parser"
 	"github.com/chris-ramon/graphql-go/types"
-	"github.com/kr/pretty"
 )
 
 var (
@@ -364,10 +363,6 @@ func Execute(t *testing.T, ep executor.ExecuteParams) *types.GraphQLResult {
 	return result
 }
 
-func Diff(a, b interface{}) []string {
-	return pretty.Diff(a, b)
-}
-
 func ASTToJSON(t *testing.T, a ast.Node) interface{} {
 	b, err := json.Marshal(a)
 	if err != nil {
@@ -2,6 +2,8 @@ package testutil_test
 
 import (
 	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+	"strings"
 	"testing"
 )
 
@@ -300,3 +302,63 @@ func TestSubset_ComplexMixed_Fail(t *testing.T) {
 		t.Fatalf("expected map to not be subset of super, got true")
 	}
 }
+
+func TestDiff_ReportsNoDifferenceBetweenEqualResults(t *testing.T) {
+	a := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+			},
+		},
+	}
+	b := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+			},
+		},
+	}
+	if diff := testutil.Diff(a, b); diff != "" {
+		t.Fatalf("expected no difference, got: %v", diff)
+	}
+}
+
+func TestDiff_ReportsTheDifferencesOfNestedValues(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+				"id":   "2001",
+				"friends": []interface{}{
+					map[string]interface{}{"name": "Luke Skywalker"},
+					map[string]interface{}{"name": "Han Solo"},
+				},
+			},
+		},
+	}
+	result := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name":        "R2-D2",
+				"id":          2001,
+				"primaryFunc": "Astromech",
+				"friends": []interface{}{
+					map[string]interface{}{"name": "Luke Skywalker"},
+					map[string]interface{}{"name": "Leia Organa"},
+					map[string]interface{}{"name": "Han Solo"},
+				},
+			},
+		},
+	}
+	diff := testutil.Diff(expected, result)
+	lines := []string{
+		``,
+		`Data.hero.friends[1].name: "Han Solo" != "Leia Organa"`,
+		`Data.hero.friends[2]: <missing> != map[string]interface {}{"name":"Han Solo"}`,
+		`Data.hero.id: "2001" (string) != 2001 (int)`,
+		`Data.hero.primaryFunc: <missing> != "Astromech"`,
+	}
+	if diff != strings.Join(lines, "\n") {
+		t.Fatalf("unexpected diff, got: %v", diff)
+	}
+}
@@ -0,0 +1,557 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/parser"
+)
+
+// BuildSchema builds a schema from its definition in the schema definition
+// language, see BuildASTSchema.
+func BuildSchema(sdl string) (GraphQLSchema, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: sdl})
+	if err != nil {
+		return GraphQLSchema{}, err
+	}
+	return BuildASTSchema(doc)
+}
+
+// BuildASTSchema builds a schema from the type definitions of a document,
+// other definitions are ignored. The root operation types are the object
+// types named Query, Mutation and Subscription, the descriptions preceding
+// the definitions are the descriptions of the types, fields, arguments and
+// values. The fields and enum values marked with the @deprecated directive
+// are deprecated for its reason, "No longer supported" when omitted, and
+// the URL of the @specifiedBy directive of a scalar is its SpecifiedByURL.
+//
+// The fields of the built types resolve to the values of the same name of
+// their source, abstract types resolve to the object type named by the
+// `__typename` value of their source, and custom scalars pass their values
+// through as is.
+func BuildASTSchema(doc *ast.Document) (GraphQLSchema, error) {
+	if doc == nil {
+		return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError("Must provide a document.")
+	}
+	b := &schemaBuilder{
+		definitions: map[string]ast.Node{},
+		types: GraphQLTypeMap{
+			"String":  GraphQLString,
+			"Int":     GraphQLInt,
+			"Float":   GraphQLFloat,
+			"Boolean": GraphQLBoolean,
+			"ID":      GraphQLID,
+		},
+	}
+	for _, def := range doc.Definitions {
+		name := typeDefinitionName(def)
+		if name == "" {
+			continue
+		}
+		if _, ok := b.definitions[name]; ok {
+			return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(
+				fmt.Sprintf(`Type "%v" was defined more than once.`, name))
+		}
+		if _, ok := b.types[name]; ok {
+			return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(
+				fmt.Sprintf(`Type "%v" is a built-in type and cannot be redefined.`, name))
+		}
+		b.definitions[name] = def
+	}
+	// The types are built lazily by thunks, which cannot report unknown type
+	// references, so these are checked up front.
+	for _, def := range doc.Definitions {
+		if err := b.checkTypeReferences(def); err != nil {
+			return GraphQLSchema{}, err
+		}
+	}
+	if _, ok := b.definitions["Query"]; !ok {
+		return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError("Must provide a type named Query.")
+	}
+
+	config := GraphQLSchemaConfig{}
+	rootTypes := []struct {
+		name     string
+		rootType **GraphQLObjectType
+	}{
+		{"Query", &config.Query},
+		{"Mutation", &config.Mutation},
+		{"Subscription", &config.Subscription},
+	}
+	for _, root := range rootTypes {
+		if _, ok := b.definitions[root.name]; !ok {
+			continue
+		}
+		rootType, ok := b.namedType(root.name).(*GraphQLObjectType)
+		if !ok {
+			return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(
+				fmt.Sprintf(`%v root type must be an Object type.`, root.name))
+		}
+		*root.rootType = rootType
+	}
+	names := []string{}
+	for name := range b.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		config.Types = append(config.Types, b.namedType(name))
+	}
+	schema, err := NewGraphQLSchema(config)
+	if err != nil {
+		return schema, err
+	}
+	schema.definitionOrder = documentDefinitionOrder(doc)
+	return schema, nil
+}
+
+type schemaBuilder struct {
+	definitions map[string]ast.Node
+	types       GraphQLTypeMap
+	// the lists and non-null types of the types of the builder, shared by
+	// the references to them
+	wrappers wrapperCache
+}
+
+func typeDefinitionName(def ast.Node) string {
+	var name *ast.Name
+	switch def := def.(type) {
+	case *ast.ObjectTypeDefinition:
+		name = def.Name
+	case *ast.InterfaceTypeDefinition:
+		name = def.Name
+	case *ast.UnionTypeDefinition:
+		name = def.Name
+	case *ast.ScalarTypeDefinition:
+		name = def.Name
+	case *ast.EnumTypeDefinition:
+		name = def.Name
+	case *ast.InputObjectTypeDefinition:
+		name = def.Name
+	}
+	if name == nil {
+		return ""
+	}
+	return name.Value
+}
+
+// A reference to a type by a definition, the type being required to be of
+// one of the given kinds, e.g. TypeKindInterface for an implemented
+// interface.
+type typeReference struct {
+	ref   ast.Type
+	kinds []string
+	// describes the reference in an error, e.g. `The type of "Query.user"
+	// must be an output type`
+	requirement string
+}
+
+var (
+	inputTypeKinds  = []string{TypeKindScalar, TypeKindEnum, TypeKindInputObject}
+	outputTypeKinds = []string{TypeKindScalar, TypeKindObject, TypeKindInterface, TypeKindUnion, TypeKindEnum}
+)
+
+// Reports the references of a definition to the types which are neither
+// defined nor built-in, or which are not of a kind the reference allows,
+// e.g. an input object type as the type of a field.
+func (b *schemaBuilder) checkTypeReferences(def ast.Node) error {
+	refs := []typeReference{}
+	addFields := func(typeName string, fields []*ast.FieldDefinition) {
+		for _, field := range fields {
+			if field.Name == nil {
+				continue
+			}
+			refs = append(refs, typeReference{field.Type, outputTypeKinds,
+				fmt.Sprintf(`The type of "%v.%v" must be an output type`, typeName, field.Name.Value)})
+			for _, arg := range field.Arguments {
+				if arg.Name == nil {
+					continue
+				}
+				refs = append(refs, typeReference{arg.Type, inputTypeKinds,
+					fmt.Sprintf(`The type of "%v.%v(%v:)" must be an input type`, typeName, field.Name.Value, arg.Name.Value)})
+			}
+		}
+	}
+	typeName := typeDefinitionName(def)
+	switch def := def.(type) {
+	case *ast.ObjectTypeDefinition:
+		for _, iface := range def.Interfaces {
+			refs = append(refs, typeReference{iface, []string{TypeKindInterface},
+				fmt.Sprintf(`The interfaces of "%v" must be interface types`, typeName)})
+		}
+		addFields(typeName, def.Fields)
+	case *ast.InterfaceTypeDefinition:
+		addFields(typeName, def.Fields)
+	case *ast.UnionTypeDefinition:
+		for _, member := range def.Types {
+			refs = append(refs, typeReference{member, []string{TypeKindObject},
+				fmt.Sprintf(`The members of union "%v" must be object types`, typeName)})
+		}
+	case *ast.InputObjectTypeDefinition:
+		for _, field := range def.Fields {
+			if field.Name == nil {
+				continue
+			}
+			refs = append(refs, typeReference{field.Type, inputTypeKinds,
+				fmt.Sprintf(`The type of "%v.%v" must be an input type`, typeName, field.Name.Value)})
+		}
+	}
+	for _, ref := range refs {
+		name := namedTypeName(ref.ref)
+		kind := b.typeKind(name)
+		if kind == "" {
+			return graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(`Type "%v" not found in document.`, name))
+		}
+		allowed := false
+		for _, allowedKind := range ref.kinds {
+			allowed = allowed || kind == allowedKind
+		}
+		if !allowed {
+			return graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(`%v but got: "%v".`, ref.requirement, name))
+		}
+	}
+	return nil
+}
+
+// Returns the kind of the type of the builder, or defined by the document,
+// of the given name, e.g. TypeKindObject, "" if there is none.
+func (b *schemaBuilder) typeKind(name string) string {
+	if def, ok := b.definitions[name]; ok {
+		switch def.(type) {
+		case *ast.ObjectTypeDefinition:
+			return TypeKindObject
+		case *ast.InterfaceTypeDefinition:
+			return TypeKindInterface
+		case *ast.UnionTypeDefinition:
+			return TypeKindUnion
+		case *ast.ScalarTypeDefinition:
+			return TypeKindScalar
+		case *ast.EnumTypeDefinition:
+			return TypeKindEnum
+		case *ast.InputObjectTypeDefinition:
+			return TypeKindInputObject
+		}
+	}
+	switch b.types[name].(type) {
+	case *GraphQLObjectType:
+		return TypeKindObject
+	case *GraphQLInterfaceType:
+		return TypeKindInterface
+	case *GraphQLUnionType:
+		return TypeKindUnion
+	case *GraphQLScalarType:
+		return TypeKindScalar
+	case *GraphQLEnumType:
+		return TypeKindEnum
+	case *GraphQLInputObjectType:
+		return TypeKindInputObject
+	}
+	return ""
+}
+
+func namedTypeName(node ast.Type) string {
+	switch node := node.(type) {
+	case *ast.ListType:
+		return namedTypeName(node.Type)
+	case *ast.NonNullType:
+		return namedTypeName(node.Type)
+	case *ast.NamedType:
+		if node != nil && node.Name != nil {
+			return node.Name.Value
+		}
+	}
+	return ""
+}
+
+func (b *schemaBuilder) typeOf(node ast.Type) GraphQLType {
+	switch node := node.(type) {
+	case *ast.ListType:
+		return b.wrappers.list(b.typeOf(node.Type))
+	case *ast.NonNullType:
+		return b.wrappers.nonNull(b.typeOf(node.Type))
+	}
+	return b.namedType(namedTypeName(node))
+}
+
+// Returns the named type, building it from its definition the first time it
+// is referenced. A type is added to the type map before its fields are built,
+// so that the fields may refer to the type itself.
+func (b *schemaBuilder) namedType(name string) GraphQLType {
+	if ttype, ok := b.types[name]; ok {
+		return ttype
+	}
+	switch def := b.definitions[name].(type) {
+	case *ast.ObjectTypeDefinition:
+		b.types[name] = NewGraphQLObjectType(GraphQLObjectTypeConfig{
+			Name:        name,
+			Description: descriptionValue(def.Description),
+			Interfaces: GraphQLInterfacesThunk(func() []*GraphQLInterfaceType {
+				ifaces := []*GraphQLInterfaceType{}
+				for _, iface := range def.Interfaces {
+					ttype, _ := b.typeOf(iface).(*GraphQLInterfaceType)
+					ifaces = append(ifaces, ttype)
+				}
+				return ifaces
+			}),
+			Fields: GraphQLFieldConfigMapThunk(func() GraphQLFieldConfigMap {
+				return b.fieldConfigMap(def.Fields)
+			}),
+		})
+	case *ast.InterfaceTypeDefinition:
+		fields := GraphQLFieldConfigMap{}
+		b.types[name] = NewGraphQLInterfaceType(GraphQLInterfaceTypeConfig{
+			Name:        name,
+			Description: descriptionValue(def.Description),
+			Fields:      fields,
+			ResolveType: resolveTypename,
+		})
+		for fieldName, field := range b.fieldConfigMap(def.Fields) {
+			fields[fieldName] = field
+		}
+	case *ast.UnionTypeDefinition:
+		b.types[name] = NewGraphQLUnionType(GraphQLUnionTypeConfig{
+			Name:        name,
+			Description: descriptionValue(def.Description),
+			Types: GraphQLUnionTypesThunk(func() []*GraphQLObjectType {
+				members := []*GraphQLObjectType{}
+				for _, member := range def.Types {
+					ttype, _ := b.typeOf(member).(*GraphQLObjectType)
+					members = append(members, ttype)
+				}
+				return members
+			}),
+			ResolveType: resolveTypename,
+		})
+	case *ast.ScalarTypeDefinition:
+		b.types[name] = NewGraphQLScalarType(GraphQLScalarTypeConfig{
+			Name:           name,
+			Description:    descriptionValue(def.Description),
+			SpecifiedByURL: specifiedByURL(def.Directives),
+			Serialize: func(value interface{}) interface{} {
+				return value
+			},
+			ParseValue: func(value interface{}) interface{} {
+				return value
+			},
+			ParseLiteral: literalValue,
+		})
+	case *ast.EnumTypeDefinition:
+		values := GraphQLEnumValueConfigMap{}
+		for _, value := range def.Values {
+			if value.Name == nil {
+				continue
+			}
+			values[value.Name.Value] = &GraphQLEnumValueConfig{
+				Description:       descriptionValue(value.Description),
+				DeprecationReason: deprecationReason(value.Directives),
+			}
+		}
+		b.types[name] = NewGraphQLEnumType(GraphQLEnumTypeConfig{
+			Name:        name,
+			Description: descriptionValue(def.Description),
+			Values:      values,
+		})
+	case *ast.InputObjectTypeDefinition:
+		b.types[name] = NewGraphQLInputObjectType(InputObjectConfig{
+			Name:        name,
+			Description: descriptionValue(def.Description),
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for _, field := range def.Fields {
+					if field.Name == nil {
+						continue
+					}
+					ttype, _ := b.typeOf(field.Type).(GraphQLInputType)
+					fields[field.Name.Value] = &InputObjectFieldConfig{
+						Type:         ttype,
+						DefaultValue: defaultValue(field.DefaultValue, ttype),
+						Description:  descriptionValue(field.Description),
+					}
+				}
+				return fields
+			}),
+		})
+	}
+	return b.types[name]
+}
+
+func (b *schemaBuilder) fieldConfigMap(defs []*ast.FieldDefinition) GraphQLFieldConfigMap {
+	fields := GraphQLFieldConfigMap{}
+	for _, def := range defs {
+		if def.Name == nil {
+			continue
+		}
+		args := GraphQLFieldConfigArgumentMap{}
+		argOrder := []string{}
+		for _, arg := range def.Arguments {
+			if arg.Name == nil {
+				continue
+			}
+			argOrder = append(argOrder, arg.Name.Value)
+			ttype, _ := b.typeOf(arg.Type).(GraphQLInputType)
+			args[arg.Name.Value] = &GraphQLArgumentConfig{
+				Type:         ttype,
+				DefaultValue: defaultValue(arg.DefaultValue, ttype),
+				Description:  descriptionValue(arg.Description),
+			}
+		}
+		ttype, _ := b.typeOf(def.Type).(GraphQLOutputType)
+		fields[def.Name.Value] = &GraphQLFieldConfig{
+			Type:              ttype,
+			Args:              args,
+			ArgOrder:          argOrder,
+			Description:       descriptionValue(def.Description),
+			DeprecationReason: deprecationReason(def.Directives),
+		}
+	}
+	return fields
+}
+
+func descriptionValue(description *ast.StringValue) string {
+	if description == nil {
+		return ""
+	}
+	return description.Value
+}
+
+// Returns the reason of the @deprecated directive among the directives of a
+// field or enum value definition, "" if it is not deprecated.
+func deprecationReason(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != GraphQLDeprecatedDirective.Name {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg.Name == nil || arg.Name.Value != "reason" {
+				continue
+			}
+			if reason, ok := arg.Value.(*ast.StringValue); ok {
+				return reason.Value
+			}
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// Returns the URL of the @specifiedBy directive among the directives of a
+// scalar definition, "" if there is none.
+func specifiedByURL(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != GraphQLSpecifiedByDirective.Name {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg.Name == nil || arg.Name.Value != "url" {
+				continue
+			}
+			if url, ok := arg.Value.(*ast.StringValue); ok {
+				return url.Value
+			}
+		}
+	}
+	return ""
+}
+
+// Resolves the object type named by the `__typename` value of a map source.
+func resolveTypename(value interface{}, info GraphQLResolveInfo) *GraphQLObjectType {
+	source, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	typeName, _ := source["__typename"].(string)
+	objectType, _ := info.Schema.GetType(typeName).(*GraphQLObjectType)
+	return objectType
+}
+
+// Coerces the default value literal of an argument or input field to the
+// internal value of its type.
+func defaultValue(valueAST ast.Value, ttype GraphQLInputType) interface{} {
+	if valueAST == nil {
+		return nil
+	}
+	switch ttype := ttype.(type) {
+	case *GraphQLNonNull:
+		return defaultValue(valueAST, ttype.OfType)
+	case *GraphQLList:
+		if listValue, ok := valueAST.(*ast.ListValue); ok {
+			values := []interface{}{}
+			for _, itemAST := range listValue.Values {
+				values = append(values, defaultValue(itemAST, ttype.OfType))
+			}
+			return values
+		}
+		return []interface{}{defaultValue(valueAST, ttype.OfType)}
+	case *GraphQLInputObjectType:
+		objectValue, ok := valueAST.(*ast.ObjectValue)
+		if !ok {
+			return nil
+		}
+		fields := ttype.GetFields()
+		values := map[string]interface{}{}
+		for _, fieldAST := range objectValue.Fields {
+			if fieldAST.Name == nil {
+				continue
+			}
+			field, ok := fields[fieldAST.Name.Value]
+			if !ok {
+				continue
+			}
+			values[field.Name] = defaultValue(fieldAST.Value, field.Type)
+		}
+		return values
+	case *GraphQLScalarType:
+		return ttype.ParseLiteral(valueAST)
+	case *GraphQLEnumType:
+		return ttype.ParseLiteral(valueAST)
+	}
+	return nil
+}
+
+// Returns the value of a literal of a custom scalar, or of GraphQLJSON.
+func literalValue(valueAST ast.Value) interface{} {
+	return literalValueWithVariables(valueAST, nil)
+}
+
+// Same as literalValue, the variables of the literal being replaced by
+// their values, the missing ones by nil.
+func literalValueWithVariables(valueAST ast.Value, variables map[string]interface{}) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.Variable:
+		if valueAST.Name != nil {
+			return variables[valueAST.Name.Value]
+		}
+	case *ast.IntValue:
+		if value, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+			return int(value)
+		}
+	case *ast.FloatValue:
+		if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return value
+		}
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	case *ast.ListValue:
+		values := []interface{}{}
+		for _, itemAST := range valueAST.Values {
+			values = append(values, literalValueWithVariables(itemAST, variables))
+		}
+		return values
+	case *ast.ObjectValue:
+		values := map[string]interface{}{}
+		for _, fieldAST := range valueAST.Fields {
+			if fieldAST.Name != nil {
+				values[fieldAST.Name.Value] = literalValueWithVariables(fieldAST.Value, variables)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package types_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestBuildSchema_ExposesDescriptionsThroughIntrospection(t *testing.T) {
+	schema, err := types.BuildSchema(`
+"""
+A published article.
+"""
+type Article {
+  "The title of the article."
+  title(
+    "Whether to capitalize the title."
+    upper: Boolean = false
+  ): String
+  body: String
+}
+
+type Query {
+  article: Article
+}
+`)
+	if err != nil {
+		t.Fatalf("Error building schema: %v", err)
+	}
+	query := `{
+      __type(name: "Article") {
+        description
+        fields {
+          name
+          description
+          args { name description defaultValue }
+        }
+      }
+    }`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"description": "A published article.",
+				"fields": []interface{}{
+					map[string]interface{}{
+						"name":        "title",
+						"description": "The title of the article.",
+						"args": []interface{}{
+							map[string]interface{}{
+								"name":         "upper",
+								"description":  "Whether to capitalize the title.",
+								"defaultValue": "false",
+							},
+						},
+					},
+					map[string]interface{}{
+						"name":        "body",
+						"description": nil,
+						"args":        []interface{}{},
+					},
+				},
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: query,
+	})
+	// the fields are listed in no particular order
+	if !reflect.DeepEqual(sortedByName(expected.Data), sortedByName(result.Data)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(sortedByName(expected.Data), sortedByName(result.Data)))
+	}
+}
+
+func TestBuildSchema_ResolvesTheFieldsOfTheSource(t *testing.T) {
+	schema, err := types.BuildSchema(`
+interface Named {
+  name: String
+}
+
+type Dog implements Named {
+  name: String
+  friends: [Named]
+}
+
+type Query {
+  pets: [Named]
+}
+`)
+	if err != nil {
+		t.Fatalf("Error building schema: %v", err)
+	}
+	root := map[string]interface{}{
+		"pets": []interface{}{
+			map[string]interface{}{
+				"__typename": "Dog",
+				"name":       "Odie",
+				"friends": []interface{}{
+					map[string]interface{}{"__typename": "Dog", "name": "Snoopy"},
+				},
+			},
+		},
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"pets": []interface{}{
+				map[string]interface{}{
+					"name": "Odie",
+					"friends": []interface{}{
+						map[string]interface{}{"name": "Snoopy"},
+					},
+				},
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: `{ pets { name ... on Dog { friends { name } } } }`,
+		RootObject:    root,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestBuildSchema_RejectsUnknownTypesAndTypesOfTheWrongKind(t *testing.T) {
+	tests := []struct {
+		sdl      string
+		expected string
+	}{
+		{
+			sdl:      `type Query { article: Article }`,
+			expected: `Type "Article" not found in document.`,
+		},
+		{
+			sdl:      `input ArticleInput { title: String } type Query { article: ArticleInput }`,
+			expected: `The type of "Query.article" must be an output type but got: "ArticleInput".`,
+		},
+		{
+			sdl:      `type Article { title: String } type Query { article(filter: Article): String }`,
+			expected: `The type of "Query.article(filter:)" must be an input type but got: "Article".`,
+		},
+		{
+			sdl:      `type Article { title: String } input ArticleInput { related: Article } type Query { article(input: ArticleInput): String }`,
+			expected: `The type of "ArticleInput.related" must be an input type but got: "Article".`,
+		},
+		{
+			sdl:      `type Node { id: ID } type Query implements Node { id: ID }`,
+			expected: `The interfaces of "Query" must be interface types but got: "Node".`,
+		},
+		{
+			sdl:      `interface Node { id: ID } union Result = Node type Query { result: Result }`,
+			expected: `The members of union "Result" must be object types but got: "Node".`,
+		},
+	}
+	for _, test := range tests {
+		_, err := types.BuildSchema(test.sdl)
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("Expected error %q, got: %v", test.expected, err)
+		}
+	}
+}
+
+func TestBuildSchema_DeprecatesFieldsAndEnumValues(t *testing.T) {
+	schema, err := types.BuildSchema(`
+type Article {
+  title: String
+  headline: String @deprecated(reason: "Use title.")
+  summary: String @deprecated
+}
+
+enum Status {
+  DRAFT
+  PENDING @deprecated(reason: "Use DRAFT.")
+}
+
+type Query {
+  article: Article
+  status: Status
+}
+`)
+	if err != nil {
+		t.Fatalf("Error building schema: %v", err)
+	}
+	fields := schema.GetType("Article").(*types.GraphQLObjectType).GetFields()
+	reasons := map[string]string{
+		"title":    "",
+		"headline": "Use title.",
+		"summary":  "No longer supported",
+	}
+	for name, reason := range reasons {
+		if fields[name].DeprecationReason != reason {
+			t.Fatalf("Expected Article.%v to be deprecated for %q, got: %q", name, reason, fields[name].DeprecationReason)
+		}
+	}
+	for _, value := range schema.GetType("Status").(*types.GraphQLEnumType).GetValues() {
+		if value.Name == "PENDING" && value.DeprecationReason != "Use DRAFT." {
+			t.Fatalf("Expected Status.PENDING to be deprecated, got: %q", value.DeprecationReason)
+		}
+	}
+
+	query := `{
+      article: __type(name: "Article") {
+        fields { name }
+      }
+      status: __type(name: "Status") {
+        enumValues { name }
+      }
+    }`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"article": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{"name": "title"},
+				},
+			},
+			"status": map[string]interface{}{
+				"enumValues": []interface{}{
+					map[string]interface{}{"name": "DRAFT"},
+				},
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestBuildSchema_ReportsTheSpecificationURLOfScalars(t *testing.T) {
+	sdl := `type Query {
+  homepage: URL
+}
+
+scalar URL @specifiedBy(url: "https://tools.ietf.org/html/rfc3986")
+`
+	schema, err := types.BuildSchema(sdl)
+	if err != nil {
+		t.Fatalf("Error building schema: %v", err)
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"specifiedByURL": "https://tools.ietf.org/html/rfc3986",
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: `{ __type(name: "URL") { specifiedByURL } }`,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if printed := types.PrintSchema(schema, types.PrintOptions{}); printed != sdl {
+		t.Fatalf("Unexpected printed schema, Diff: %v", testutil.Diff(sdl, printed))
+	}
+}
+
+func TestBuildSchema_BuildsThePrintedSpecificationURLOfScalars(t *testing.T) {
+	urlType := types.NewGraphQLScalarType(types.GraphQLScalarTypeConfig{
+		Name:           "URL",
+		SpecifiedByURL: "https://tools.ietf.org/html/rfc3986",
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"homepage": &types.GraphQLFieldConfig{
+					Type: urlType,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	printed := types.PrintSchema(schema, types.PrintOptions{})
+	built, err := types.BuildSchema(printed)
+	if err != nil {
+		t.Fatalf("Error building the printed schema: %v", err)
+	}
+	hasSpecifiedBy := false
+	for _, directive := range built.GetDirectives() {
+		hasSpecifiedBy = hasSpecifiedBy || directive == types.GraphQLSpecifiedByDirective
+	}
+	if !hasSpecifiedBy {
+		t.Fatalf("Expected the built schema to have the @specifiedBy directive, got: %v", built.GetDirectives())
+	}
+	builtURLType, ok := built.GetType("URL").(*types.GraphQLScalarType)
+	if !ok || builtURLType.SpecifiedByURL != urlType.SpecifiedByURL {
+		t.Fatalf("Expected the built URL scalar to be specified by %v, got: %v", urlType.SpecifiedByURL, built.GetType("URL"))
+	}
+	if reprinted := types.PrintSchema(built, types.PrintOptions{}); reprinted != printed {
+		t.Fatalf("Unexpected printed schema, Diff: %v", testutil.Diff(printed, reprinted))
+	}
+}
+
+type buildSchemaTestAuthor struct{}
+
+func (author *buildSchemaTestAuthor) Pic(width, height int) string {
+	return fmt.Sprintf("%vx%v", width, height)
+}
+
+func TestBuildSchema_BindsTheArgumentsToTheParametersOfMethodsInTheirOrder(t *testing.T) {
+	schema, err := types.BuildSchema(`
+type Author {
+  pic(width: Int, height: Int): String
+}
+
+type Query {
+  author: Author
+}
+`)
+	if err != nil {
+		t.Fatalf("Error building schema: %v", err)
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"author": map[string]interface{}{
+				"pic": "640x480",
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: `{ author { pic(height: 480, width: 640) } }`,
+		RootObject:    map[string]interface{}{"author": &buildSchemaTestAuthor{}},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"github.com/chris-ramon/graphql-go/language/ast"
+)
+
+// ShouldIncludeNode reports whether a selection is included with the given
+// variable values, as decided by its @skip and @include directives: it is
+// left out if the condition of @skip is true or the one of @include false.
+// A condition which is not a boolean, e.g. a missing variable, is ignored.
+func ShouldIncludeNode(directives []*ast.Directive, variableValues map[string]interface{}) bool {
+	for _, directive := range directives {
+		if directive == nil || directive.Name == nil {
+			continue
+		}
+		condition, ok := directiveCondition(directive, variableValues)
+		if !ok {
+			continue
+		}
+		switch directive.Name.Value {
+		case GraphQLSkipDirective.Name:
+			if condition {
+				return false
+			}
+		case GraphQLIncludeDirective.Name:
+			if !condition {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns the value of the `if` argument of a directive, ok is false if it
+// is not a boolean.
+func directiveCondition(directive *ast.Directive, variableValues map[string]interface{}) (condition bool, ok bool) {
+	for _, arg := range directive.Arguments {
+		if arg == nil || arg.Name == nil || arg.Name.Value != "if" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.BooleanValue:
+			return value.Value, true
+		case *ast.Variable:
+			if value.Name != nil {
+				condition, ok = variableValues[value.Name.Value].(bool)
+				return condition, ok
+			}
+		}
+	}
+	return false, false
+}
+
+// DoesFragmentConditionMatch reports whether a fragment of the given type
+// condition applies to an object type: the condition is the type itself or
+// an abstract type it is a possible type of. A fragment without a type
+// condition applies to any type.
+func DoesFragmentConditionMatch(schema GraphQLSchema, condition *ast.NamedType, ttype *GraphQLObjectType) bool {
+	if condition == nil {
+		return true
+	}
+	conditionalType := TypeFromAST(schema, condition)
+	if conditionalType == ttype {
+		return true
+	}
+	if _, ok := conditionalType.(GraphQLAbstractType); ok {
+		return schema.IsPossibleType(conditionalType, ttype)
+	}
+	return false
+}
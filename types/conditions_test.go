@@ -0,0 +1,107 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestShouldIncludeNode_EvaluatesSkipAndInclude(t *testing.T) {
+	type Test struct {
+		directives string
+		expected   bool
+	}
+	tests := []Test{
+		Test{``, true},
+		Test{`@skip(if: true)`, false},
+		Test{`@skip(if: false)`, true},
+		Test{`@include(if: true)`, true},
+		Test{`@include(if: false)`, false},
+		Test{`@skip(if: false) @include(if: false)`, false},
+		Test{`@include(if: true) @skip(if: true)`, false},
+		Test{`@skip(if: $yes)`, false},
+		Test{`@include(if: $no)`, false},
+		Test{`@include(if: $missing)`, true},
+	}
+	variableValues := map[string]interface{}{"yes": true, "no": false}
+	for _, test := range tests {
+		doc := testutil.Parse(t, `{ a `+test.directives+` }`)
+		field := doc.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+		if included := types.ShouldIncludeNode(field.Directives, variableValues); included != test.expected {
+			t.Fatalf("expected a field with %q to be included: %v, got: %v", test.directives, test.expected, included)
+		}
+	}
+}
+
+func TestDoesFragmentConditionMatch_MatchesTheTypeAndItsAbstractTypes(t *testing.T) {
+	namedInterface := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Named",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	dogType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "Dog",
+		Interfaces: []*types.GraphQLInterfaceType{namedInterface},
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	catType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Cat",
+		Fields: types.GraphQLFieldConfigMap{
+			"meows": &types.GraphQLFieldConfig{
+				Type: types.GraphQLBoolean,
+			},
+		},
+	})
+	petUnion := types.NewGraphQLUnionType(types.GraphQLUnionTypeConfig{
+		Name:  "Pet",
+		Types: []*types.GraphQLObjectType{dogType, catType},
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return dogType
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"pet": &types.GraphQLFieldConfig{
+					Type: petUnion,
+				},
+				"named": &types.GraphQLFieldConfig{
+					Type: namedInterface,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	namedType := func(name string) *ast.NamedType {
+		return ast.NewNamedType(&ast.NamedType{Name: ast.NewName(&ast.Name{Value: name})})
+	}
+	type Test struct {
+		condition *ast.NamedType
+		expected  bool
+	}
+	tests := []Test{
+		Test{nil, true},
+		Test{namedType("Dog"), true},
+		Test{namedType("Named"), true},
+		Test{namedType("Pet"), true},
+		Test{namedType("Cat"), false},
+		Test{namedType("Unknown"), false},
+	}
+	for _, test := range tests {
+		if matches := types.DoesFragmentConditionMatch(schema, test.condition, dogType); matches != test.expected {
+			t.Fatalf("expected the condition %v to match Dog: %v, got: %v", test.condition, test.expected, matches)
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package types
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/chris-ramon/graphql-go/errors"
 	"github.com/chris-ramon/graphql-go/language/ast"
@@ -14,6 +18,7 @@ import (
 type GraphQLType interface {
 	GetName() string
 	GetDescription() string
+	// The type reference in the notation of the spec, e.g. `[Author!]!`.
 	String() string
 	GetError() error
 }
@@ -76,6 +81,34 @@ func IsOutputType(ttype GraphQLType) bool {
 	return false
 }
 
+// IsLeafType reports whether the named type of a type is a scalar or an enum,
+// the types of the values a query ends with.
+func IsLeafType(ttype GraphQLType) bool {
+	switch GetNamedType(ttype).(type) {
+	case *GraphQLScalarType, *GraphQLEnumType:
+		return true
+	}
+	return false
+}
+
+// IsCompositeType reports whether the named type of a type is an object, an
+// interface or a union, the types which may have a selection set.
+func IsCompositeType(ttype GraphQLType) bool {
+	switch GetNamedType(ttype).(type) {
+	case *GraphQLObjectType, *GraphQLInterfaceType, *GraphQLUnionType:
+		return true
+	}
+	return false
+}
+
+// GetNullableType returns the type without its NonNull wrapper.
+func GetNullableType(ttype GraphQLType) GraphQLType {
+	if nonNull, ok := ttype.(*GraphQLNonNull); ok {
+		return nonNull.OfType
+	}
+	return ttype
+}
+
 // These types may be used as output types as the result of fields.
 type GraphQLOutputType interface {
 	GetName() string
@@ -113,7 +146,10 @@ var _ GraphQLAbstractType = (*GraphQLUnionType)(nil)
 
 // These named types do not include modifiers like List or NonNull.
 type GraphQLNamedType interface {
+	GetName() string
+	GetDescription() string
 	String() string
+	GetError() error
 }
 
 var _ GraphQLNamedType = (*GraphQLScalarType)(nil)
@@ -123,6 +159,8 @@ var _ GraphQLNamedType = (*GraphQLUnionType)(nil)
 var _ GraphQLNamedType = (*GraphQLEnumType)(nil)
 var _ GraphQLNamedType = (*GraphQLInputObjectType)(nil)
 
+// GetNamedType returns the type without its List and NonNull wrappers, e.g.
+// `String` for `[String!]!`.
 func GetNamedType(ttype GraphQLType) GraphQLNamedType {
 	unmodifiedType := ttype
 	for {
@@ -157,8 +195,9 @@ func GetNamedType(ttype GraphQLType) GraphQLNamedType {
  *
  */
 type GraphQLScalarType struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	SpecifiedByURL string `json:"specifiedByURL"`
 
 	scalarConfig GraphQLScalarTypeConfig
 	err          error
@@ -166,12 +205,27 @@ type GraphQLScalarType struct {
 type SerializeFn func(value interface{}) interface{}
 type ParseValueFn func(value interface{}) interface{}
 type ParseLiteralFn func(valueAST ast.Value) interface{}
+
+// ParseLiteralWithVariablesFn parses a literal which may refer to the
+// variables of the operation, e.g. within an object literal.
+type ParseLiteralWithVariablesFn func(valueAST ast.Value, variables map[string]interface{}) interface{}
 type GraphQLScalarTypeConfig struct {
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	Serialize    SerializeFn
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// URL of the specification of the scalar's behavior, reported by
+	// introspection and the @specifiedBy directive.
+	SpecifiedByURL string `json:"specifiedByURL"`
+	Serialize      SerializeFn
+	// ParseValue and ParseLiteral return nil for a value which is not a
+	// value of the scalar. They may instead return, or panic with, an error
+	// telling why, e.g. `missing "@"`, which is added to the error reporting
+	// the value.
 	ParseValue   ParseValueFn
 	ParseLiteral ParseLiteralFn
+	// Parses the literals of the operations instead of ParseLiteral, with
+	// the variable values of the operation, e.g. to resolve the variables
+	// nested in an object literal.
+	ParseLiteralWithVariables ParseLiteralWithVariablesFn
 }
 
 func NewGraphQLScalarType(config GraphQLScalarTypeConfig) *GraphQLScalarType {
@@ -190,6 +244,7 @@ func NewGraphQLScalarType(config GraphQLScalarTypeConfig) *GraphQLScalarType {
 
 	st.Name = config.Name
 	st.Description = config.Description
+	st.SpecifiedByURL = config.SpecifiedByURL
 
 	err = invariant(
 		config.Serialize != nil,
@@ -233,6 +288,16 @@ func (st *GraphQLScalarType) ParseLiteral(valueAST ast.Value) interface{} {
 	}
 	return st.scalarConfig.ParseLiteral(valueAST)
 }
+
+// ParseLiteralWithVariables parses a literal of an operation with its
+// variable values, see GraphQLScalarTypeConfig.ParseLiteralWithVariables.
+// It falls back to ParseLiteral.
+func (st *GraphQLScalarType) ParseLiteralWithVariables(valueAST ast.Value, variables map[string]interface{}) interface{} {
+	if st.scalarConfig.ParseLiteralWithVariables == nil {
+		return st.ParseLiteral(valueAST)
+	}
+	return st.scalarConfig.ParseLiteralWithVariables(valueAST, variables)
+}
 func (st *GraphQLScalarType) GetName() string {
 	return st.Name
 }
@@ -288,24 +353,76 @@ type GraphQLObjectType struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	IsTypeOf    IsTypeOfFn
+	// Arbitrary metadata of the type, see GraphQLObjectTypeConfig.Extensions.
+	Extensions map[string]interface{} `json:"-"`
 
-	typeConfig GraphQLObjectTypeConfig
-	fields     GraphQLFieldDefinitionMap
-	interfaces []*GraphQLInterfaceType
+	typeConfig      GraphQLObjectTypeConfig
+	fieldConfigs    GraphQLFieldConfigMap
+	fieldConfigsErr error
+	fields          GraphQLFieldDefinitionMap
+	fieldsErr       error
+	interfaces      []*GraphQLInterfaceType
+	interfacesErr   error
+	// whether a schema was built from the type, see AddFieldConfig
+	built bool
 	// Interim alternative to throwing an error during schema definition at run-time
 	err error
+
+	// guards the memoized fields, types are shared by concurrent executions
+	mu sync.Mutex
 }
 
 type IsTypeOfFn func(value interface{}, info GraphQLResolveInfo) bool
 
+// GraphQLInterfacesThunk supplies the interfaces of an object type lazily, so
+// that they may refer to interfaces which are not defined yet. It is
+// evaluated once, when the interfaces are first needed, e.g. when a schema
+// is built. The interfaces do not list such an object type among their
+// possible types, the schema keeps track of it instead, see
+// GraphQLSchema.GetPossibleTypes, so it must be part of the schema's types.
 type GraphQLInterfacesThunk func() []*GraphQLInterfaceType
 
 type GraphQLObjectTypeConfig struct {
-	Name        string                `json:"description"`
-	Interfaces  interface{}           `json:"interfaces"`
-	Fields      GraphQLFieldConfigMap `json:"fields"`
-	IsTypeOf    IsTypeOfFn            `json:"isTypeOf"`
-	Description string                `json:"description"`
+	Name       string      `json:"description"`
+	Interfaces interface{} `json:"interfaces"`
+	// Either a GraphQLFieldConfigMap or a GraphQLFieldConfigMapThunk.
+	Fields      interface{} `json:"fields"`
+	IsTypeOf    IsTypeOfFn  `json:"isTypeOf"`
+	Description string      `json:"description"`
+	// Arbitrary metadata of the type, e.g. permission tags for a code
+	// generator or a middleware. It is carried onto the type but is not used
+	// by the execution.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// GraphQLFieldConfigMapThunk supplies the fields of an object type lazily, so
+// that the fields may refer to types which are not defined yet, including
+// the object type itself. It is evaluated once, when the fields are first
+// needed.
+type GraphQLFieldConfigMapThunk func() GraphQLFieldConfigMap
+
+// GraphQLOutputTypeThunk supplies the type of a field lazily, so that it may
+// refer to a type which is not defined yet, e.g. of mutually recursive types
+// defined by variables. It is evaluated once, when the fields of the type
+// are first needed, and the field definition has the type it returns, which
+// may be a wrapping type or an abstract type.
+type GraphQLOutputTypeThunk func() GraphQLOutputType
+
+var _ GraphQLOutputType = GraphQLOutputTypeThunk(nil)
+
+// The methods of GraphQLOutputType evaluate the thunk, they are only needed
+// to set it as the type of a field config.
+func (thunk GraphQLOutputTypeThunk) GetName() string {
+	return thunk().GetName()
+}
+func (thunk GraphQLOutputTypeThunk) GetDescription() string {
+	return thunk().GetDescription()
+}
+func (thunk GraphQLOutputTypeThunk) String() string {
+	return thunk().String()
+}
+func (thunk GraphQLOutputTypeThunk) GetError() error {
+	return thunk().GetError()
 }
 
 func NewGraphQLObjectType(config GraphQLObjectTypeConfig) *GraphQLObjectType {
@@ -325,6 +442,7 @@ func NewGraphQLObjectType(config GraphQLObjectTypeConfig) *GraphQLObjectType {
 	objectType.Name = config.Name
 	objectType.Description = config.Description
 	objectType.IsTypeOf = config.IsTypeOf
+	objectType.Extensions = config.Extensions
 	objectType.typeConfig = config
 
 	/*
@@ -334,6 +452,12 @@ func NewGraphQLObjectType(config GraphQLObjectTypeConfig) *GraphQLObjectType {
 		 	implementations, but avoids an expensive "getPossibleTypes"
 		 	implementation for Interface types.
 	*/
+	// An interfaces thunk might refer to interfaces which are not defined
+	// yet, it is evaluated by GetInterfaces and the schema keeps track of
+	// the implementations instead.
+	if _, ok := config.Interfaces.(GraphQLInterfacesThunk); ok {
+		return objectType
+	}
 	interfaces := objectType.GetInterfaces()
 	if interfaces == nil {
 		return objectType
@@ -344,12 +468,44 @@ func NewGraphQLObjectType(config GraphQLObjectTypeConfig) *GraphQLObjectType {
 
 	return objectType
 }
-func (gt *GraphQLObjectType) AddFieldConfig(fieldName string, fieldConfig *GraphQLFieldConfig) {
+
+// AddFieldConfig adds a field to the type, or replaces the field of the same
+// name, e.g. to define a field referring to a type which refers back to this
+// one. It may only be called while defining the types: once a schema is
+// built from the type, the type is immutable and an error is returned.
+// The field config map given in the type config is not modified.
+func (gt *GraphQLObjectType) AddFieldConfig(fieldName string, fieldConfig *GraphQLFieldConfig) error {
 	if fieldName == "" || fieldConfig == nil {
-		return
+		return nil
 	}
-	gt.typeConfig.Fields[fieldName] = fieldConfig
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	if gt.built {
+		return builtTypeError(gt, fieldName)
+	}
+	fieldConfigs, err := gt.getFieldConfigs()
+	if err != nil {
+		return err
+	}
+	gt.fieldConfigs = withFieldConfig(fieldConfigs, fieldName, fieldConfig)
+	gt.fields = nil
+	return nil
+}
 
+// Returns the error of adding a field to a type a schema was built from.
+func builtTypeError(ttype GraphQLNamedType, fieldName string) error {
+	return errors.New(fmt.Sprintf(`Cannot add the field "%v" to %v, a schema was built from it.`, fieldName, ttype))
+}
+
+// Returns a copy of the field configs with the given field config set, the
+// field definitions already handed out keep referring to the former configs.
+func withFieldConfig(fieldConfigs GraphQLFieldConfigMap, fieldName string, fieldConfig *GraphQLFieldConfig) GraphQLFieldConfigMap {
+	copied := GraphQLFieldConfigMap{}
+	for name, config := range fieldConfigs {
+		copied[name] = config
+	}
+	copied[fieldName] = fieldConfig
+	return copied
 }
 func (gt *GraphQLObjectType) GetName() string {
 	return gt.Name
@@ -361,26 +517,97 @@ func (gt *GraphQLObjectType) String() string {
 	return gt.Name
 }
 func (gt *GraphQLObjectType) GetFields() GraphQLFieldDefinitionMap {
-	fields, err := defineFieldMap(gt, gt.typeConfig.Fields)
-	gt.err = err
-	gt.fields = fields
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	if gt.fields == nil {
+		fieldConfigs, err := gt.getFieldConfigs()
+		if err == nil {
+			gt.fields, gt.fieldsErr = defineFieldMap(gt, fieldConfigs)
+		} else {
+			gt.fields, gt.fieldsErr = GraphQLFieldDefinitionMap{}, err
+		}
+	}
+	if gt.fieldsErr != nil {
+		gt.err = gt.fieldsErr
+	}
 	return gt.fields
 }
-func (gt *GraphQLObjectType) GetInterfaces() []*GraphQLInterfaceType {
-	var configInterfaces []*GraphQLInterfaceType
-	switch gt.typeConfig.Interfaces.(type) {
-	case GraphQLInterfacesThunk:
-		configInterfaces = gt.typeConfig.Interfaces.(GraphQLInterfacesThunk)()
-	case []*GraphQLInterfaceType:
-		configInterfaces = gt.typeConfig.Interfaces.([]*GraphQLInterfaceType)
+
+// GetField returns the definition of a field by name, nil if there is none.
+// The `__typename` meta-field is defined on every object type. The
+// `__schema` and `__type` meta-fields depend on the schema the type is the
+// query type of, so they are left to the executor and the validator.
+func (gt *GraphQLObjectType) GetField(name string) *GraphQLFieldDefinition {
+	if name == TypeNameMetaFieldDef.Name {
+		return TypeNameMetaFieldDef
+	}
+	return gt.GetFields()[name]
+}
+
+// Returns the field configs, evaluating the fields thunk on the first call.
+// Expects gt.mu to be held.
+func (gt *GraphQLObjectType) getFieldConfigs() (GraphQLFieldConfigMap, error) {
+	if gt.fieldConfigs != nil || gt.fieldConfigsErr != nil {
+		return gt.fieldConfigs, gt.fieldConfigsErr
+	}
+	switch fields := gt.typeConfig.Fields.(type) {
+	case GraphQLFieldConfigMap:
+		gt.fieldConfigs = fields
+	case GraphQLFieldConfigMapThunk:
+		gt.fieldConfigsErr = evaluateThunk(gt, "fields", func() {
+			gt.fieldConfigs = fields()
+		})
+		if gt.fieldConfigsErr != nil {
+			return nil, gt.fieldConfigsErr
+		}
 	case nil:
 	default:
-		gt.err = errors.New(fmt.Sprintf("Unknown GraphQLObjectType.Interfaces type: %v", reflect.TypeOf(gt.typeConfig.Interfaces)))
+		gt.fieldConfigsErr = errors.New(fmt.Sprintf("Unknown GraphQLObjectType.Fields type: %v", reflect.TypeOf(gt.typeConfig.Fields)))
+		return nil, gt.fieldConfigsErr
+	}
+	if gt.fieldConfigs == nil {
+		gt.fieldConfigs = GraphQLFieldConfigMap{}
+	}
+	return gt.fieldConfigs, nil
+}
+
+// Calls the thunk of the given kind, reporting a panic within it as an error.
+func evaluateThunk(ttype GraphQLNamedType, kind string, thunk func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(fmt.Sprintf("%v %v thunk failed: %v", ttype, kind, r))
+		}
+	}()
+	thunk()
+	return nil
+}
+
+// Returns the interfaces of the object type, evaluating the interfaces thunk
+// on the first call.
+func (gt *GraphQLObjectType) GetInterfaces() []*GraphQLInterfaceType {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	if gt.interfaces == nil && gt.interfacesErr == nil {
+		var configInterfaces []*GraphQLInterfaceType
+		switch interfaces := gt.typeConfig.Interfaces.(type) {
+		case GraphQLInterfacesThunk:
+			gt.interfacesErr = evaluateThunk(gt, "interfaces", func() {
+				configInterfaces = interfaces()
+			})
+		case []*GraphQLInterfaceType:
+			configInterfaces = interfaces
+		case nil:
+		default:
+			gt.interfacesErr = errors.New(fmt.Sprintf("Unknown GraphQLObjectType.Interfaces type: %v", reflect.TypeOf(gt.typeConfig.Interfaces)))
+		}
+		if gt.interfacesErr == nil {
+			gt.interfaces, gt.interfacesErr = defineInterfaces(gt, configInterfaces)
+		}
+	}
+	if gt.interfacesErr != nil {
+		gt.err = gt.interfacesErr
 		return nil
 	}
-	interfaces, err := defineInterfaces(gt, configInterfaces)
-	gt.err = err
-	gt.interfaces = interfaces
 	return gt.interfaces
 }
 func (gt *GraphQLObjectType) GetError() error {
@@ -435,15 +662,24 @@ func defineFieldMap(ttype GraphQLNamedType, fields GraphQLFieldConfigMap) (Graph
 		if field == nil {
 			continue
 		}
+		fieldType := field.Type
+		if thunk, ok := fieldType.(GraphQLOutputTypeThunk); ok {
+			err = evaluateThunk(ttype, fmt.Sprintf("%v field type", fieldName), func() {
+				fieldType = thunk()
+			})
+			if err != nil {
+				return resultFieldMap, err
+			}
+		}
 		err = invariant(
-			field.Type != nil,
-			fmt.Sprintf(`%v.%v field type must be Output Type but got: %v.`, ttype, fieldName, field.Type),
+			fieldType != nil,
+			fmt.Sprintf(`%v.%v field type must be Output Type but got: %v.`, ttype, fieldName, fieldType),
 		)
 		if err != nil {
 			return resultFieldMap, err
 		}
-		if field.Type.GetError() != nil {
-			return resultFieldMap, field.Type.GetError()
+		if fieldType.GetError() != nil {
+			return resultFieldMap, fieldType.GetError()
 		}
 		err = assertValidName(fieldName)
 		if err != nil {
@@ -452,9 +688,14 @@ func defineFieldMap(ttype GraphQLNamedType, fields GraphQLFieldConfigMap) (Graph
 		fieldDef := &GraphQLFieldDefinition{
 			Name:              fieldName,
 			Description:       field.Description,
-			Type:              field.Type,
+			Type:              fieldType,
 			Resolve:           field.Resolve,
+			Subscribe:         field.Subscribe,
 			DeprecationReason: field.DeprecationReason,
+			Cache:             field.Cache,
+			Complexity:        field.Complexity,
+			MaxListSize:       field.MaxListSize,
+			Extensions:        field.Extensions,
 		}
 
 		fieldDef.Args = []*GraphQLArgument{}
@@ -482,9 +723,31 @@ func defineFieldMap(ttype GraphQLNamedType, fields GraphQLFieldConfigMap) (Graph
 				Description:  arg.Description,
 				Type:         arg.Type,
 				DefaultValue: arg.DefaultValue,
+				Extensions:   arg.Extensions,
 			}
 			fieldDef.Args = append(fieldDef.Args, fieldArg)
 		}
+		// the arguments are ordered as declared by ArgOrder, the others by
+		// name, so that they are introspected and bound to the parameters
+		// of a method in the same order each time
+		positions := map[string]int{}
+		for i, argName := range field.ArgOrder {
+			err = invariant(
+				field.Args[argName] != nil,
+				fmt.Sprintf(`%v.%v argument order names "%v", which is not an argument of the field.`, ttype, fieldName, argName),
+			)
+			if err != nil {
+				return resultFieldMap, err
+			}
+			positions[argName] = i + 1
+		}
+		sort.Slice(fieldDef.Args, func(i, j int) bool {
+			pi, pj := positions[fieldDef.Args[i].Name], positions[fieldDef.Args[j].Name]
+			if pi != pj {
+				return pi != 0 && (pj == 0 || pi < pj)
+			}
+			return fieldDef.Args[i].Name < fieldDef.Args[j].Name
+		})
 		resultFieldMap[fieldName] = fieldDef
 	}
 	return resultFieldMap, nil
@@ -492,17 +755,48 @@ func defineFieldMap(ttype GraphQLNamedType, fields GraphQLFieldConfigMap) (Graph
 
 // TODO: clean up GQLFRParams fields
 type GQLFRParams struct {
-	Source interface{}
-	Args   map[string]interface{}
-	Info   GraphQLResolveInfo
-	Schema GraphQLSchema
+	Source  interface{}
+	Args    map[string]interface{}
+	Info    GraphQLResolveInfo
+	Schema  GraphQLSchema
+	Context context.Context
 }
 
 // TODO: relook at GraphQLFieldResolveFn params
 type GraphQLFieldResolveFn func(p GQLFRParams) interface{}
 
+// GraphQLFieldSubscribeFn returns the source stream of events of a
+// subscription field, each event is then resolved to the field's value by the
+// field's resolve function with the event as p.Source.
+type GraphQLFieldSubscribeFn func(p GQLFRParams) interface{}
+
+// skipEvent is the type of SkipEvent, its pointer identity is what matters.
+type skipEvent struct{}
+
+// SkipEvent is returned by the resolve function of a subscription field to
+// filter out the event being resolved, no result is emitted for it.
+var SkipEvent = &skipEvent{}
+
+// CompletedValue wraps a value that a resolve function has already fully
+// completed (e.g. a cached subtree). The executor uses it verbatim as the
+// field's output and skips resolving its sub-fields, so its shape must
+// match the selection.
+type CompletedValue struct {
+	Value interface{}
+}
+
+func CompleteValue(value interface{}) *CompletedValue {
+	return &CompletedValue{
+		Value: value,
+	}
+}
+
 type GraphQLResolveInfo struct {
-	FieldName      string
+	FieldName string
+	// The path of the field in the response, the response names of the
+	// fields, aliases included, and the indices of the list items, e.g.
+	// `[]interface{}{"feed", 0, "author"}`.
+	Path           []interface{}
 	FieldASTs      []*ast.Field
 	ReturnType     GraphQLOutputType
 	ParentType     GraphQLCompositeType
@@ -516,12 +810,58 @@ type GraphQLResolveInfo struct {
 type GraphQLFieldConfigMap map[string]*GraphQLFieldConfig
 
 type GraphQLFieldConfig struct {
-	Name              string                        `json:"name"` // used by graphlql-relay
-	Type              GraphQLOutputType             `json:"type"`
-	Args              GraphQLFieldConfigArgumentMap `json:"args"`
+	Name string                        `json:"name"` // used by graphlql-relay
+	Type GraphQLOutputType             `json:"type"`
+	Args GraphQLFieldConfigArgumentMap `json:"args"`
+	// Names of the arguments in the order of their declaration, e.g. the
+	// order of the parameters of the method resolving the field, see
+	// Resolve. The arguments it leaves out follow, ordered by name.
+	ArgOrder []string `json:"-"`
+	// Resolves the value of the field. Without it, the value is the struct
+	// field of the source matching the field's name or json tag, the entry
+	// of a map source, or the value returned by the method of the source
+	// named after the field, its first letter upper-cased, e.g. `Pic` for
+	// `pic`. The arguments of the field are bound by name to the fields of
+	// a single struct parameter of the method, or else by position to its
+	// parameters, in the order of ArgOrder. The method may take a first
+	// context.Context parameter and may return an error after the value.
+	// A list field may resolve to a channel, whose items are completed as
+	// they are received until it is closed: the goroutine sending them must
+	// stop and close it once the context of p is done.
 	Resolve           GraphQLFieldResolveFn
+	Subscribe         GraphQLFieldSubscribeFn
 	DeprecationReason string `json:"deprecationReason"`
 	Description       string `json:"description"`
+	// Caches the resolved values of the field across executions, using the
+	// cache of the execution.
+	Cache *GraphQLFieldCacheConfig `json:"-"`
+	// Estimates the cost of resolving the field, given its arguments and the
+	// estimated cost of its sub-fields. Defaults to 1 plus the cost of the
+	// sub-fields, multiplied by the `first` or `limit` argument of a list.
+	Complexity ComplexityFn `json:"-"`
+	// Maximum number of items of the lists resolved for the field, overrides
+	// the limit of the execution, see executor.ExecuteOptions.MaxListSize.
+	MaxListSize int `json:"-"`
+	// Arbitrary metadata of the field, e.g. a cache hint, carried onto its
+	// definition, see GraphQLResolveInfo.FieldDefinition. It is not used by
+	// the execution.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// ComplexityFn estimates the cost of resolving a field, see
+// GraphQLFieldConfig.Complexity.
+type ComplexityFn func(args map[string]interface{}, childComplexity int) int
+
+// GraphQLFieldCacheConfig configures the caching of the resolved values of a
+// field. Values are cached by the field's parent type and name, and by the
+// key returned by KeyFn, which defaults to the field's arguments for the
+// fields of the operation's root type. The value of another field depends on
+// its source too, it is only cached with a KeyFn identifying the source.
+// Errors are not cached.
+type GraphQLFieldCacheConfig struct {
+	// How long a value is cached, zero caches it until the cache evicts it.
+	TTL   time.Duration
+	KeyFn func(p GQLFRParams) string
 }
 
 type GraphQLFieldConfigArgumentMap map[string]*GraphQLArgumentConfig
@@ -530,16 +870,23 @@ type GraphQLArgumentConfig struct {
 	Type         GraphQLInputType `json:"type"`
 	DefaultValue interface{}      `json:"defaultValue"`
 	Description  string           `json:"description"`
+	// Arbitrary metadata of the argument, carried onto its definition.
+	Extensions map[string]interface{} `json:"-"`
 }
 
 type GraphQLFieldDefinitionMap map[string]*GraphQLFieldDefinition
 type GraphQLFieldDefinition struct {
-	Name              string                `json:"name"`
-	Description       string                `json:"description"`
-	Type              GraphQLOutputType     `json:"type"`
-	Args              []*GraphQLArgument    `json:"args"`
-	Resolve           GraphQLFieldResolveFn `json:"-"`
-	DeprecationReason string                `json:"deprecationReason"`
+	Name              string                   `json:"name"`
+	Description       string                   `json:"description"`
+	Type              GraphQLOutputType        `json:"type"`
+	Args              []*GraphQLArgument       `json:"args"`
+	Resolve           GraphQLFieldResolveFn    `json:"-"`
+	Subscribe         GraphQLFieldSubscribeFn  `json:"-"`
+	DeprecationReason string                   `json:"deprecationReason"`
+	Cache             *GraphQLFieldCacheConfig `json:"-"`
+	Complexity        ComplexityFn             `json:"-"`
+	MaxListSize       int                      `json:"-"`
+	Extensions        map[string]interface{}   `json:"-"`
 }
 
 type GraphQLFieldArgument struct {
@@ -550,10 +897,11 @@ type GraphQLFieldArgument struct {
 }
 
 type GraphQLArgument struct {
-	Name         string           `json:"name"`
-	Type         GraphQLInputType `json:"type"`
-	DefaultValue interface{}      `json:"defaultValue"`
-	Description  string           `json:"description"`
+	Name         string                 `json:"name"`
+	Type         GraphQLInputType       `json:"type"`
+	DefaultValue interface{}            `json:"defaultValue"`
+	Description  string                 `json:"description"`
+	Extensions   map[string]interface{} `json:"-"`
 }
 
 func (st *GraphQLArgument) GetName() string {
@@ -597,11 +945,19 @@ type GraphQLInterfaceType struct {
 	fields          GraphQLFieldDefinitionMap
 	implementations []*GraphQLObjectType
 	possibleTypes   map[string]bool
+	// whether a schema was built from the type, see AddFieldConfig
+	built bool
 
 	err error
+
+	// guards the memoized fields and possible types
+	mu sync.Mutex
 }
 type GraphQLInterfaceTypeConfig struct {
-	Name        string                `json:"name"`
+	Name string `json:"name"`
+	// The fields shared by the implementations. A field selected on the
+	// interface is resolved by the field of the runtime object type, so the
+	// Resolve functions of these fields are not used.
 	Fields      GraphQLFieldConfigMap `json:"fields"`
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
@@ -630,11 +986,20 @@ func NewGraphQLInterfaceType(config GraphQLInterfaceTypeConfig) *GraphQLInterfac
 	return it
 }
 
-func (it *GraphQLInterfaceType) AddFieldConfig(fieldName string, fieldConfig *GraphQLFieldConfig) {
+// AddFieldConfig adds a field to the interface, see
+// GraphQLObjectType.AddFieldConfig for when it may be called.
+func (it *GraphQLInterfaceType) AddFieldConfig(fieldName string, fieldConfig *GraphQLFieldConfig) error {
 	if fieldName == "" || fieldConfig == nil {
-		return
+		return nil
 	}
-	it.typeConfig.Fields[fieldName] = fieldConfig
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.built {
+		return builtTypeError(it, fieldName)
+	}
+	it.typeConfig.Fields = withFieldConfig(it.typeConfig.Fields, fieldName, fieldConfig)
+	it.fields = nil
+	return nil
 }
 func (it *GraphQLInterfaceType) GetName() string {
 	return it.Name
@@ -643,7 +1008,11 @@ func (it *GraphQLInterfaceType) GetDescription() string {
 	return it.Description
 }
 func (it *GraphQLInterfaceType) GetFields() (fields GraphQLFieldDefinitionMap) {
-	it.fields, it.err = defineFieldMap(it, it.typeConfig.Fields)
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.fields == nil {
+		it.fields, it.err = defineFieldMap(it, it.typeConfig.Fields)
+	}
 	return it.fields
 }
 func (it *GraphQLInterfaceType) GetPossibleTypes() []*GraphQLObjectType {
@@ -653,6 +1022,8 @@ func (it *GraphQLInterfaceType) IsPossibleType(ttype *GraphQLObjectType) bool {
 	if ttype == nil {
 		return false
 	}
+	it.mu.Lock()
+	defer it.mu.Unlock()
 	if len(it.possibleTypes) == 0 {
 		possibleTypes := map[string]bool{}
 		for _, possibleType := range it.GetPossibleTypes() {
@@ -682,7 +1053,11 @@ func (it *GraphQLInterfaceType) GetError() error {
 }
 
 func getTypeOf(value interface{}, info GraphQLResolveInfo, abstractType GraphQLAbstractType) *GraphQLObjectType {
-	possibleTypes := abstractType.GetPossibleTypes()
+	// the schema knows of the implementations defined with an interfaces thunk
+	possibleTypes := info.Schema.GetPossibleTypes(abstractType.(GraphQLType))
+	if info.Schema.implementations == nil {
+		possibleTypes = abstractType.GetPossibleTypes()
+	}
 	for _, possibleType := range possibleTypes {
 		if possibleType.IsTypeOf == nil {
 			continue
@@ -727,14 +1102,24 @@ type GraphQLUnionType struct {
 	possibleTypes map[string]bool
 
 	err error
+
+	// guards the memoized possible types
+	mu sync.Mutex
 }
 type GraphQLUnionTypeConfig struct {
-	Name        string               `json:"name"`
-	Types       []*GraphQLObjectType `json:"types"`
+	Name string `json:"name"`
+	// Either a []*GraphQLObjectType or a GraphQLUnionTypesThunk.
+	Types       interface{} `json:"types"`
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
 }
 
+// GraphQLUnionTypesThunk supplies the possible types of a union lazily, so
+// that they may refer to types which are not defined yet, including types
+// with fields of the union itself. It is evaluated once, when the possible
+// types are first needed.
+type GraphQLUnionTypesThunk func() []*GraphQLObjectType
+
 func NewGraphQLUnionType(config GraphQLUnionTypeConfig) *GraphQLUnionType {
 	objectType := &GraphQLUnionType{}
 
@@ -751,54 +1136,92 @@ func NewGraphQLUnionType(config GraphQLUnionTypeConfig) *GraphQLUnionType {
 	objectType.Name = config.Name
 	objectType.Description = config.Description
 	objectType.ResolveType = config.ResolveType
+	objectType.typeConfig = config
 
-	err = invariant(
-		len(config.Types) > 0,
-		fmt.Sprintf(`Must provide Array of types for Union %v.`, config.Name),
+	if _, ok := config.Types.(GraphQLUnionTypesThunk); !ok {
+		objectType.GetPossibleTypes()
+	}
+
+	return objectType
+}
+
+// Returns the possible types of the union, evaluating the types thunk on the
+// first call.
+func (ut *GraphQLUnionType) GetPossibleTypes() []*GraphQLObjectType {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	return ut.getPossibleTypes()
+}
+
+// Expects ut.mu to be held.
+func (ut *GraphQLUnionType) getPossibleTypes() []*GraphQLObjectType {
+	if ut.types != nil || ut.err != nil {
+		return ut.types
+	}
+	var configTypes []*GraphQLObjectType
+	switch types := ut.typeConfig.Types.(type) {
+	case GraphQLUnionTypesThunk:
+		ut.err = evaluateThunk(ut, "types", func() {
+			configTypes = types()
+		})
+		if ut.err != nil {
+			return nil
+		}
+	case []*GraphQLObjectType:
+		configTypes = types
+	case nil:
+	default:
+		ut.err = errors.New(fmt.Sprintf("Unknown GraphQLUnionType.Types type: %v", reflect.TypeOf(ut.typeConfig.Types)))
+		return nil
+	}
+	ut.types, ut.err = defineUnionTypes(ut, configTypes)
+	if ut.err != nil {
+		ut.types = nil
+	}
+	return ut.types
+}
+
+func defineUnionTypes(ut *GraphQLUnionType, types []*GraphQLObjectType) ([]*GraphQLObjectType, error) {
+	err := invariant(
+		len(types) > 0,
+		fmt.Sprintf(`Must provide Array of types for Union %v.`, ut.Name),
 	)
 	if err != nil {
-		objectType.err = err
-		return objectType
+		return nil, err
 	}
-	for _, ttype := range config.Types {
+	for _, ttype := range types {
 		err := invariant(
 			ttype != nil,
-			fmt.Sprintf(`%v may only contain Object types, it cannot contain: %v.`, objectType, ttype),
+			fmt.Sprintf(`%v may only contain Object types, it cannot contain: %v.`, ut, ttype),
 		)
 		if err != nil {
-			objectType.err = err
-			return objectType
+			return nil, err
 		}
-		if objectType.ResolveType == nil {
+		if ut.ResolveType == nil {
 			err = invariant(
 				ttype.IsTypeOf != nil,
 				fmt.Sprintf(`Union Type %v does not provide a "resolveType" function `+
 					`and possible Type %v does not provide a "isTypeOf" `+
 					`function. There is no way to resolve this possible type `+
-					`during execution.`, objectType, ttype),
+					`during execution.`, ut, ttype),
 			)
 			if err != nil {
-				objectType.err = err
-				return objectType
+				return nil, err
 			}
 		}
 	}
-	objectType.types = config.Types
-	objectType.typeConfig = config
-
-	return objectType
-}
-func (ut *GraphQLUnionType) GetPossibleTypes() []*GraphQLObjectType {
-	return ut.types
+	return types, nil
 }
 func (ut *GraphQLUnionType) IsPossibleType(ttype *GraphQLObjectType) bool {
 
 	if ttype == nil {
 		return false
 	}
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
 	if len(ut.possibleTypes) == 0 {
 		possibleTypes := map[string]bool{}
-		for _, possibleType := range ut.GetPossibleTypes() {
+		for _, possibleType := range ut.getPossibleTypes() {
 			if possibleType == nil {
 				continue
 			}
@@ -862,9 +1285,15 @@ type GraphQLEnumType struct {
 	nameLookup   map[string]*GraphQLEnumValueDefinition
 
 	err error
+
+	// guards the memoized lookups
+	mu sync.Mutex
 }
 type GraphQLEnumValueConfigMap map[string]*GraphQLEnumValueConfig
 type GraphQLEnumValueConfig struct {
+	// Internal value of the enum value, defaults to its name. Arguments
+	// receive it as is, e.g. a typed constant usable as a map key, and
+	// results serialize it back to the name.
 	Value             interface{} `json:"value"`
 	DeprecationReason string      `json:"deprecationReason"`
 	Description       string      `json:"description"`
@@ -942,9 +1371,17 @@ func (gt *GraphQLEnumType) GetValues() []*GraphQLEnumValueDefinition {
 	return gt.values
 }
 func (gt *GraphQLEnumType) Serialize(value interface{}) interface{} {
+	// values which cannot be internal values, e.g. slices, cannot be looked up
+	if value == nil || !reflect.TypeOf(value).Comparable() {
+		return nil
+	}
 	if enumValue, ok := gt.getValueLookup()[value]; ok {
 		return enumValue.Name
 	}
+	// a pointer to an internal value, e.g. an item of a []*Direction
+	if valueVal := reflect.ValueOf(value); valueVal.Kind() == reflect.Ptr && !valueVal.IsNil() {
+		return gt.Serialize(valueVal.Elem().Interface())
+	}
 	return nil
 }
 func (gt *GraphQLEnumType) ParseValue(value interface{}) interface{} {
@@ -978,6 +1415,8 @@ func (gt *GraphQLEnumType) GetError() error {
 	return gt.err
 }
 func (gt *GraphQLEnumType) getValueLookup() map[interface{}]*GraphQLEnumValueDefinition {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
 	if len(gt.valuesLookup) > 0 {
 		return gt.valuesLookup
 	}
@@ -990,6 +1429,8 @@ func (gt *GraphQLEnumType) getValueLookup() map[interface{}]*GraphQLEnumValueDef
 }
 
 func (gt *GraphQLEnumType) getNameLookup() map[string]*GraphQLEnumValueDefinition {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
 	if len(gt.nameLookup) > 0 {
 		return gt.nameLookup
 	}
@@ -1026,7 +1467,10 @@ type GraphQLInputObjectType struct {
 	Description string `json:"description"`
 
 	typeConfig InputObjectConfig
-	fields     InputObjectFieldMap
+
+	mu        sync.Mutex
+	fields    InputObjectFieldMap
+	fieldsErr error
 
 	err error
 }
@@ -1077,17 +1521,27 @@ func NewGraphQLInputObjectType(config InputObjectConfig) *GraphQLInputObjectType
 	gt.Name = config.Name
 	gt.Description = config.Description
 	gt.typeConfig = config
-	gt.fields = gt.defineFieldMap()
+	if _, ok := config.Fields.(InputObjectConfigFieldMapThunk); !ok {
+		gt.GetFields()
+	}
 	return gt
 }
 
-func (gt *GraphQLInputObjectType) defineFieldMap() InputObjectFieldMap {
+// Defines the fields, evaluating the fields thunk, which may thus reference
+// input objects defined after this one, e.g. to define recursive input
+// objects.
+func (gt *GraphQLInputObjectType) defineFieldMap() (InputObjectFieldMap, error) {
 	var fieldMap InputObjectConfigFieldMap
-	switch gt.typeConfig.Fields.(type) {
+	switch fields := gt.typeConfig.Fields.(type) {
 	case InputObjectConfigFieldMap:
-		fieldMap = gt.typeConfig.Fields.(InputObjectConfigFieldMap)
+		fieldMap = fields
 	case InputObjectConfigFieldMapThunk:
-		fieldMap = gt.typeConfig.Fields.(InputObjectConfigFieldMapThunk)()
+		err := evaluateThunk(gt, "fields", func() {
+			fieldMap = fields()
+		})
+		if err != nil {
+			return InputObjectFieldMap{}, err
+		}
 	}
 	resultFieldMap := InputObjectFieldMap{}
 
@@ -1096,8 +1550,7 @@ func (gt *GraphQLInputObjectType) defineFieldMap() InputObjectFieldMap {
 		fmt.Sprintf(`%v fields must be an object with field names as keys or a function which return such an object.`, gt),
 	)
 	if err != nil {
-		gt.err = err
-		return resultFieldMap
+		return resultFieldMap, err
 	}
 
 	for fieldName, fieldConfig := range fieldMap {
@@ -1113,8 +1566,7 @@ func (gt *GraphQLInputObjectType) defineFieldMap() InputObjectFieldMap {
 			fmt.Sprintf(`%v.%v field type must be Input Type but got: %v.`, gt, fieldName, fieldConfig.Type),
 		)
 		if err != nil {
-			gt.err = err
-			return resultFieldMap
+			return resultFieldMap, err
 		}
 		field := &InputObjectField{}
 		field.Name = fieldName
@@ -1123,9 +1575,17 @@ func (gt *GraphQLInputObjectType) defineFieldMap() InputObjectFieldMap {
 		field.DefaultValue = fieldConfig.DefaultValue
 		resultFieldMap[fieldName] = field
 	}
-	return resultFieldMap
+	return resultFieldMap, nil
 }
 func (gt *GraphQLInputObjectType) GetFields() InputObjectFieldMap {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	if gt.fields == nil {
+		gt.fields, gt.fieldsErr = gt.defineFieldMap()
+	}
+	if gt.fieldsErr != nil {
+		gt.err = gt.fieldsErr
+	}
 	return gt.fields
 }
 func (gt *GraphQLInputObjectType) GetName() string {
@@ -1177,6 +1637,16 @@ func NewGraphQLList(ofType GraphQLType) *GraphQLList {
 	gl.OfType = ofType
 	return gl
 }
+
+// NewInternedGraphQLList returns the list of ofType shared by the callers,
+// created on the first call, so that the lists of the same type are the same
+// pointer and are not allocated again. The list must not be modified,
+// NewGraphQLList creates a list of its own. The lists are kept for the
+// lifetime of the program, so ofType should be a type of a long-lived schema.
+func NewInternedGraphQLList(ofType GraphQLType) *GraphQLList {
+	return internedWrappers.list(ofType)
+}
+
 func (gl *GraphQLList) GetName() string {
 	return fmt.Sprintf("%v", gl.OfType)
 }
@@ -1232,6 +1702,52 @@ func NewGraphQLNonNull(ofType GraphQLType) *GraphQLNonNull {
 	gl.OfType = ofType
 	return gl
 }
+
+// NewInternedGraphQLNonNull returns the non-null type of ofType shared by the
+// callers, as NewInternedGraphQLList does for lists.
+func NewInternedGraphQLNonNull(ofType GraphQLType) *GraphQLNonNull {
+	return internedWrappers.nonNull(ofType)
+}
+
+// The wrapping types created by NewInternedGraphQLList and
+// NewInternedGraphQLNonNull.
+var internedWrappers wrapperCache
+
+// Memoizes the lists and non-null types of the types, by the wrapped type.
+type wrapperCache struct {
+	lists    sync.Map
+	nonNulls sync.Map
+}
+
+func (c *wrapperCache) list(ofType GraphQLType) *GraphQLList {
+	if !isInternable(ofType) {
+		return NewGraphQLList(ofType)
+	}
+	if gl, ok := c.lists.Load(ofType); ok {
+		return gl.(*GraphQLList)
+	}
+	gl, _ := c.lists.LoadOrStore(ofType, NewGraphQLList(ofType))
+	return gl.(*GraphQLList)
+}
+
+func (c *wrapperCache) nonNull(ofType GraphQLType) *GraphQLNonNull {
+	if _, isOfTypeNonNull := ofType.(*GraphQLNonNull); isOfTypeNonNull || !isInternable(ofType) {
+		return NewGraphQLNonNull(ofType)
+	}
+	if gl, ok := c.nonNulls.Load(ofType); ok {
+		return gl.(*GraphQLNonNull)
+	}
+	gl, _ := c.nonNulls.LoadOrStore(ofType, NewGraphQLNonNull(ofType))
+	return gl.(*GraphQLNonNull)
+}
+
+// Reports whether a type can key the memoized wrappers, the types being
+// pointers but for custom implementations of GraphQLType. Invalid wrappers,
+// e.g. of nil, are created each time.
+func isInternable(ttype GraphQLType) bool {
+	return ttype != nil && reflect.TypeOf(ttype).Kind() == reflect.Ptr
+}
+
 func (gl *GraphQLNonNull) GetName() string {
 	return fmt.Sprintf("%v!", gl.OfType)
 }
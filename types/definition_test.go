@@ -92,6 +92,20 @@ var blogMutation = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
 	},
 })
 
+var blogSubscription = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Subscription",
+	Fields: types.GraphQLFieldConfigMap{
+		"articleSubscribe": &types.GraphQLFieldConfig{
+			Args: types.GraphQLFieldConfigArgumentMap{
+				"id": &types.GraphQLArgumentConfig{
+					Type: types.GraphQLString,
+				},
+			},
+			Type: blogArticle,
+		},
+	},
+})
+
 var objectType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
 	Name: "Object",
 	IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
@@ -227,6 +241,34 @@ func TestTypeSystem_DefinitionExample_DefinesAMutationScheme(t *testing.T) {
 	}
 }
 
+func TestTypeSystem_DefinitionExample_DefinesASubscriptionScheme(t *testing.T) {
+	blogSchema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query:        blogQuery,
+		Subscription: blogSubscription,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	if blogSchema.GetSubscriptionType() != blogSubscription {
+		t.Fatalf("expected blogSchema.GetSubscriptionType() == blogSubscription")
+	}
+
+	subscription, _ := blogSubscription.GetFields()["articleSubscribe"]
+	if subscription == nil {
+		t.Fatalf("subscription is nil")
+	}
+	if subscription.Type != blogArticle {
+		t.Fatalf("subscription.Type expected to equal blogArticle, got: %v", subscription.Type)
+	}
+	if subscription.Name != "articleSubscribe" {
+		t.Fatalf("subscription.Name expected to equal `articleSubscribe`, got: %v", subscription.Name)
+	}
+	if blogSchema.GetType("Subscription") != blogSubscription {
+		t.Fatalf("expected the type map to include the subscription type")
+	}
+}
+
 func TestTypeSystem_DefinitionExample_IncludesNestedInputObjectsInTheMap(t *testing.T) {
 	nestedInputObject := types.NewGraphQLInputObjectType(types.InputObjectConfig{
 		Name: "NestedInputObject",
@@ -344,6 +386,8 @@ func TestTypeSystem_DefinitionExample_IncludesInterfacesThunkSubtypesInTheTypeMa
 				},
 			},
 		}),
+		// the interfaces thunk is not evaluated before the schema is built
+		Types: []types.GraphQLType{someSubType},
 	})
 	if err != nil {
 		t.Fatalf("unexpected error, got: %v", err)
@@ -351,6 +395,282 @@ func TestTypeSystem_DefinitionExample_IncludesInterfacesThunkSubtypesInTheTypeMa
 	if schema.GetType("SomeSubtype") != someSubType {
 		t.Fatalf(`schema.GetType("SomeSubtype") expected to equal someSubType, got: %v`, schema.GetType("SomeSubtype"))
 	}
+	possibleTypes := schema.GetPossibleTypes(someInterface)
+	if len(possibleTypes) != 1 || possibleTypes[0] != someSubType {
+		t.Fatalf(`schema.GetPossibleTypes(someInterface) expected to equal [someSubType], got: %v`, possibleTypes)
+	}
+}
+
+func TestTypeSystem_DefinitionExample_SchemaKnowsPossibleTypesOfAbstractTypes(t *testing.T) {
+
+	someInterface := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "SomeInterface",
+		Fields: types.GraphQLFieldConfigMap{
+			"f": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+			},
+		},
+	})
+	someSubType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "SomeSubtype",
+		Fields: types.GraphQLFieldConfigMap{
+			"f": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+			},
+		},
+		Interfaces: []*types.GraphQLInterfaceType{someInterface},
+		IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
+			return true
+		},
+	})
+	someUnion := types.NewGraphQLUnionType(types.GraphQLUnionTypeConfig{
+		Name:  "SomeUnion",
+		Types: []*types.GraphQLObjectType{someSubType},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"iface": &types.GraphQLFieldConfig{
+					Type: someInterface,
+				},
+				"union": &types.GraphQLFieldConfig{
+					Type: someUnion,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	expected := []*types.GraphQLObjectType{someSubType}
+	if !reflect.DeepEqual(schema.GetPossibleTypes(someInterface), expected) {
+		t.Fatalf(`expected possible types of SomeInterface to equal %v, got: %v`, expected, schema.GetPossibleTypes(someInterface))
+	}
+	if !reflect.DeepEqual(schema.GetPossibleTypes(someUnion), expected) {
+		t.Fatalf(`expected possible types of SomeUnion to equal %v, got: %v`, expected, schema.GetPossibleTypes(someUnion))
+	}
+	if !schema.IsPossibleType(someInterface, someSubType) {
+		t.Fatalf(`expected SomeSubtype to be a possible type of SomeInterface`)
+	}
+	if !schema.IsPossibleType(someUnion, someSubType) {
+		t.Fatalf(`expected SomeSubtype to be a possible type of SomeUnion`)
+	}
+	if schema.IsPossibleType(someInterface, schema.GetQueryType()) {
+		t.Fatalf(`expected Query not to be a possible type of SomeInterface`)
+	}
+}
+
+func TestTypeSystem_DefinitionExample_DefinesMutuallyRecursiveTypesWithFieldsThunks(t *testing.T) {
+
+	var authorType, articleType *types.GraphQLObjectType
+	thunkCalls := 0
+	authorType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Author",
+		Fields: (types.GraphQLFieldConfigMapThunk)(func() types.GraphQLFieldConfigMap {
+			thunkCalls++
+			return types.GraphQLFieldConfigMap{
+				"name": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+				},
+				"recentArticle": &types.GraphQLFieldConfig{
+					Type: articleType,
+				},
+			}
+		}),
+	})
+	articleType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: (types.GraphQLFieldConfigMapThunk)(func() types.GraphQLFieldConfigMap {
+			return types.GraphQLFieldConfigMap{
+				"author": &types.GraphQLFieldConfig{
+					Type: authorType,
+				},
+			}
+		}),
+	})
+	if err := authorType.AddFieldConfig("id", &types.GraphQLFieldConfig{
+		Type: types.GraphQLID,
+	}); err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if authorType.GetFields()["id"] == nil {
+		t.Fatalf("Author.id expected to be added to the fields of the thunk")
+	}
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: articleType,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if schema.GetType("Author") != authorType {
+		t.Fatalf(`schema.GetType("Author") expected to equal authorType, got: %v`, schema.GetType("Author"))
+	}
+	if authorType.GetFields()["recentArticle"].Type != articleType {
+		t.Fatalf("Author.recentArticle expected to be of type Article, got: %v", authorType.GetFields()["recentArticle"].Type)
+	}
+	if thunkCalls != 1 {
+		t.Fatalf("fields thunk expected to be evaluated once, got: %v", thunkCalls)
+	}
+}
+
+func TestTypeSystem_DefinitionExample_ReportsErrorsWithinFieldsThunks(t *testing.T) {
+
+	brokenType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Broken",
+		Fields: (types.GraphQLFieldConfigMapThunk)(func() types.GraphQLFieldConfigMap {
+			panic("no fields for you")
+		}),
+	})
+	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"broken": &types.GraphQLFieldConfig{
+					Type: brokenType,
+				},
+			},
+		}),
+	})
+	expected := "Broken fields thunk failed: no fields for you"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got: %v", expected, err)
+	}
+	if brokenType.GetError() == nil || brokenType.GetError().Error() != expected {
+		t.Fatalf("expected type error %q, got: %v", expected, brokenType.GetError())
+	}
+}
+
+func TestTypeSystem_DefinitionExample_DefinesCyclicUnionsAndInterfacesWithThunks(t *testing.T) {
+
+	var nodeInterface *types.GraphQLInterfaceType
+	var searchResultUnion *types.GraphQLUnionType
+	interfacesThunkCalls, typesThunkCalls := 0, 0
+	nodeInterfaces := (types.GraphQLInterfacesThunk)(func() []*types.GraphQLInterfaceType {
+		interfacesThunkCalls++
+		return []*types.GraphQLInterfaceType{nodeInterface}
+	})
+	isTypeOf := func(value interface{}, info types.GraphQLResolveInfo) bool {
+		return true
+	}
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "Article",
+		Interfaces: nodeInterfaces,
+		IsTypeOf:   isTypeOf,
+		Fields: (types.GraphQLFieldConfigMapThunk)(func() types.GraphQLFieldConfigMap {
+			return types.GraphQLFieldConfigMap{
+				"id": &types.GraphQLFieldConfig{
+					Type: types.GraphQLID,
+				},
+				"related": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(searchResultUnion),
+				},
+			}
+		}),
+	})
+	authorType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "Author",
+		Interfaces: nodeInterfaces,
+		IsTypeOf:   isTypeOf,
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLID,
+			},
+		},
+	})
+	searchResultUnion = types.NewGraphQLUnionType(types.GraphQLUnionTypeConfig{
+		Name: "SearchResult",
+		Types: (types.GraphQLUnionTypesThunk)(func() []*types.GraphQLObjectType {
+			typesThunkCalls++
+			return []*types.GraphQLObjectType{articleType, authorType}
+		}),
+	})
+	nodeInterface = types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Node",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLID,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"search": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(searchResultUnion),
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if schema.GetType("Node") != nodeInterface {
+		t.Fatalf(`schema.GetType("Node") expected to equal nodeInterface, got: %v`, schema.GetType("Node"))
+	}
+	expectedInterfaces := []*types.GraphQLInterfaceType{nodeInterface}
+	if !reflect.DeepEqual(articleType.GetInterfaces(), expectedInterfaces) {
+		t.Fatalf("Unexpected Article interfaces, Diff: %v", testutil.Diff(expectedInterfaces, articleType.GetInterfaces()))
+	}
+	if !schema.IsPossibleType(nodeInterface, authorType) || !searchResultUnion.IsPossibleType(articleType) {
+		t.Fatalf("Article and Author expected to be possible types of Node and SearchResult")
+	}
+	// once per object type, when the schema is built
+	if interfacesThunkCalls != 2 {
+		t.Fatalf("interfaces thunk expected to be evaluated twice, got: %v", interfacesThunkCalls)
+	}
+	if typesThunkCalls != 1 {
+		t.Fatalf("types thunk expected to be evaluated once, got: %v", typesThunkCalls)
+	}
+}
+
+func TestTypeSystem_DefinitionExample_AssertsInterfacesOfThunksAreImplemented(t *testing.T) {
+
+	var nodeInterface *types.GraphQLInterfaceType
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Interfaces: (types.GraphQLInterfacesThunk)(func() []*types.GraphQLInterfaceType {
+			return []*types.GraphQLInterfaceType{nodeInterface}
+		}),
+		Fields: types.GraphQLFieldConfigMap{
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	nodeInterface = types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Node",
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return articleType
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLID,
+			},
+		},
+	})
+	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: articleType,
+				},
+			},
+		}),
+	})
+	expected := `"Node" expects field "id" but "Article" does not provide it.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got: %v", expected, err)
+	}
 }
 
 func TestTypeSystem_DefinitionExample_StringifiesSimpleTypes(t *testing.T) {
@@ -380,6 +700,28 @@ func TestTypeSystem_DefinitionExample_StringifiesSimpleTypes(t *testing.T) {
 	}
 }
 
+func TestTypeSystem_DefinitionExample_StringifiesDeeplyNestedWrappers(t *testing.T) {
+	type Test struct {
+		ttype    types.GraphQLType
+		expected string
+	}
+	tests := []Test{
+		Test{types.NewGraphQLNonNull(types.NewGraphQLList(types.GraphQLString)), "[String]!"},
+		Test{types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(blogAuthor))), "[Author!]!"},
+		Test{types.NewGraphQLList(types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(types.GraphQLInt)))), "[[Int!]!]"},
+		Test{types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLList(types.NewGraphQLList(inputObjectType)))), "[[[InputObject]]]!"},
+		Test{types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(enumType))))), "[[Enum!]!]!"},
+	}
+	for _, test := range tests {
+		if ttypeStr := fmt.Sprintf("%v", test.ttype); ttypeStr != test.expected {
+			t.Fatalf(`expected %v , got: %v`, test.expected, ttypeStr)
+		}
+		if ttypeStr := test.ttype.String(); ttypeStr != test.expected {
+			t.Fatalf(`expected %v , got: %v`, test.expected, ttypeStr)
+		}
+	}
+}
+
 func TestTypeSystem_DefinitionExample_IdentifiesInputTypes(t *testing.T) {
 	type Test struct {
 		ttype    types.GraphQLType
@@ -434,6 +776,82 @@ func TestTypeSystem_DefinitionExample_IdentifiesOutputTypes(t *testing.T) {
 	}
 }
 
+func TestTypeSystem_DefinitionExample_UnwrapsListAndNonNullTypes(t *testing.T) {
+	listType := types.NewGraphQLList(types.NewGraphQLNonNull(types.GraphQLString))
+	ttype := types.NewGraphQLNonNull(listType)
+	if namedType := types.GetNamedType(ttype); namedType != types.GraphQLString {
+		t.Fatalf(`expected %v, got: %v`, types.GraphQLString, namedType)
+	}
+	if nullableType := types.GetNullableType(ttype); nullableType != listType {
+		t.Fatalf(`expected %v, got: %v`, listType, nullableType)
+	}
+	if nullableType := types.GetNullableType(listType); nullableType != listType {
+		t.Fatalf(`expected %v, got: %v`, listType, nullableType)
+	}
+	var namedType types.GraphQLNamedType = types.GetNamedType(types.NewGraphQLList(enumType))
+	if namedType != enumType {
+		t.Fatalf(`expected %v, got: %v`, enumType, namedType)
+	}
+	if namedType := types.GetNamedType(nil); namedType != nil {
+		t.Fatalf(`expected nil, got: %v`, namedType)
+	}
+}
+
+func TestTypeSystem_DefinitionExample_GetsFieldsIncludingTheTypeNameMetaField(t *testing.T) {
+	type Test struct {
+		ttype    *types.GraphQLObjectType
+		name     string
+		expected *types.GraphQLFieldDefinition
+	}
+	tests := []Test{
+		Test{blogQuery, "article", blogQuery.GetFields()["article"]},
+		Test{blogQuery, "missing", nil},
+		Test{blogQuery, "__schema", nil},
+		Test{blogQuery, "__type", nil},
+		Test{blogQuery, "__typename", types.TypeNameMetaFieldDef},
+		Test{blogArticle, "__schema", nil},
+		Test{blogArticle, "__type", nil},
+		Test{blogArticle, "__typename", types.TypeNameMetaFieldDef},
+	}
+	for _, test := range tests {
+		if field := test.ttype.GetField(test.name); field != test.expected {
+			t.Fatalf(`expected %v.%v to be %v, got: %v`, test.ttype, test.name, test.expected, field)
+		}
+	}
+	if blogQuery.GetField("article") == nil {
+		t.Fatalf(`expected %v.article to be defined`, blogQuery)
+	}
+}
+
+func TestTypeSystem_DefinitionExample_IdentifiesLeafAndCompositeTypes(t *testing.T) {
+	type Test struct {
+		ttype     types.GraphQLType
+		leaf      bool
+		composite bool
+	}
+	tests := []Test{
+		Test{types.GraphQLString, true, false},
+		Test{enumType, true, false},
+		Test{objectType, false, true},
+		Test{interfaceType, false, true},
+		Test{unionType, false, true},
+		Test{inputObjectType, false, false},
+		Test{types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(types.GraphQLString))), true, false},
+		Test{types.NewGraphQLList(objectType), false, true},
+		Test{types.NewGraphQLNonNull(unionType), false, true},
+		Test{types.NewGraphQLList(inputObjectType), false, false},
+	}
+	for _, test := range tests {
+		ttypeStr := fmt.Sprintf("%v", test.ttype)
+		if types.IsLeafType(test.ttype) != test.leaf {
+			t.Fatalf(`expected IsLeafType %v, got: %v`, test.leaf, ttypeStr)
+		}
+		if types.IsCompositeType(test.ttype) != test.composite {
+			t.Fatalf(`expected IsCompositeType %v, got: %v`, test.composite, ttypeStr)
+		}
+	}
+}
+
 func TestTypeSystem_DefinitionExample_ProhibitsNestingNonNullInsideNonNull(t *testing.T) {
 	ttype := types.NewGraphQLNonNull(types.NewGraphQLNonNull(types.GraphQLInt))
 	expected := `Can only create NonNull of a Nullable GraphQLType but got: Int!.`
@@ -533,3 +951,172 @@ func TestTypeSystem_DefinitionExample_DoesNotMutatePassedFieldDefinitions(t *tes
 	}
 
 }
+
+func TestTypeSystem_DefinitionExample_RejectsFieldsAddedOnceASchemaIsBuilt(t *testing.T) {
+	fields := types.GraphQLFieldConfigMap{
+		"id": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+	}
+	userType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:   "User",
+		Fields: fields,
+	})
+	nodeInterface := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Node",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	if err := userType.AddFieldConfig("name", &types.GraphQLFieldConfig{
+		Type: types.GraphQLString,
+	}); err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if len(fields) != 1 {
+		t.Fatalf("expected the field config map of the type config to be left unmodified, got: %v", fields)
+	}
+	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"user": &types.GraphQLFieldConfig{
+					Type: userType,
+				},
+				"node": &types.GraphQLFieldConfig{
+					Type: nodeInterface,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	type Test struct {
+		addFieldConfig func(fieldName string, fieldConfig *types.GraphQLFieldConfig) error
+		expected       string
+	}
+	tests := []Test{
+		Test{userType.AddFieldConfig, `Cannot add the field "email" to User, a schema was built from it.`},
+		Test{nodeInterface.AddFieldConfig, `Cannot add the field "email" to Node, a schema was built from it.`},
+	}
+	for _, test := range tests {
+		err := test.addFieldConfig("email", &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		})
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("expected error %q, got: %v", test.expected, err)
+		}
+	}
+	if _, ok := userType.GetFields()["email"]; ok || len(userType.GetFields()) != 2 {
+		t.Fatalf("expected the fields of User to be left unmodified, got: %v", userType.GetFields())
+	}
+}
+
+func TestTypeSystem_DefinitionExample_InternsListAndNonNullTypes(t *testing.T) {
+	list := types.NewInternedGraphQLList(types.NewInternedGraphQLNonNull(types.GraphQLString))
+	if list != types.NewInternedGraphQLList(types.NewInternedGraphQLNonNull(types.GraphQLString)) {
+		t.Fatalf("expected the interned lists of the same type to be the same pointer")
+	}
+	if list == types.NewGraphQLList(list.OfType) {
+		t.Fatalf("expected NewGraphQLList to create a list of its own")
+	}
+	ttype := types.NewInternedGraphQLNonNull(types.NewInternedGraphQLNonNull(types.GraphQLInt))
+	expected := `Can only create NonNull of a Nullable GraphQLType but got: Int!.`
+	if ttype.GetError() == nil || ttype.GetError().Error() != expected {
+		t.Fatalf(`expected %v , got: %v`, expected, ttype.GetError())
+	}
+
+	schema, err := types.BuildSchema(`
+type Query {
+  tags: [String!]
+  labels: [String!]
+}
+`)
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	fields := schema.GetQueryType().GetFields()
+	if fields["tags"].Type != fields["labels"].Type {
+		t.Fatalf("expected the fields of a built schema to share the list of the same type")
+	}
+}
+
+func BenchmarkNewGraphQLList(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		types.NewGraphQLList(types.NewGraphQLNonNull(types.GraphQLString))
+	}
+}
+
+func BenchmarkNewInternedGraphQLList(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		types.NewInternedGraphQLList(types.NewInternedGraphQLNonNull(types.GraphQLString))
+	}
+}
+
+func TestTypeSystem_DefinitionExample_OrdersTheArgumentsAsDeclared(t *testing.T) {
+	picArgs := func() types.GraphQLFieldConfigArgumentMap {
+		return types.GraphQLFieldConfigArgumentMap{
+			"width": &types.GraphQLArgumentConfig{
+				Type: types.GraphQLInt,
+			},
+			"height": &types.GraphQLArgumentConfig{
+				Type: types.GraphQLInt,
+			},
+			"crop": &types.GraphQLArgumentConfig{
+				Type: types.GraphQLBoolean,
+			},
+		}
+	}
+	type Test struct {
+		argOrder []string
+		expected []string
+	}
+	tests := []Test{
+		// ordered by name without ArgOrder
+		Test{nil, []string{"crop", "height", "width"}},
+		// the arguments left out of ArgOrder follow
+		Test{[]string{"width", "height"}, []string{"width", "height", "crop"}},
+	}
+	for _, test := range tests {
+		authorType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Author",
+			Fields: types.GraphQLFieldConfigMap{
+				"pic": &types.GraphQLFieldConfig{
+					Type:     types.GraphQLString,
+					Args:     picArgs(),
+					ArgOrder: test.argOrder,
+				},
+			},
+		})
+		argNames := []string{}
+		for _, arg := range authorType.GetFields()["pic"].Args {
+			argNames = append(argNames, arg.Name)
+		}
+		if !reflect.DeepEqual(test.expected, argNames) {
+			t.Fatalf("Unexpected arguments with the order %v, Diff: %v", test.argOrder, testutil.Diff(test.expected, argNames))
+		}
+	}
+
+	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"pic": &types.GraphQLFieldConfig{
+					Type:     types.GraphQLString,
+					Args:     picArgs(),
+					ArgOrder: []string{"width", "depth"},
+				},
+			},
+		}),
+	})
+	expected := `Query.pic argument order names "depth", which is not an argument of the field.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got: %v", expected, err)
+	}
+}
@@ -1,5 +1,22 @@
 package types
 
+const (
+	DirectiveLocationQuery              = "QUERY"
+	DirectiveLocationMutation           = "MUTATION"
+	DirectiveLocationSubscription       = "SUBSCRIPTION"
+	DirectiveLocationField              = "FIELD"
+	DirectiveLocationFragmentDefinition = "FRAGMENT_DEFINITION"
+	DirectiveLocationFragmentSpread     = "FRAGMENT_SPREAD"
+	DirectiveLocationInlineFragment     = "INLINE_FRAGMENT"
+	DirectiveLocationScalar             = "SCALAR"
+	DirectiveLocationFieldDefinition    = "FIELD_DEFINITION"
+	DirectiveLocationEnumValue          = "ENUM_VALUE"
+)
+
+// The reason of the @deprecated directive when its reason argument is
+// omitted.
+const DefaultDeprecationReason = "No longer supported"
+
 type GraphQLDirective struct {
 	Name        string             `json:"name"`
 	Description string             `json:"description"`
@@ -7,6 +24,15 @@ type GraphQLDirective struct {
 	OnOperation bool               `json:"onOperation"`
 	OnFragment  bool               `json:"onFragment"`
 	OnField     bool               `json:"onField"`
+
+	// The locations where the directive may be used, one of the
+	// DirectiveLocation constants each. When not set, the locations are
+	// those of the OnOperation, OnFragment and OnField flags.
+	Locations []string `json:"locations"`
+
+	// Whether the directive may be used more than once at a location, no
+	// built-in directive is repeatable.
+	IsRepeatable bool `json:"isRepeatable"`
 }
 
 /**
@@ -18,13 +44,34 @@ func NewGraphQLDirective(config *GraphQLDirective) *GraphQLDirective {
 		config = &GraphQLDirective{}
 	}
 	return &GraphQLDirective{
-		Name:        config.Name,
-		Description: config.Description,
-		Args:        config.Args,
-		OnOperation: config.OnOperation,
-		OnFragment:  config.OnFragment,
-		OnField:     config.OnField,
+		Name:         config.Name,
+		Description:  config.Description,
+		Args:         config.Args,
+		OnOperation:  config.OnOperation,
+		OnFragment:   config.OnFragment,
+		OnField:      config.OnField,
+		Locations:    append([]string{}, config.Locations...),
+		IsRepeatable: config.IsRepeatable,
+	}
+}
+
+// GetLocations returns the locations where the directive may be used.
+func (dir *GraphQLDirective) GetLocations() []string {
+	if len(dir.Locations) > 0 {
+		return dir.Locations
+	}
+	locations := []string{}
+	if dir.OnOperation {
+		locations = append(locations, DirectiveLocationQuery, DirectiveLocationMutation, DirectiveLocationSubscription)
 	}
+	if dir.OnField {
+		locations = append(locations, DirectiveLocationField)
+	}
+	if dir.OnFragment {
+		locations = append(locations, DirectiveLocationFragmentDefinition, DirectiveLocationFragmentSpread,
+			DirectiveLocationInlineFragment)
+	}
+	return locations
 }
 
 /**
@@ -44,6 +91,11 @@ var GraphQLIncludeDirective *GraphQLDirective = NewGraphQLDirective(&GraphQLDire
 	OnOperation: false,
 	OnFragment:  true,
 	OnField:     true,
+	Locations: []string{
+		DirectiveLocationField,
+		DirectiveLocationFragmentSpread,
+		DirectiveLocationInlineFragment,
+	},
 })
 
 /**
@@ -63,4 +115,54 @@ var GraphQLSkipDirective *GraphQLDirective = NewGraphQLDirective(&GraphQLDirecti
 	OnOperation: false,
 	OnFragment:  true,
 	OnField:     true,
+	Locations: []string{
+		DirectiveLocationField,
+		DirectiveLocationFragmentSpread,
+		DirectiveLocationInlineFragment,
+	},
+})
+
+/**
+ * Used to declare the fields and enum values of a schema definition which
+ * are deprecated, see BuildSchema.
+ */
+var GraphQLDeprecatedDirective *GraphQLDirective = NewGraphQLDirective(&GraphQLDirective{
+	Name:        "deprecated",
+	Description: "Marks an element of a GraphQL schema as no longer supported.",
+	Args: []*GraphQLArgument{
+		&GraphQLArgument{
+			Name: "reason",
+			Type: GraphQLString,
+			Description: "Explains why this element was deprecated, usually also including a " +
+				"suggestion for how to access supported similar data.",
+			DefaultValue: DefaultDeprecationReason,
+		},
+	},
+	OnOperation: false,
+	OnFragment:  false,
+	OnField:     false,
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+		DirectiveLocationEnumValue,
+	},
+})
+
+/**
+ * Used to provide the URL of the specification of a custom scalar's behavior,
+ * see GraphQLScalarTypeConfig.SpecifiedByURL.
+ */
+var GraphQLSpecifiedByDirective *GraphQLDirective = NewGraphQLDirective(&GraphQLDirective{
+	Name:        "specifiedBy",
+	Description: "Exposes a URL that specifies the behaviour of this scalar.",
+	Args: []*GraphQLArgument{
+		&GraphQLArgument{
+			Name:        "url",
+			Type:        NewGraphQLNonNull(GraphQLString),
+			Description: "The URL that specifies the behaviour of this scalar.",
+		},
+	},
+	OnOperation: false,
+	OnFragment:  false,
+	OnField:     false,
+	Locations:   []string{DirectiveLocationScalar},
 })
@@ -332,3 +332,150 @@ func TestTypeSystem_EnumValues_EnumValueMayBeNullable(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+type enumTypeTestPaint int
+
+const (
+	enumTypeTestPaintRed enumTypeTestPaint = iota
+	enumTypeTestPaintGreen
+)
+
+func TestTypeSystem_EnumValues_DeliversTypedInternalValuesToResolvers(t *testing.T) {
+	paintType := types.NewGraphQLEnumType(types.GraphQLEnumTypeConfig{
+		Name: "Paint",
+		Values: types.GraphQLEnumValueConfigMap{
+			"RED": &types.GraphQLEnumValueConfig{
+				Value: enumTypeTestPaintRed,
+			},
+			"GREEN": &types.GraphQLEnumValueConfig{
+				Value: enumTypeTestPaintGreen,
+			},
+		},
+	})
+	hexCodes := map[enumTypeTestPaint]string{
+		enumTypeTestPaintRed:   "#f00",
+		enumTypeTestPaintGreen: "#0f0",
+	}
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"hexCode": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"paint": &types.GraphQLArgumentConfig{
+							Type: paintType,
+						},
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						paint, ok := p.Args["paint"].(enumTypeTestPaint)
+						if !ok {
+							t.Fatalf("Expected a typed enumTypeTestPaint, got: %#v", p.Args["paint"])
+						}
+						return hexCodes[paint]
+					},
+				},
+				"paint": &types.GraphQLFieldConfig{
+					Type: paintType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return enumTypeTestPaintGreen
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"fromLiteral":  "#0f0",
+			"fromVariable": "#f00",
+			"paint":        "GREEN",
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:         schema,
+		RequestString:  `query q($paint: Paint) { fromLiteral: hexCode(paint: GREEN), fromVariable: hexCode(paint: $paint), paint }`,
+		VariableValues: map[string]interface{}{"paint": "RED"},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+var enumTypeTestDirectionType = types.NewGraphQLEnumType(types.GraphQLEnumTypeConfig{
+	Name: "Direction",
+	Values: types.GraphQLEnumValueConfigMap{
+		"NORTH": &types.GraphQLEnumValueConfig{
+			Value: 0,
+		},
+		"SOUTH": &types.GraphQLEnumValueConfig{
+			Value: 1,
+		},
+	},
+})
+
+var enumTypeTestDirectionSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"turn": &types.GraphQLFieldConfig{
+				Type: enumTypeTestDirectionType,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"direction": &types.GraphQLArgumentConfig{
+						Type: enumTypeTestDirectionType,
+					},
+				},
+				Resolve: func(p types.GQLFRParams) interface{} {
+					if p.Args["direction"] == 0 {
+						return 1
+					}
+					return 0
+				},
+			},
+		},
+	}),
+})
+
+func TestTypeSystem_EnumValues_RoundTripsInternalValuesThroughResolvers(t *testing.T) {
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"fromLiteral":  "SOUTH",
+			"fromVariable": "NORTH",
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:         enumTypeTestDirectionSchema,
+		RequestString:  `query q($direction: Direction) { fromLiteral: turn(direction: NORTH) fromVariable: turn(direction: $direction) }`,
+		VariableValues: map[string]interface{}{"direction": "SOUTH"},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if value := enumTypeTestDirectionType.Serialize([]interface{}{0}); value != nil {
+		t.Fatalf("Expected a list not to serialize, got: %v", value)
+	}
+}
+
+func TestTypeSystem_EnumValues_ReportsUnknownEnumNames(t *testing.T) {
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        enumTypeTestDirectionSchema,
+		RequestString: `{ turn(direction: WEST) }`,
+	})
+	message := `Argument "direction" has invalid value: Expected type "Direction", found WEST.`
+	if len(result.Errors) != 1 || result.Errors[0].Message != message {
+		t.Fatalf("Expected error %q, got: %v", message, result.Errors)
+	}
+
+	result = graphql(t, gql.GraphqlParams{
+		Schema:         enumTypeTestDirectionSchema,
+		RequestString:  `query q($direction: Direction) { turn(direction: $direction) }`,
+		VariableValues: map[string]interface{}{"direction": "WEST"},
+	})
+	message = `Variable "$direction" expected value of type "Direction" but got: "WEST".`
+	if len(result.Errors) != 1 || result.Errors[0].Message != message {
+		t.Fatalf("Expected error %q, got: %v", message, result.Errors)
+	}
+}
@@ -0,0 +1,335 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/parser"
+)
+
+// ExtendSchema returns a new schema with the types and the type extensions
+// defined in the schema definition language, see ExtendASTSchema.
+func ExtendSchema(schema GraphQLSchema, sdl string) (GraphQLSchema, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: sdl})
+	if err != nil {
+		return GraphQLSchema{}, err
+	}
+	return ExtendASTSchema(schema, doc)
+}
+
+// ExtendASTSchema returns a new schema with the types defined in the document
+// added, and the fields and interfaces of the `extend type` definitions of
+// the document added to the existing object types, other definitions are
+// ignored. The added types are built as by BuildASTSchema.
+//
+// The given schema is not modified: its object, interface, union and input
+// object types are copied into the new schema, along with their resolvers.
+// Adding a type or a field which already exists is an error. The schema is
+// returned as is when the document neither defines nor extends a type.
+// The directives of the schema are carried over, their arguments referring
+// to the copied types; directive definitions are not parsed yet, so the
+// document cannot add directives.
+func ExtendASTSchema(schema GraphQLSchema, doc *ast.Document) (GraphQLSchema, error) {
+	if doc == nil {
+		return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError("Must provide a document.")
+	}
+	b := &schemaBuilder{
+		definitions: map[string]ast.Node{},
+		types: GraphQLTypeMap{
+			"String":  GraphQLString,
+			"Int":     GraphQLInt,
+			"Float":   GraphQLFloat,
+			"Boolean": GraphQLBoolean,
+			"ID":      GraphQLID,
+		},
+	}
+	typeMap := schema.GetTypeMap()
+	extensions := map[string][]*ast.ObjectTypeDefinition{}
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.TypeExtensionDefinition); ok {
+			if def.Definition == nil || def.Definition.Name == nil {
+				continue
+			}
+			name := def.Definition.Name.Value
+			existing, ok := typeMap[name]
+			if !ok {
+				return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+					`Cannot extend type "%v" because it does not exist in the existing schema.`, name))
+			}
+			if _, ok := existing.(*GraphQLObjectType); !ok {
+				return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+					`Cannot extend non-object type "%v".`, name))
+			}
+			extensions[name] = append(extensions[name], def.Definition)
+			continue
+		}
+		name := typeDefinitionName(def)
+		if name == "" {
+			continue
+		}
+		if _, ok := typeMap[name]; ok {
+			return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+				`Type "%v" already exists in the schema. It cannot also be defined in this type definition.`, name))
+		}
+		if _, ok := b.definitions[name]; ok {
+			return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(
+				fmt.Sprintf(`Type "%v" was defined more than once.`, name))
+		}
+		if _, ok := b.types[name]; ok {
+			return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(
+				fmt.Sprintf(`Type "%v" is a built-in type and cannot be redefined.`, name))
+		}
+		b.definitions[name] = def
+	}
+	if len(b.definitions) == 0 && len(extensions) == 0 {
+		return schema, nil
+	}
+	if err := checkExtensions(typeMap, extensions); err != nil {
+		return GraphQLSchema{}, err
+	}
+
+	// The copies of the existing types refer to each other, and to the added
+	// types, through the type map of the builder, so every copy is added to
+	// it before the fields of any type are built.
+	names := []string{}
+	for name := range typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	interfaceFields := map[string]GraphQLFieldConfigMap{}
+	for _, name := range names {
+		b.types[name] = b.copyType(typeMap[name], extensions[name], interfaceFields)
+	}
+	for name, fields := range interfaceFields {
+		for fieldName, field := range b.fieldConfigs(typeMap[name].(*GraphQLInterfaceType).GetFields()) {
+			fields[fieldName] = field
+		}
+	}
+	for _, def := range doc.Definitions {
+		if def, ok := def.(*ast.TypeExtensionDefinition); ok {
+			if def.Definition == nil {
+				continue
+			}
+			if err := b.checkTypeReferences(def.Definition); err != nil {
+				return GraphQLSchema{}, err
+			}
+			continue
+		}
+		if err := b.checkTypeReferences(def); err != nil {
+			return GraphQLSchema{}, err
+		}
+	}
+
+	config := GraphQLSchemaConfig{}
+	config.Query, _ = b.types[schema.GetQueryType().Name].(*GraphQLObjectType)
+	if mutationType := schema.GetMutationType(); mutationType != nil {
+		config.Mutation, _ = b.types[mutationType.Name].(*GraphQLObjectType)
+	}
+	if subscriptionType := schema.GetSubscriptionType(); subscriptionType != nil {
+		config.Subscription, _ = b.types[subscriptionType.Name].(*GraphQLObjectType)
+	}
+	for name := range b.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		config.Types = append(config.Types, b.namedType(name))
+	}
+	config.Directives = b.copiedDirectives(schema.GetDirectives())
+	return NewGraphQLSchema(config)
+}
+
+// Returns the directives with the types of their arguments replaced by those
+// of the builder, the directives whose arguments are of scalar or enum types
+// being returned as is.
+func (b *schemaBuilder) copiedDirectives(directives []*GraphQLDirective) []*GraphQLDirective {
+	copied := []*GraphQLDirective{}
+	for _, directive := range directives {
+		args := []*GraphQLArgument{}
+		changed := false
+		for _, arg := range directive.Args {
+			copiedArg := *arg
+			copiedArg.Type = b.copiedType(arg.Type).(GraphQLInputType)
+			changed = changed || copiedArg.Type != arg.Type
+			args = append(args, &copiedArg)
+		}
+		if !changed {
+			copied = append(copied, directive)
+			continue
+		}
+		copiedDirective := *directive
+		copiedDirective.Args = args
+		copied = append(copied, &copiedDirective)
+	}
+	return copied
+}
+
+// Reports the fields and interfaces of the extensions which the extended
+// types, or other extensions of the same types, already define.
+func checkExtensions(typeMap GraphQLTypeMap, extensions map[string][]*ast.ObjectTypeDefinition) error {
+	for name, defs := range extensions {
+		objectType := typeMap[name].(*GraphQLObjectType)
+		fields := map[string]bool{}
+		for fieldName := range objectType.GetFields() {
+			fields[fieldName] = true
+		}
+		interfaces := map[string]bool{}
+		for _, iface := range objectType.GetInterfaces() {
+			interfaces[iface.Name] = true
+		}
+		for _, def := range defs {
+			for _, field := range def.Fields {
+				if field.Name == nil {
+					continue
+				}
+				if fields[field.Name.Value] {
+					return graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+						`Field "%v.%v" already exists in the schema. It cannot also be defined in this type extension.`,
+						name, field.Name.Value))
+				}
+				fields[field.Name.Value] = true
+			}
+			for _, iface := range def.Interfaces {
+				ifaceName := namedTypeName(iface)
+				if interfaces[ifaceName] {
+					return graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+						`Type "%v" already implements "%v". It cannot also be implemented in this type extension.`,
+						name, ifaceName))
+				}
+				interfaces[ifaceName] = true
+			}
+		}
+	}
+	return nil
+}
+
+// Returns a copy of an existing type whose references to other types are
+// those of the builder, with the fields and interfaces of its extensions.
+// Scalars, enums and the introspection types, which do not refer to the
+// types of the schema, are not copied. The fields of an interface are set
+// in interfaceFields once every type is copied.
+func (b *schemaBuilder) copyType(ttype GraphQLType, extensions []*ast.ObjectTypeDefinition, interfaceFields map[string]GraphQLFieldConfigMap) GraphQLType {
+	if strings.HasPrefix(ttype.GetName(), "__") {
+		return ttype
+	}
+	switch ttype := ttype.(type) {
+	case *GraphQLObjectType:
+		return NewGraphQLObjectType(GraphQLObjectTypeConfig{
+			Name:        ttype.Name,
+			Description: ttype.Description,
+			IsTypeOf:    ttype.IsTypeOf,
+			Extensions:  ttype.Extensions,
+			Interfaces: GraphQLInterfacesThunk(func() []*GraphQLInterfaceType {
+				ifaces := []*GraphQLInterfaceType{}
+				for _, iface := range ttype.GetInterfaces() {
+					ifaces = append(ifaces, b.types[iface.Name].(*GraphQLInterfaceType))
+				}
+				for _, def := range extensions {
+					for _, iface := range def.Interfaces {
+						ifaceType, _ := b.typeOf(iface).(*GraphQLInterfaceType)
+						ifaces = append(ifaces, ifaceType)
+					}
+				}
+				return ifaces
+			}),
+			Fields: GraphQLFieldConfigMapThunk(func() GraphQLFieldConfigMap {
+				fields := b.fieldConfigs(ttype.GetFields())
+				for _, def := range extensions {
+					for fieldName, field := range b.fieldConfigMap(def.Fields) {
+						fields[fieldName] = field
+					}
+				}
+				return fields
+			}),
+		})
+	case *GraphQLInterfaceType:
+		fields := GraphQLFieldConfigMap{}
+		interfaceFields[ttype.Name] = fields
+		return NewGraphQLInterfaceType(GraphQLInterfaceTypeConfig{
+			Name:        ttype.Name,
+			Description: ttype.Description,
+			Fields:      fields,
+			ResolveType: ttype.ResolveType,
+		})
+	case *GraphQLUnionType:
+		return NewGraphQLUnionType(GraphQLUnionTypeConfig{
+			Name:        ttype.Name,
+			Description: ttype.Description,
+			Types: GraphQLUnionTypesThunk(func() []*GraphQLObjectType {
+				members := []*GraphQLObjectType{}
+				for _, member := range ttype.GetPossibleTypes() {
+					members = append(members, b.types[member.Name].(*GraphQLObjectType))
+				}
+				return members
+			}),
+			ResolveType: ttype.ResolveType,
+		})
+	case *GraphQLInputObjectType:
+		return NewGraphQLInputObjectType(InputObjectConfig{
+			Name:        ttype.Name,
+			Description: ttype.Description,
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for fieldName, field := range ttype.GetFields() {
+					fields[fieldName] = &InputObjectFieldConfig{
+						Type:         b.copiedType(field.Type).(GraphQLInputType),
+						DefaultValue: field.DefaultValue,
+						Description:  field.Description,
+					}
+				}
+				return fields
+			}),
+		})
+	}
+	return ttype
+}
+
+// Returns the configs of the fields of an existing type, referring to the
+// types of the builder.
+func (b *schemaBuilder) fieldConfigs(fieldDefs GraphQLFieldDefinitionMap) GraphQLFieldConfigMap {
+	fields := GraphQLFieldConfigMap{}
+	for fieldName, fieldDef := range fieldDefs {
+		args := GraphQLFieldConfigArgumentMap{}
+		argOrder := []string{}
+		for _, arg := range fieldDef.Args {
+			argOrder = append(argOrder, arg.Name)
+			args[arg.Name] = &GraphQLArgumentConfig{
+				Type:         b.copiedType(arg.Type).(GraphQLInputType),
+				DefaultValue: arg.DefaultValue,
+				Description:  arg.Description,
+				Extensions:   arg.Extensions,
+			}
+		}
+		fields[fieldName] = &GraphQLFieldConfig{
+			Type:              b.copiedType(fieldDef.Type).(GraphQLOutputType),
+			Args:              args,
+			ArgOrder:          argOrder,
+			Resolve:           fieldDef.Resolve,
+			Subscribe:         fieldDef.Subscribe,
+			DeprecationReason: fieldDef.DeprecationReason,
+			Description:       fieldDef.Description,
+			Cache:             fieldDef.Cache,
+			Complexity:        fieldDef.Complexity,
+			Extensions:        fieldDef.Extensions,
+		}
+	}
+	return fields
+}
+
+// Returns the type of the builder named as the named type of an existing
+// type, wrapped as the existing type.
+func (b *schemaBuilder) copiedType(ttype GraphQLType) GraphQLType {
+	switch ttype := ttype.(type) {
+	case *GraphQLList:
+		return b.wrappers.list(b.copiedType(ttype.OfType))
+	case *GraphQLNonNull:
+		return b.wrappers.nonNull(b.copiedType(ttype.OfType))
+	}
+	if copied, ok := b.types[ttype.GetName()]; ok {
+		return copied
+	}
+	return ttype
+}
@@ -0,0 +1,167 @@
+package types_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+var extendSchemaNodeType = types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+	Name: "Node",
+	Fields: types.GraphQLFieldConfigMap{
+		"id": &types.GraphQLFieldConfig{
+			Type: types.NewGraphQLNonNull(types.GraphQLID),
+		},
+	},
+})
+
+var extendSchemaUserType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name:       "User",
+	Interfaces: []*types.GraphQLInterfaceType{extendSchemaNodeType},
+	Fields: types.GraphQLFieldConfigMap{
+		"id": &types.GraphQLFieldConfig{
+			Type: types.NewGraphQLNonNull(types.GraphQLID),
+		},
+		"name": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+			Resolve: func(p types.GQLFRParams) interface{} {
+				return "John"
+			},
+		},
+	},
+	IsTypeOf: func(value interface{}, info types.GraphQLResolveInfo) bool {
+		return true
+	},
+})
+
+var extendSchemaTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"viewer": &types.GraphQLFieldConfig{
+				Type: extendSchemaUserType,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return map[string]interface{}{
+						"id":     "1",
+						"avatar": map[string]interface{}{"url": "cdn://1"},
+					}
+				},
+			},
+		},
+	}),
+})
+
+func TestExtendSchema_AddsFieldsAndTypesWithoutModifyingTheSchema(t *testing.T) {
+	schema := extendSchemaTestSchema
+	extended, err := types.ExtendSchema(schema, `
+type Avatar {
+  url: String
+}
+
+extend type User {
+  avatar: Avatar
+}
+`)
+	if err != nil {
+		t.Fatalf("Error extending schema: %v", err)
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"viewer": map[string]interface{}{
+				"name":   "John",
+				"avatar": map[string]interface{}{"url": "cdn://1"},
+			},
+		},
+	}
+	result := gql.ExecuteString(extended, `{ viewer { name avatar { url } } }`)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	if _, ok := schema.GetType("User").(*types.GraphQLObjectType).GetFields()["avatar"]; ok {
+		t.Fatalf("Expected the extended schema to leave User unmodified")
+	}
+	if schema.GetType("Avatar") != nil {
+		t.Fatalf("Expected the extended schema to leave the type map unmodified")
+	}
+	node := extended.GetType("Node")
+	user, _ := extended.GetType("User").(*types.GraphQLObjectType)
+	if node == schema.GetType("Node") || !extended.IsPossibleType(node, user) {
+		t.Fatalf("Expected User to implement the copy of Node")
+	}
+}
+
+func TestExtendSchema_RejectsConflictingExtensions(t *testing.T) {
+	tests := map[string]string{
+		`extend type User { name: String }`: `Field "User.name" already exists in the schema. ` +
+			`It cannot also be defined in this type extension.`,
+		`extend type User implements Node { email: String }`: `Type "User" already implements "Node". ` +
+			`It cannot also be implemented in this type extension.`,
+		`extend type Article { title: String }`: `Cannot extend type "Article" because it does not exist ` +
+			`in the existing schema.`,
+		`extend type Node { name: String }`: `Cannot extend non-object type "Node".`,
+		`type User { id: ID }`: `Type "User" already exists in the schema. ` +
+			`It cannot also be defined in this type definition.`,
+	}
+	schema := extendSchemaTestSchema
+	for sdl, message := range tests {
+		_, err := types.ExtendSchema(schema, sdl)
+		if err == nil || err.Error() != message {
+			t.Fatalf("Expected error %q extending with %q, got: %v", message, sdl, err)
+		}
+	}
+}
+
+func TestExtendSchema_CarriesOverTheDirectivesOfTheSchema(t *testing.T) {
+	windowType := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "Window",
+		Fields: types.InputObjectConfigFieldMap{
+			"seconds": &types.InputObjectFieldConfig{
+				Type: types.GraphQLInt,
+			},
+		},
+	})
+	limitDirective := types.NewGraphQLDirective(&types.GraphQLDirective{
+		Name: "limit",
+		Args: []*types.GraphQLArgument{
+			&types.GraphQLArgument{
+				Name: "window",
+				Type: windowType,
+			},
+		},
+		Locations: []string{types.DirectiveLocationField},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"viewer": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+				},
+			},
+		}),
+		Directives: []*types.GraphQLDirective{limitDirective},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	extended, err := types.ExtendSchema(schema, `extend type Query { count: Int }`)
+	if err != nil {
+		t.Fatalf("Error extending schema: %v", err)
+	}
+	var limit *types.GraphQLDirective
+	for _, directive := range extended.GetDirectives() {
+		if directive.Name == "limit" {
+			limit = directive
+		}
+	}
+	if limit == nil {
+		t.Fatalf("Expected the extended schema to carry over the @limit directive")
+	}
+	if limit.Args[0].Type != extended.GetType("Window") {
+		t.Fatalf("Expected the argument of @limit to be of the Window type of the extended schema")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/chris-ramon/graphql-go/language/printer"
 	"math"
 	"reflect"
+	"sort"
 )
 
 const (
@@ -27,6 +28,7 @@ var __InputValue *GraphQLObjectType
 var __EnumValue *GraphQLObjectType
 
 var __TypeKind *GraphQLEnumType
+var __DirectiveLocation *GraphQLEnumType
 
 var SchemaMetaFieldDef *GraphQLFieldDefinition
 var TypeMetaFieldDef *GraphQLFieldDefinition
@@ -114,6 +116,15 @@ func init() {
 			"description": &GraphQLFieldConfig{
 				Type: GraphQLString,
 			},
+			"specifiedByURL": &GraphQLFieldConfig{
+				Type: GraphQLString,
+				Resolve: func(p GQLFRParams) interface{} {
+					if ttype, ok := p.Source.(*GraphQLScalarType); ok && ttype.SpecifiedByURL != "" {
+						return ttype.SpecifiedByURL
+					}
+					return nil
+				},
+			},
 			"fields":        &GraphQLFieldConfig{},
 			"interfaces":    &GraphQLFieldConfig{},
 			"possibleTypes": &GraphQLFieldConfig{},
@@ -142,14 +153,14 @@ func init() {
 						if inputVal.DefaultValue == nil {
 							return nil
 						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
+						astVal := astFromValue(inputVal.DefaultValue, inputVal.Type)
 						return printer.Print(astVal)
 					}
 					if inputVal, ok := p.Source.(*InputObjectField); ok {
 						if inputVal.DefaultValue == nil {
 							return nil
 						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
+						astVal := astFromValue(inputVal.DefaultValue, inputVal.Type)
 						return printer.Print(astVal)
 					}
 					return nil
@@ -194,6 +205,53 @@ func init() {
 		},
 	})
 
+	__DirectiveLocation = NewGraphQLEnumType(GraphQLEnumTypeConfig{
+		Name:        "__DirectiveLocation",
+		Description: "A location where a directive may be used.",
+		Values: GraphQLEnumValueConfigMap{
+			"QUERY": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationQuery,
+				Description: "Location adjacent to a query operation.",
+			},
+			"MUTATION": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationMutation,
+				Description: "Location adjacent to a mutation operation.",
+			},
+			"SUBSCRIPTION": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationSubscription,
+				Description: "Location adjacent to a subscription operation.",
+			},
+			"FIELD": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationField,
+				Description: "Location adjacent to a field.",
+			},
+			"FRAGMENT_DEFINITION": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationFragmentDefinition,
+				Description: "Location adjacent to a fragment definition.",
+			},
+			"FRAGMENT_SPREAD": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationFragmentSpread,
+				Description: "Location adjacent to a fragment spread.",
+			},
+			"INLINE_FRAGMENT": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationInlineFragment,
+				Description: "Location adjacent to an inline fragment.",
+			},
+			"SCALAR": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationScalar,
+				Description: "Location adjacent to a scalar definition.",
+			},
+			"FIELD_DEFINITION": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationFieldDefinition,
+				Description: "Location adjacent to a field definition.",
+			},
+			"ENUM_VALUE": &GraphQLEnumValueConfig{
+				Value:       DirectiveLocationEnumValue,
+				Description: "Location adjacent to an enum value definition.",
+			},
+		},
+	})
+
 	__Directive = NewGraphQLObjectType(GraphQLObjectTypeConfig{
 		Name: "__Directive",
 		Fields: GraphQLFieldConfigMap{
@@ -217,6 +275,20 @@ func init() {
 			"onField": &GraphQLFieldConfig{
 				Type: NewGraphQLNonNull(GraphQLBoolean),
 			},
+			"locations": &GraphQLFieldConfig{
+				Type: NewGraphQLNonNull(NewGraphQLList(
+					NewGraphQLNonNull(__DirectiveLocation),
+				)),
+				Resolve: func(p GQLFRParams) interface{} {
+					if dir, ok := p.Source.(*GraphQLDirective); ok {
+						return dir.GetLocations()
+					}
+					return []string{}
+				},
+			},
+			"isRepeatable": &GraphQLFieldConfig{
+				Type: NewGraphQLNonNull(GraphQLBoolean),
+			},
 		},
 	})
 
@@ -224,8 +296,8 @@ func init() {
 		Name: "__Schema",
 		Description: `A GraphQL Schema defines the capabilities of a GraphQL
 server. It exposes all available types and directives on
-the server, as well as the entry points for query and
-mutation operations.`,
+the server, as well as the entry points for query, mutation,
+and subscription operations.`,
 		Fields: GraphQLFieldConfigMap{
 			"types": &GraphQLFieldConfig{
 				Description: "A list of all types supported by this server.",
@@ -266,6 +338,19 @@ mutation operations.`,
 					return nil
 				},
 			},
+			"subscriptionType": &GraphQLFieldConfig{
+				Description: `If this server supports subscription, the type that ` +
+					`subscription operations will be rooted at.`,
+				Type: __Type,
+				Resolve: func(p GQLFRParams) interface{} {
+					if schema, ok := p.Source.(GraphQLSchema); ok {
+						if schema.GetSubscriptionType() != nil {
+							return schema.GetSubscriptionType()
+						}
+					}
+					return nil
+				},
+			},
 			"directives": &GraphQLFieldConfig{
 				Description: `A list of all directives supported by this server.`,
 				Type: NewGraphQLNonNull(NewGraphQLList(
@@ -361,9 +446,9 @@ mutation operations.`,
 		Resolve: func(p GQLFRParams) interface{} {
 			switch ttype := p.Source.(type) {
 			case *GraphQLInterfaceType:
-				return ttype.GetPossibleTypes()
+				return p.Info.Schema.GetPossibleTypes(ttype)
 			case *GraphQLUnionType:
-				return ttype.GetPossibleTypes()
+				return p.Info.Schema.GetPossibleTypes(ttype)
 			}
 			return nil
 		},
@@ -404,6 +489,10 @@ mutation operations.`,
 				for _, field := range ttype.GetFields() {
 					fields = append(fields, field)
 				}
+				// sorted, as the fields are defined by a map
+				sort.Slice(fields, func(i, j int) bool {
+					return fields[i].Name < fields[j].Name
+				})
 				return fields
 			}
 			return nil
@@ -520,8 +609,41 @@ func astFromValue(value interface{}, ttype GraphQLType) ast.Value {
 		}
 	}
 
-	if valueVal.Type().Kind() == reflect.Map {
-		// TODO: implement astFromValue from Map to ast.Value
+	// Convert a map to a GraphQL input object, its fields sorted by name.
+	if ttype, ok := ttype.(*GraphQLInputObjectType); ok {
+		valueMap, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := ttype.GetFields()
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		fieldASTs := []*ast.ObjectField{}
+		for _, fieldName := range fieldNames {
+			fieldValue := astFromValue(valueMap[fieldName], fields[fieldName].Type)
+			if fieldValue == nil {
+				continue
+			}
+			fieldASTs = append(fieldASTs, ast.NewObjectField(&ast.ObjectField{
+				Name:  ast.NewName(&ast.Name{Value: fieldName}),
+				Value: fieldValue,
+			}))
+		}
+		return ast.NewObjectValue(&ast.ObjectValue{
+			Fields: fieldASTs,
+		})
+	}
+
+	// The internal value of an enum is printed as the name of its value.
+	if enumType, ok := ttype.(*GraphQLEnumType); ok {
+		if name, ok := enumType.Serialize(value).(string); ok {
+			return ast.NewEnumValue(&ast.EnumValue{
+				Value: name,
+			})
+		}
 	}
 
 	if value, ok := value.(bool); ok {
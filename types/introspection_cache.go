@@ -0,0 +1,72 @@
+package types
+
+import (
+	"sync"
+
+	"github.com/chris-ramon/graphql-go/errors"
+)
+
+// ExecuteIntrospectionQuery executes IntrospectionQuery against a schema,
+// without the memoized result. It is set by the executor package, which
+// depends on this one.
+var ExecuteIntrospectionQuery func(schema GraphQLSchema) *GraphQLResult
+
+// Memoizes the introspection of a schema, shared by the copies of the
+// schema, see GraphQLSchema.IntrospectionResult.
+type introspectionCache struct {
+	mu     sync.Mutex
+	result *GraphQLResult
+}
+
+// IntrospectionResult returns the result of IntrospectionQuery, computed
+// once for the schema, whose types are immutable, see AddFieldConfig. A
+// schema extended by ExtendSchema is a new schema, with its own result.
+// Each call returns a copy, which the caller may modify. The executor
+// answers the documents of IntrospectionQuery with it.
+func (gq *GraphQLSchema) IntrospectionResult() *GraphQLResult {
+	if ExecuteIntrospectionQuery == nil {
+		return &GraphQLResult{Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.NewGraphQLFormattedError("The executor package must be imported to introspect a schema."),
+		}}
+	}
+	cache := gq.introspection
+	if cache == nil {
+		return ExecuteIntrospectionQuery(*gq)
+	}
+	cache.mu.Lock()
+	cached := cache.result
+	cache.mu.Unlock()
+	if cached == nil {
+		// executed without the lock, so that the callers do not wait for
+		// each other, the first result stored being kept
+		result := ExecuteIntrospectionQuery(*gq)
+		if result.HasErrors() {
+			return result
+		}
+		cache.mu.Lock()
+		if cache.result == nil {
+			cache.result = result
+		}
+		cached = cache.result
+		cache.mu.Unlock()
+	}
+	return &GraphQLResult{Data: copyIntrospectionValue(cached.Data)}
+}
+
+func copyIntrospectionValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			copied[key] = copyIntrospectionValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, item := range value {
+			copied[i] = copyIntrospectionValue(item)
+		}
+		return copied
+	}
+	return value
+}
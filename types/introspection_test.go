@@ -1,12 +1,17 @@
 package types_test
 
 import (
+	"fmt"
 	"github.com/chris-ramon/graphql-go"
 	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/executor"
 	"github.com/chris-ramon/graphql-go/language/location"
 	"github.com/chris-ramon/graphql-go/testutil"
 	"github.com/chris-ramon/graphql-go/types"
 	"reflect"
+	"runtime"
+	"sort"
+	"sync"
 	"testing"
 )
 
@@ -33,7 +38,8 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 	}
 	expectedDataSubSet := map[string]interface{}{
 		"__schema": map[string]interface{}{
-			"mutationType": nil,
+			"mutationType":     nil,
+			"subscriptionType": nil,
 			"queryType": map[string]interface{}{
 				"name": "QueryRoot",
 			},
@@ -96,6 +102,16 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							"isDeprecated":      false,
 							"deprecationReason": nil,
 						},
+						map[string]interface{}{
+							"name": "subscriptionType",
+							"args": []interface{}{},
+							"type": map[string]interface{}{
+								"kind": "OBJECT",
+								"name": "__Type",
+							},
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
 						map[string]interface{}{
 							"name": "directives",
 							"args": []interface{}{},
@@ -1267,8 +1283,8 @@ func TestIntrospection_ExposesDescriptionsOnTypesAndFields(t *testing.T) {
 				"name": "__Schema",
 				"description": `A GraphQL Schema defines the capabilities of a GraphQL
 server. It exposes all available types and directives on
-the server, as well as the entry points for query and
-mutation operations.`,
+the server, as well as the entry points for query, mutation,
+and subscription operations.`,
 				"fields": []interface{}{
 					map[string]interface{}{
 						"name":        "types",
@@ -1283,6 +1299,11 @@ mutation operations.`,
 						"description": "If this server supports mutation, the type that " +
 							"mutation operations will be rooted at.",
 					},
+					map[string]interface{}{
+						"name": "subscriptionType",
+						"description": "If this server supports subscription, the type that " +
+							"subscription operations will be rooted at.",
+					},
 					map[string]interface{}{
 						"name":        "directives",
 						"description": "A list of all directives supported by this server.",
@@ -1377,3 +1398,504 @@ func TestIntrospection_ExposesDescriptionsOnEnums(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestIntrospection_ExposesSpecifiedByURLOnScalars(t *testing.T) {
+
+	urlType := types.NewGraphQLScalarType(types.GraphQLScalarTypeConfig{
+		Name:           "URL",
+		SpecifiedByURL: "https://tools.ietf.org/html/rfc3986",
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+	})
+	queryRoot := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "QueryRoot",
+		Fields: types.GraphQLFieldConfigMap{
+			"homepage": &types.GraphQLFieldConfig{
+				Type: urlType,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: queryRoot,
+	})
+	if err != nil {
+		t.Fatalf("Error creating GraphQLSchema: %v", err.Error())
+	}
+	query := `
+      {
+        urlType: __type(name: "URL") {
+          specifiedByURL
+        }
+        stringType: __type(name: "String") {
+          specifiedByURL
+        }
+        queryRootType: __type(name: "QueryRoot") {
+          specifiedByURL
+        }
+      }
+    `
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"urlType": map[string]interface{}{
+				"specifiedByURL": "https://tools.ietf.org/html/rfc3986",
+			},
+			"stringType": map[string]interface{}{
+				"specifiedByURL": nil,
+			},
+			"queryRootType": map[string]interface{}{
+				"specifiedByURL": nil,
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestIntrospection_PrintsTheDefaultValuesOfInputFieldsAsLiterals(t *testing.T) {
+	sizeType := types.NewGraphQLEnumType(types.GraphQLEnumTypeConfig{
+		Name: "Size",
+		Values: types.GraphQLEnumValueConfigMap{
+			"SMALL": &types.GraphQLEnumValueConfig{
+				Value: 0,
+			},
+			"LARGE": &types.GraphQLEnumValueConfig{
+				Value: 1,
+			},
+		},
+	})
+	pointType := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "Point",
+		Fields: types.InputObjectConfigFieldMap{
+			"x": &types.InputObjectFieldConfig{
+				Type: types.GraphQLFloat,
+			},
+			"y": &types.InputObjectFieldConfig{
+				Type: types.GraphQLFloat,
+			},
+		},
+	})
+	myInputType := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "MyInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"name": &types.InputObjectFieldConfig{
+				Type:         types.GraphQLString,
+				DefaultValue: "Hello \"World\"",
+			},
+			"size": &types.InputObjectFieldConfig{
+				Type:         sizeType,
+				DefaultValue: 1,
+			},
+			"tags": &types.InputObjectFieldConfig{
+				Type:         types.NewGraphQLList(types.GraphQLString),
+				DefaultValue: []interface{}{"a", "b"},
+			},
+			"origin": &types.InputObjectFieldConfig{
+				Type:         pointType,
+				DefaultValue: map[string]interface{}{"x": 1.5, "y": -2},
+			},
+			"limit": &types.InputObjectFieldConfig{
+				Type: types.GraphQLInt,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"field": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"input": &types.GraphQLArgumentConfig{
+							Type: myInputType,
+						},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"inputFields": []interface{}{
+					map[string]interface{}{"name": "limit", "defaultValue": nil},
+					map[string]interface{}{"name": "name", "defaultValue": `"Hello \"World\""`},
+					map[string]interface{}{"name": "origin", "defaultValue": `{x: 1.5, y: -2.0}`},
+					map[string]interface{}{"name": "size", "defaultValue": "LARGE"},
+					map[string]interface{}{"name": "tags", "defaultValue": `["a", "b"]`},
+				},
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: `{ __type(name: "MyInput") { inputFields { name defaultValue } } }`,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestIntrospection_ExposesTheLocationsAndArgsOfDirectives(t *testing.T) {
+
+	cacheDirective := types.NewGraphQLDirective(&types.GraphQLDirective{
+		Name: "cache",
+		Args: []*types.GraphQLArgument{
+			&types.GraphQLArgument{
+				Name: "maxAge",
+				Type: types.GraphQLInt,
+			},
+		},
+		Locations:    []string{types.DirectiveLocationField},
+		IsRepeatable: true,
+	})
+	traceDirective := types.NewGraphQLDirective(&types.GraphQLDirective{
+		Name:        "trace",
+		Args:        []*types.GraphQLArgument{},
+		OnOperation: true,
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "QueryRoot",
+			Fields: types.GraphQLFieldConfigMap{
+				"onlyField": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+				},
+			},
+		}),
+		Directives: []*types.GraphQLDirective{
+			types.GraphQLIncludeDirective,
+			types.GraphQLSkipDirective,
+			cacheDirective,
+			traceDirective,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating GraphQLSchema: %v", err.Error())
+	}
+	query := `
+      {
+        __schema {
+          directives {
+            name
+            locations
+            args { name type { kind name ofType { kind name } } }
+            isRepeatable
+          }
+        }
+      }
+    `
+	ifArgs := []interface{}{
+		map[string]interface{}{
+			"name": "if",
+			"type": map[string]interface{}{
+				"kind": "NON_NULL",
+				"name": nil,
+				"ofType": map[string]interface{}{
+					"kind": "SCALAR",
+					"name": "Boolean",
+				},
+			},
+		},
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"__schema": map[string]interface{}{
+				"directives": []interface{}{
+					map[string]interface{}{
+						"name":         "include",
+						"locations":    []interface{}{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+						"args":         ifArgs,
+						"isRepeatable": false,
+					},
+					map[string]interface{}{
+						"name":         "skip",
+						"locations":    []interface{}{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+						"args":         ifArgs,
+						"isRepeatable": false,
+					},
+					map[string]interface{}{
+						"name":      "deprecated",
+						"locations": []interface{}{"FIELD_DEFINITION", "ENUM_VALUE"},
+						"args": []interface{}{
+							map[string]interface{}{
+								"name": "reason",
+								"type": map[string]interface{}{
+									"kind":   "SCALAR",
+									"name":   "String",
+									"ofType": nil,
+								},
+							},
+						},
+						"isRepeatable": false,
+					},
+					map[string]interface{}{
+						"name":      "specifiedBy",
+						"locations": []interface{}{"SCALAR"},
+						"args": []interface{}{
+							map[string]interface{}{
+								"name": "url",
+								"type": map[string]interface{}{
+									"kind": "NON_NULL",
+									"name": nil,
+									"ofType": map[string]interface{}{
+										"kind": "SCALAR",
+										"name": "String",
+									},
+								},
+							},
+						},
+						"isRepeatable": false,
+					},
+					map[string]interface{}{
+						"name":      "cache",
+						"locations": []interface{}{"FIELD"},
+						"args": []interface{}{
+							map[string]interface{}{
+								"name": "maxAge",
+								"type": map[string]interface{}{
+									"kind":   "SCALAR",
+									"name":   "Int",
+									"ofType": nil,
+								},
+							},
+						},
+						"isRepeatable": true,
+					},
+					map[string]interface{}{
+						"name":         "trace",
+						"locations":    []interface{}{"QUERY", "MUTATION", "SUBSCRIPTION"},
+						"args":         []interface{}{},
+						"isRepeatable": false,
+					},
+				},
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestIntrospection_ExposesTheTypesOfDirectiveArguments(t *testing.T) {
+
+	roleEnum := types.NewGraphQLEnumType(types.GraphQLEnumTypeConfig{
+		Name: "Role",
+		Values: types.GraphQLEnumValueConfigMap{
+			"ADMIN":  &types.GraphQLEnumValueConfig{},
+			"EDITOR": &types.GraphQLEnumValueConfig{},
+		},
+	})
+	authDirective := types.NewGraphQLDirective(&types.GraphQLDirective{
+		Name: "auth",
+		Args: []*types.GraphQLArgument{
+			&types.GraphQLArgument{
+				Name: "role",
+				Type: roleEnum,
+			},
+		},
+		Locations: []string{types.DirectiveLocationField},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "QueryRoot",
+			Fields: types.GraphQLFieldConfigMap{
+				"onlyField": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+				},
+			},
+		}),
+		Directives: []*types.GraphQLDirective{
+			types.GraphQLIncludeDirective,
+			types.GraphQLSkipDirective,
+			authDirective,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating GraphQLSchema: %v", err.Error())
+	}
+	if schema.GetType("Role") != roleEnum {
+		t.Fatalf("Expected the type map to contain Role, got %v", schema.GetType("Role"))
+	}
+	query := `
+      {
+        __type(name: "Role") {
+          kind
+          name
+        }
+      }
+    `
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"kind": "ENUM",
+				"name": "Role",
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+// The schema of the introspection cache tests, built by each test as the
+// introspection is memoized per schema.
+var introspectionCacheTestSDL = `
+      interface Node {
+        id: ID!
+      }
+
+      type Article implements Node {
+        id: ID!
+        title(format: Format = PLAIN): String
+        body: String @deprecated(reason: "Use content.")
+        tags: [String!]!
+      }
+
+      type Author implements Node {
+        id: ID!
+        name: String
+      }
+
+      union SearchResult = Article | Author
+
+      enum Format {
+        PLAIN
+        HTML @deprecated
+      }
+
+      input ArticleInput {
+        title: String!
+        tags: [String] = ["news"]
+      }
+
+      type Query {
+        node(id: ID!): Node
+        search(text: String, first: Int = 10): [SearchResult]
+      }
+
+      type Mutation {
+        publish(input: ArticleInput): Article
+      }
+`
+
+func TestIntrospection_IntrospectionResultIsTheResultOfTheIntrospectionQuery(t *testing.T) {
+	schema, err := types.BuildSchema(introspectionCacheTestSDL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: testutil.IntrospectionQuery,
+	})
+	if len(expected.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", expected.Errors)
+	}
+	result := schema.IntrospectionResult()
+	// the types and fields are listed in no particular order
+	if !reflect.DeepEqual(sortedByName(expected.Data), sortedByName(result.Data)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(sortedByName(expected.Data), sortedByName(result.Data)))
+	}
+	// each call returns a copy
+	result.Data.(map[string]interface{})["__schema"] = nil
+	if again := schema.IntrospectionResult(); !reflect.DeepEqual(sortedByName(expected.Data), sortedByName(again.Data)) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(sortedByName(expected.Data), sortedByName(again.Data)))
+	}
+}
+
+func TestIntrospection_CompletesTheIntrospectionOfASchemaOnce(t *testing.T) {
+	schema, err := types.BuildSchema(introspectionCacheTestSDL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ast := testutil.Parse(t, testutil.IntrospectionQuery)
+	execute := func() (*types.GraphQLResult, uint64) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		result := testutil.Execute(t, executor.ExecuteParams{
+			Schema: schema,
+			AST:    ast,
+		})
+		runtime.ReadMemStats(&after)
+		return result, after.Mallocs - before.Mallocs
+	}
+	first, firstAllocs := execute()
+	second, secondAllocs := execute()
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(first, second))
+	}
+	if secondAllocs*10 > firstAllocs {
+		t.Fatalf("Expected the second introspection to allocate far less than %v times, got %v", firstAllocs, secondAllocs)
+	}
+}
+
+func TestIntrospection_IntrospectsASchemaConcurrently(t *testing.T) {
+	schema, err := types.BuildSchema(introspectionCacheTestSDL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := schema.IntrospectionResult()
+	var wg sync.WaitGroup
+	results := make([]*types.GraphQLResult, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				results[i] = schema.IntrospectionResult()
+				return
+			}
+			results[i] = graphql(t, gql.GraphqlParams{
+				Schema:        schema,
+				RequestString: testutil.IntrospectionQuery,
+			})
+		}(i)
+	}
+	wg.Wait()
+	for _, result := range results {
+		if !reflect.DeepEqual(sortedByName(expected.Data), sortedByName(result.Data)) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(sortedByName(expected.Data), sortedByName(result.Data)))
+		}
+	}
+}
+
+// Returns a copy of an introspection result in which the lists of named
+// items, e.g. types and fields, are sorted by name.
+func sortedByName(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		sorted := map[string]interface{}{}
+		for key, item := range value {
+			sorted[key] = sortedByName(item)
+		}
+		return sorted
+	case []interface{}:
+		sorted := []interface{}{}
+		for _, item := range value {
+			sorted = append(sorted, sortedByName(item))
+		}
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, _ := sorted[i].(map[string]interface{})
+			b, _ := sorted[j].(map[string]interface{})
+			return fmt.Sprintf("%v", a["name"]) < fmt.Sprintf("%v", b["name"])
+		})
+		return sorted
+	}
+	return value
+}
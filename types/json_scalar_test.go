@@ -0,0 +1,119 @@
+package types_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestTypeSystem_JSONScalar_PassesValuesThrough(t *testing.T) {
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"settings": &types.GraphQLFieldConfig{
+					Type: types.GraphQLJSON,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return map[string]interface{}{
+							"theme": "dark",
+							"layout": map[string]interface{}{
+								"columns": []interface{}{1, 2.5, nil},
+								"compact": true,
+							},
+						}
+					},
+				},
+				"echo": &types.GraphQLFieldConfig{
+					Type: types.GraphQLJSON,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"value": &types.GraphQLArgumentConfig{
+							Type: types.GraphQLJSON,
+						},
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return p.Args["value"]
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	query := `{
+  settings
+  echo(value: {name: "cover", size: [640, 4.5], tags: [PUBLIC], extra: {hidden: false, owner: null}})
+}`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"settings": map[string]interface{}{
+				"theme": "dark",
+				"layout": map[string]interface{}{
+					"columns": []interface{}{1, 2.5, nil},
+					"compact": true,
+				},
+			},
+			"echo": map[string]interface{}{
+				"name": "cover",
+				"size": []interface{}{640, 4.5},
+				"tags": []interface{}{"PUBLIC"},
+				"extra": map[string]interface{}{
+					"hidden": false,
+					"owner":  nil,
+				},
+			},
+		},
+	}
+	result := gql.ExecuteString(schema, query)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestTypeSystem_JSONScalar_ResolvesTheVariablesOfLiterals(t *testing.T) {
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"echo": &types.GraphQLFieldConfig{
+					Type: types.GraphQLJSON,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"value": &types.GraphQLArgumentConfig{
+							Type: types.GraphQLJSON,
+						},
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return p.Args["value"]
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	query := `query Echo($name: String, $size: JSON, $missing: String) {
+  echo(value: {name: $name, size: [$size, 4.5], extra: {missing: $missing}})
+}`
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"echo": map[string]interface{}{
+				"name": "cover",
+				"size": []interface{}{640, 4.5},
+				"extra": map[string]interface{}{
+					"missing": nil,
+				},
+			},
+		},
+	}
+	result := gql.ExecuteString(schema, query, gql.WithVariables(map[string]interface{}{
+		"name": "cover",
+		"size": 640,
+	}))
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
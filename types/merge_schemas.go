@@ -0,0 +1,173 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/printer"
+)
+
+// MergeSchemas returns a new schema whose root types have the fields of the
+// root types of every given schema, named as those of the first schema
+// defining them, and whose types are those of every given schema.
+//
+// The schemas are not modified: their types are copied into the new schema,
+// along with their resolvers. A type found in several schemas must be
+// defined the same in each of them, as printed by PrintSchema, and is
+// resolved as in the first schema defining it. Types of the same name
+// defined differently, and root fields defined by several schemas, are an
+// error. The directives of the schemas are merged likewise.
+func MergeSchemas(schemas ...GraphQLSchema) (GraphQLSchema, error) {
+	if len(schemas) == 0 {
+		return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError("Must provide at least one schema to merge.")
+	}
+	b := &schemaBuilder{types: GraphQLTypeMap{}}
+	rootTypes := map[string]bool{}
+	config := GraphQLSchemaConfig{}
+	var err error
+	rootRefs := []struct {
+		merged   **GraphQLObjectType
+		rootType func(schema *GraphQLSchema) *GraphQLObjectType
+	}{
+		{&config.Query, (*GraphQLSchema).GetQueryType},
+		{&config.Mutation, (*GraphQLSchema).GetMutationType},
+		{&config.Subscription, (*GraphQLSchema).GetSubscriptionType},
+	}
+	for _, ref := range rootRefs {
+		*ref.merged, err = b.mergeRootTypes(schemas, ref.rootType, rootTypes)
+		if err != nil {
+			return GraphQLSchema{}, err
+		}
+	}
+
+	p := &schemaPrinter{}
+	names := []string{}
+	mergedTypes := GraphQLTypeMap{}
+	definitions := map[string]string{}
+	for _, schema := range schemas {
+		typeMap := schema.GetTypeMap()
+		schemaNames := []string{}
+		for name := range typeMap {
+			if !rootTypes[name] && !strings.HasPrefix(name, "__") {
+				schemaNames = append(schemaNames, name)
+			}
+		}
+		sort.Strings(schemaNames)
+		for _, name := range schemaNames {
+			definition := p.printedType(typeMap[name])
+			if existing, ok := mergedTypes[name]; ok {
+				if existing != typeMap[name] && definitions[name] != definition {
+					return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+						`Type "%v" is defined differently by several of the merged schemas.`, name))
+				}
+				continue
+			}
+			mergedTypes[name] = typeMap[name]
+			definitions[name] = definition
+			names = append(names, name)
+		}
+	}
+	// The types are copied so that the types of the same name found in
+	// several schemas are referred to as one.
+	interfaceFields := map[string]GraphQLFieldConfigMap{}
+	for _, name := range names {
+		b.types[name] = b.copyType(mergedTypes[name], nil, interfaceFields)
+	}
+	for name, fields := range interfaceFields {
+		for fieldName, field := range b.fieldConfigs(mergedTypes[name].(*GraphQLInterfaceType).GetFields()) {
+			fields[fieldName] = field
+		}
+	}
+	for _, name := range names {
+		config.Types = append(config.Types, b.types[name])
+	}
+
+	directives := map[string]*GraphQLDirective{}
+	mergedDirectives := []*GraphQLDirective{}
+	for _, schema := range schemas {
+		for _, directive := range schema.GetDirectives() {
+			if existing, ok := directives[directive.Name]; ok {
+				if existing != directive && p.directiveDefinition(existing) != p.directiveDefinition(directive) {
+					return GraphQLSchema{}, graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+						`Directive "@%v" is defined differently by several of the merged schemas.`, directive.Name))
+				}
+				continue
+			}
+			directives[directive.Name] = directive
+			mergedDirectives = append(mergedDirectives, directive)
+		}
+	}
+	config.Directives = b.copiedDirectives(mergedDirectives)
+	return NewGraphQLSchema(config)
+}
+
+// Prints the definition of a type, compared to that of the types of the
+// same name.
+func (p *schemaPrinter) printedType(ttype GraphQLType) string {
+	def := p.typeDefinition(ttype)
+	if def == nil {
+		return ""
+	}
+	printed, _ := printer.Print(def).(string)
+	return printed
+}
+
+// Returns the root type of the schemas given by rootType, with the fields and
+// interfaces of the root type of each schema, nil if none of the schemas has one. The names
+// of the root types of the schemas are added to rootTypes.
+func (b *schemaBuilder) mergeRootTypes(schemas []GraphQLSchema, rootType func(schema *GraphQLSchema) *GraphQLObjectType, rootTypes map[string]bool) (*GraphQLObjectType, error) {
+	var merged *GraphQLObjectType
+	schemaRootTypes := []*GraphQLObjectType{}
+	defined := map[string]bool{}
+	for i := range schemas {
+		schemaRootType := rootType(&schemas[i])
+		if schemaRootType == nil {
+			continue
+		}
+		if merged == nil {
+			// The fields and interfaces are copied once every type is
+			// merged, so that they refer to the merged types.
+			merged = NewGraphQLObjectType(GraphQLObjectTypeConfig{
+				Name:        schemaRootType.Name,
+				Description: schemaRootType.Description,
+				IsTypeOf:    schemaRootType.IsTypeOf,
+				Extensions:  schemaRootType.Extensions,
+				Interfaces: GraphQLInterfacesThunk(func() []*GraphQLInterfaceType {
+					ifaces := []*GraphQLInterfaceType{}
+					implemented := map[string]bool{}
+					for _, schemaRootType := range schemaRootTypes {
+						for _, iface := range schemaRootType.GetInterfaces() {
+							if !implemented[iface.Name] {
+								implemented[iface.Name] = true
+								ifaces = append(ifaces, b.types[iface.Name].(*GraphQLInterfaceType))
+							}
+						}
+					}
+					return ifaces
+				}),
+				Fields: GraphQLFieldConfigMapThunk(func() GraphQLFieldConfigMap {
+					fields := GraphQLFieldConfigMap{}
+					for _, schemaRootType := range schemaRootTypes {
+						for name, field := range b.fieldConfigs(schemaRootType.GetFields()) {
+							fields[name] = field
+						}
+					}
+					return fields
+				}),
+			})
+		}
+		for name := range schemaRootType.GetFields() {
+			if defined[name] {
+				return nil, graphqlerrors.NewGraphQLFormattedError(fmt.Sprintf(
+					`Field "%v.%v" is defined by several of the merged schemas.`, merged.Name, name))
+			}
+			defined[name] = true
+		}
+		rootTypes[schemaRootType.Name] = true
+		b.types[schemaRootType.Name] = merged
+		schemaRootTypes = append(schemaRootTypes, schemaRootType)
+	}
+	return merged, nil
+}
@@ -0,0 +1,200 @@
+package types_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+var mergeSchemasUserType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "User",
+	Fields: types.GraphQLFieldConfigMap{
+		"name": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+	},
+})
+
+var mergeSchemasUsersSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"viewer": &types.GraphQLFieldConfig{
+				Type: mergeSchemasUserType,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return map[string]interface{}{"name": "John"}
+				},
+			},
+		},
+	}),
+})
+
+var mergeSchemasPostsSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"author": &types.GraphQLFieldConfig{
+				Type: mergeSchemasUserType,
+				Resolve: func(p types.GQLFRParams) interface{} {
+					return map[string]interface{}{"name": "Jane"}
+				},
+			},
+		},
+	}),
+})
+
+func TestMergeSchemas_MergesTheQueryFieldsOfTheSchemas(t *testing.T) {
+	merged, err := types.MergeSchemas(mergeSchemasUsersSchema, mergeSchemasPostsSchema)
+	if err != nil {
+		t.Fatalf("Error merging schemas: %v", err)
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"viewer": map[string]interface{}{"name": "John"},
+			"author": map[string]interface{}{"name": "Jane"},
+		},
+	}
+	result := gql.ExecuteString(merged, `{ viewer { name } author { name } }`)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if _, ok := mergeSchemasUsersSchema.GetQueryType().GetFields()["author"]; ok {
+		t.Fatalf("Expected the merged schema to leave the query type of the schemas unmodified")
+	}
+}
+
+func TestMergeSchemas_MergesTheTypesDefinedTheSameBySeveralSchemas(t *testing.T) {
+	otherUserType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "User",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	posts, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"author": &types.GraphQLFieldConfig{
+					Type: otherUserType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return map[string]interface{}{"name": "Jane"}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	merged, err := types.MergeSchemas(mergeSchemasUsersSchema, posts)
+	if err != nil {
+		t.Fatalf("Error merging schemas: %v", err)
+	}
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"viewer": map[string]interface{}{"name": "John"},
+			"author": map[string]interface{}{"name": "Jane"},
+		},
+	}
+	result := gql.ExecuteString(merged, `{ viewer { name } author { name } }`)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMergeSchemas_KeepsTheInterfacesOfTheRootTypes(t *testing.T) {
+	nodeType := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Node",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLID,
+			},
+		},
+	})
+	isTypeOf := func(value interface{}, info types.GraphQLResolveInfo) bool {
+		return true
+	}
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name:       "Query",
+			Interfaces: []*types.GraphQLInterfaceType{nodeType},
+			IsTypeOf:   isTypeOf,
+			Fields: types.GraphQLFieldConfigMap{
+				"id": &types.GraphQLFieldConfig{
+					Type: types.GraphQLID,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	merged, err := types.MergeSchemas(schema, mergeSchemasUsersSchema)
+	if err != nil {
+		t.Fatalf("Error merging schemas: %v", err)
+	}
+	queryType := merged.GetQueryType()
+	ifaces := queryType.GetInterfaces()
+	if len(ifaces) != 1 || ifaces[0] != merged.GetType("Node") {
+		t.Fatalf("Expected the merged query type to implement Node, got: %v", ifaces)
+	}
+	if queryType.IsTypeOf == nil {
+		t.Fatalf("Expected the merged query type to keep its IsTypeOf function")
+	}
+}
+
+func TestMergeSchemas_RejectsConflictingDefinitions(t *testing.T) {
+	otherUserType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "User",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"email": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	posts, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"author": &types.GraphQLFieldConfig{
+					Type: otherUserType,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	_, err = types.MergeSchemas(mergeSchemasUsersSchema, posts)
+	expected := `Type "User" is defined differently by several of the merged schemas.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got: %v", expected, err)
+	}
+
+	viewers, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"viewer": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	_, err = types.MergeSchemas(mergeSchemasUsersSchema, viewers)
+	expected = `Field "Query.viewer" is defined by several of the merged schemas.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got: %v", expected, err)
+	}
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// OrderedMap is an object of the data of a result executed with
+// OrderedResult, its keys are the response names of the fields, aliases
+// included, in the order of the query. It is marshaled to a JSON object
+// with its keys in that order.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{
+		keys:   []string{},
+		values: map[string]interface{}{},
+	}
+}
+
+// Set sets the value of a key, a new key is added after the existing ones.
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Keys returns the keys in their order.
+func (m *OrderedMap) Keys() []string {
+	return append([]string{}, m.keys...)
+}
+
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// Map returns the values by key, nested ordered maps are converted as well,
+// e.g. to compare the data with that of an unordered result.
+func (m *OrderedMap) Map() map[string]interface{} {
+	values := map[string]interface{}{}
+	for key, value := range m.values {
+		values[key] = unorderedValue(value)
+	}
+	return values
+}
+
+func unorderedValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *OrderedMap:
+		return value.Map()
+	case []interface{}:
+		values := []interface{}{}
+		for _, item := range value {
+			values = append(values, unorderedValue(item))
+		}
+		return values
+	}
+	return value
+}
+
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		encodedValue, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,412 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/printer"
+)
+
+// PrintOptions are the options of PrintSchema.
+type PrintOptions struct {
+	// Prints the types, fields, arguments, enum values and directives in the
+	// order of their definition rather than sorted by name. The order of the
+	// definitions is known for the schemas built by BuildSchema, the types
+	// and fields whose order is not known, e.g. those of a schema defined in
+	// Go, are printed sorted by name after the others.
+	PreserveOrder bool
+	// Leaves out the descriptions of the types, fields, arguments, enum
+	// values and directives.
+	OmitDescriptions bool
+}
+
+// PrintSchema prints a schema in the schema definition language, leaving
+// out the built-in scalars and directives and the introspection types. The
+// `schema` definition is only printed when a root type is not named Query,
+// Mutation or Subscription. By default the definitions are sorted by name,
+// so that a schema is always printed the same, e.g. for snapshot tests.
+func PrintSchema(schema GraphQLSchema, options PrintOptions) string {
+	p := &schemaPrinter{
+		options: options,
+		order:   schema.definitionOrder,
+	}
+	parts := []string{}
+	if def := p.schemaDefinition(schema); def != "" {
+		parts = append(parts, def)
+	}
+	for _, directive := range p.directives(schema.GetDirectives()) {
+		parts = append(parts, p.directiveDefinition(directive))
+	}
+
+	typeNames := []string{}
+	for name := range schema.GetTypeMap() {
+		if strings.HasPrefix(name, "__") || isBuiltInScalarName(name) {
+			continue
+		}
+		typeNames = append(typeNames, name)
+	}
+	definitions := []ast.Node{}
+	for _, name := range p.sorted("", typeNames) {
+		if def := p.typeDefinition(schema.GetType(name)); def != nil {
+			definitions = append(definitions, def)
+		}
+	}
+	if len(definitions) > 0 {
+		printed, _ := printer.Print(ast.NewDocument(&ast.Document{Definitions: definitions})).(string)
+		parts = append(parts, strings.TrimSuffix(printed, "\n"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// The names of the definitions of a schema built from a document, in the
+// order of the document: the names of the types under "", those of the
+// fields, input fields or enum values of a type under its name, and those
+// of the arguments of a field under "Type.field".
+type definitionOrder map[string][]string
+
+func documentDefinitionOrder(doc *ast.Document) definitionOrder {
+	order := definitionOrder{}
+	addFields := func(typeName string, fields []*ast.FieldDefinition) {
+		for _, field := range fields {
+			if field.Name == nil {
+				continue
+			}
+			order[typeName] = append(order[typeName], field.Name.Value)
+			for _, arg := range field.Arguments {
+				if arg.Name != nil {
+					key := typeName + "." + field.Name.Value
+					order[key] = append(order[key], arg.Name.Value)
+				}
+			}
+		}
+	}
+	for _, def := range doc.Definitions {
+		name := typeDefinitionName(def)
+		if name == "" {
+			continue
+		}
+		order[""] = append(order[""], name)
+		switch def := def.(type) {
+		case *ast.ObjectTypeDefinition:
+			addFields(name, def.Fields)
+		case *ast.InterfaceTypeDefinition:
+			addFields(name, def.Fields)
+		case *ast.EnumTypeDefinition:
+			for _, value := range def.Values {
+				if value.Name != nil {
+					order[name] = append(order[name], value.Name.Value)
+				}
+			}
+		case *ast.InputObjectTypeDefinition:
+			for _, field := range def.Fields {
+				if field.Name != nil {
+					order[name] = append(order[name], field.Name.Value)
+				}
+			}
+		}
+	}
+	return order
+}
+
+type schemaPrinter struct {
+	options PrintOptions
+	order   definitionOrder
+}
+
+// Returns the names in the order of their definitions recorded under the
+// key when the order is preserved, followed by the other names sorted.
+func (p *schemaPrinter) sorted(key string, names []string) []string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	if !p.options.PreserveOrder || len(p.order[key]) == 0 {
+		return sorted
+	}
+	remaining := map[string]bool{}
+	for _, name := range names {
+		remaining[name] = true
+	}
+	ordered := []string{}
+	for _, name := range p.order[key] {
+		if remaining[name] {
+			ordered = append(ordered, name)
+			delete(remaining, name)
+		}
+	}
+	for _, name := range sorted {
+		if remaining[name] {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+func (p *schemaPrinter) description(description string) *ast.StringValue {
+	if description == "" || p.options.OmitDescriptions {
+		return nil
+	}
+	return ast.NewStringValue(&ast.StringValue{Value: description})
+}
+
+func (p *schemaPrinter) schemaDefinition(schema GraphQLSchema) string {
+	roots := []struct {
+		operation string
+		rootType  *GraphQLObjectType
+	}{
+		{"query", schema.GetQueryType()},
+		{"mutation", schema.GetMutationType()},
+		{"subscription", schema.GetSubscriptionType()},
+	}
+	conventional := true
+	lines := []string{}
+	for _, root := range roots {
+		if root.rootType == nil {
+			continue
+		}
+		if root.rootType.Name != strings.Title(root.operation) {
+			conventional = false
+		}
+		lines = append(lines, fmt.Sprintf("  %v: %v", root.operation, root.rootType.Name))
+	}
+	if conventional {
+		return ""
+	}
+	return "schema {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+// Returns the directives which are not built-in, in the order of the
+// schema's directives when the order is preserved.
+func (p *schemaPrinter) directives(directives []*GraphQLDirective) []*GraphQLDirective {
+	printed := []*GraphQLDirective{}
+	for _, directive := range directives {
+		if directive != nil && !isBuiltInDirective(directive) {
+			printed = append(printed, directive)
+		}
+	}
+	if !p.options.PreserveOrder {
+		sort.Slice(printed, func(i, j int) bool {
+			return printed[i].Name < printed[j].Name
+		})
+	}
+	return printed
+}
+
+func (p *schemaPrinter) directiveDefinition(directive *GraphQLDirective) string {
+	str := ""
+	if description := p.description(directive.Description); description != nil {
+		str = printDescription(description.Value) + "\n"
+	}
+	str += "directive @" + directive.Name
+	// the arguments of a directive are defined in order
+	directiveArgs := append([]*GraphQLArgument{}, directive.Args...)
+	if !p.options.PreserveOrder {
+		sort.Slice(directiveArgs, func(i, j int) bool {
+			return directiveArgs[i].Name < directiveArgs[j].Name
+		})
+	}
+	args := []string{}
+	for _, arg := range directiveArgs {
+		printed, _ := printer.Print(p.inputValueDefinition(arg.Name, arg.Type, arg.DefaultValue, arg.Description)).(string)
+		args = append(args, printed)
+	}
+	if len(args) > 0 {
+		str += "(" + strings.Join(args, ", ") + ")"
+	}
+	if directive.IsRepeatable {
+		str += " repeatable"
+	}
+	return str + " on " + strings.Join(directive.GetLocations(), " | ")
+}
+
+// Prints a description as the printer prints those of the definitions.
+func printDescription(description string) string {
+	escaped := strings.Replace(description, `"""`, `\"""`, -1)
+	if strings.Contains(description, "\n") || strings.HasSuffix(description, `"`) || strings.HasSuffix(description, `\`) {
+		return `"""` + "\n" + escaped + "\n" + `"""`
+	}
+	return `"""` + escaped + `"""`
+}
+
+func (p *schemaPrinter) typeDefinition(ttype GraphQLType) ast.Node {
+	switch ttype := ttype.(type) {
+	case *GraphQLScalarType:
+		directives := []*ast.Directive{}
+		if ttype.SpecifiedByURL != "" {
+			directives = append(directives, directiveAST(GraphQLSpecifiedByDirective.Name, "url", ttype.SpecifiedByURL))
+		}
+		return ast.NewScalarTypeDefinition(&ast.ScalarTypeDefinition{
+			Description: p.description(ttype.Description),
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name}),
+			Directives:  directives,
+		})
+	case *GraphQLObjectType:
+		interfaces := []*ast.NamedType{}
+		for _, iface := range ttype.GetInterfaces() {
+			interfaces = append(interfaces, typeAST(iface).(*ast.NamedType))
+		}
+		return ast.NewObjectTypeDefinition(&ast.ObjectTypeDefinition{
+			Description: p.description(ttype.Description),
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name}),
+			Interfaces:  interfaces,
+			Fields:      p.fieldDefinitions(ttype.Name, ttype.GetFields()),
+		})
+	case *GraphQLInterfaceType:
+		return ast.NewInterfaceTypeDefinition(&ast.InterfaceTypeDefinition{
+			Description: p.description(ttype.Description),
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name}),
+			Fields:      p.fieldDefinitions(ttype.Name, ttype.GetFields()),
+		})
+	case *GraphQLUnionType:
+		members := []*ast.NamedType{}
+		for _, member := range ttype.GetPossibleTypes() {
+			members = append(members, typeAST(member).(*ast.NamedType))
+		}
+		return ast.NewUnionTypeDefinition(&ast.UnionTypeDefinition{
+			Description: p.description(ttype.Description),
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name}),
+			Types:       members,
+		})
+	case *GraphQLEnumType:
+		byName := map[string]*GraphQLEnumValueDefinition{}
+		names := []string{}
+		for _, value := range ttype.GetValues() {
+			byName[value.Name] = value
+			names = append(names, value.Name)
+		}
+		values := []*ast.EnumValueDefinition{}
+		for _, name := range p.sorted(ttype.Name, names) {
+			values = append(values, ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+				Description: p.description(byName[name].Description),
+				Name:        ast.NewName(&ast.Name{Value: name}),
+				Directives:  deprecatedDirectives(byName[name].DeprecationReason),
+			}))
+		}
+		return ast.NewEnumTypeDefinition(&ast.EnumTypeDefinition{
+			Description: p.description(ttype.Description),
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name}),
+			Values:      values,
+		})
+	case *GraphQLInputObjectType:
+		fieldMap := ttype.GetFields()
+		names := []string{}
+		for name := range fieldMap {
+			names = append(names, name)
+		}
+		fields := []*ast.InputValueDefinition{}
+		for _, name := range p.sorted(ttype.Name, names) {
+			field := fieldMap[name]
+			fields = append(fields, p.inputValueDefinition(field.Name, field.Type, field.DefaultValue, field.Description))
+		}
+		return ast.NewInputObjectTypeDefinition(&ast.InputObjectTypeDefinition{
+			Description: p.description(ttype.Description),
+			Name:        ast.NewName(&ast.Name{Value: ttype.Name}),
+			Fields:      fields,
+		})
+	}
+	return nil
+}
+
+func (p *schemaPrinter) fieldDefinitions(typeName string, fieldMap GraphQLFieldDefinitionMap) []*ast.FieldDefinition {
+	names := []string{}
+	for name := range fieldMap {
+		names = append(names, name)
+	}
+	fields := []*ast.FieldDefinition{}
+	for _, name := range p.sorted(typeName, names) {
+		field := fieldMap[name]
+		fields = append(fields, ast.NewFieldDefinition(&ast.FieldDefinition{
+			Description: p.description(field.Description),
+			Name:        ast.NewName(&ast.Name{Value: name}),
+			Arguments:   p.arguments(typeName+"."+name, field.Args),
+			Type:        typeAST(field.Type),
+			Directives:  deprecatedDirectives(field.DeprecationReason),
+		}))
+	}
+	return fields
+}
+
+func (p *schemaPrinter) arguments(key string, args []*GraphQLArgument) []*ast.InputValueDefinition {
+	byName := map[string]*GraphQLArgument{}
+	names := []string{}
+	for _, arg := range args {
+		byName[arg.Name] = arg
+		names = append(names, arg.Name)
+	}
+	defs := []*ast.InputValueDefinition{}
+	for _, name := range p.sorted(key, names) {
+		arg := byName[name]
+		defs = append(defs, p.inputValueDefinition(arg.Name, arg.Type, arg.DefaultValue, arg.Description))
+	}
+	return defs
+}
+
+func (p *schemaPrinter) inputValueDefinition(name string, ttype GraphQLInputType, defaultValue interface{}, description string) *ast.InputValueDefinition {
+	return ast.NewInputValueDefinition(&ast.InputValueDefinition{
+		Description:  p.description(description),
+		Name:         ast.NewName(&ast.Name{Value: name}),
+		Type:         typeAST(ttype),
+		DefaultValue: astFromValue(defaultValue, ttype),
+	})
+}
+
+// Returns the @deprecated directive of a deprecated field or enum value, its
+// reason left out when it is the default reason.
+func deprecatedDirectives(reason string) []*ast.Directive {
+	switch reason {
+	case "":
+		return []*ast.Directive{}
+	case DefaultDeprecationReason:
+		return []*ast.Directive{
+			ast.NewDirective(&ast.Directive{
+				Name:      ast.NewName(&ast.Name{Value: GraphQLDeprecatedDirective.Name}),
+				Arguments: []*ast.Argument{},
+			}),
+		}
+	}
+	return []*ast.Directive{directiveAST(GraphQLDeprecatedDirective.Name, "reason", reason)}
+}
+
+func directiveAST(name string, argName string, value string) *ast.Directive {
+	return ast.NewDirective(&ast.Directive{
+		Name: ast.NewName(&ast.Name{Value: name}),
+		Arguments: []*ast.Argument{
+			ast.NewArgument(&ast.Argument{
+				Name:  ast.NewName(&ast.Name{Value: argName}),
+				Value: ast.NewStringValue(&ast.StringValue{Value: value}),
+			}),
+		},
+	})
+}
+
+// Returns the type reference of a type, the inverse of TypeFromAST.
+func typeAST(ttype GraphQLType) ast.Type {
+	switch ttype := ttype.(type) {
+	case *GraphQLList:
+		return ast.NewListType(&ast.ListType{Type: typeAST(ttype.OfType)})
+	case *GraphQLNonNull:
+		return ast.NewNonNullType(&ast.NonNullType{Type: typeAST(ttype.OfType)})
+	}
+	return ast.NewNamedType(&ast.NamedType{
+		Name: ast.NewName(&ast.Name{Value: ttype.GetName()}),
+	})
+}
+
+func isBuiltInScalarName(name string) bool {
+	switch name {
+	case GraphQLString.Name, GraphQLInt.Name, GraphQLFloat.Name, GraphQLBoolean.Name, GraphQLID.Name:
+		return true
+	}
+	return false
+}
+
+func isBuiltInDirective(directive *GraphQLDirective) bool {
+	switch directive {
+	case GraphQLIncludeDirective, GraphQLSkipDirective, GraphQLDeprecatedDirective, GraphQLSpecifiedByDirective:
+		return true
+	}
+	return false
+}
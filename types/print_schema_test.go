@@ -0,0 +1,184 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+const printSchemaTestSDL = `"""The root of the queries."""
+type Query {
+  """The latest articles."""
+  feed(limit: Int = 10, after: ID): [Article]
+  article(id: ID!): Article
+}
+
+type Article implements Node {
+  title: String
+  id: ID!
+  headline: String @deprecated(reason: "Use title.")
+  status: Status
+}
+
+interface Node {
+  id: ID!
+}
+
+enum Status {
+  PUBLISHED
+  DRAFT @deprecated
+}
+
+input ArticleFilter {
+  status: Status = PUBLISHED
+  author: String
+}
+
+union SearchResult = Article
+
+scalar URL
+`
+
+func TestPrintSchema_PrintsTheDefinitionsSortedByName(t *testing.T) {
+	schema, err := types.BuildSchema(printSchemaTestSDL)
+	if err != nil {
+		t.Fatalf("Error building schema: %v", err)
+	}
+	expected := `type Article implements Node {
+  headline: String @deprecated(reason: "Use title.")
+  id: ID!
+  status: Status
+  title: String
+}
+
+input ArticleFilter {
+  author: String
+  status: Status = PUBLISHED
+}
+
+interface Node {
+  id: ID!
+}
+
+"""The root of the queries."""
+type Query {
+  article(id: ID!): Article
+  """The latest articles."""
+  feed(after: ID, limit: Int = 10): [Article]
+}
+
+union SearchResult = Article
+
+enum Status {
+  DRAFT @deprecated
+  PUBLISHED
+}
+
+scalar URL
+`
+	if printed := types.PrintSchema(schema, types.PrintOptions{}); printed != expected {
+		t.Fatalf("Unexpected schema, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
+
+func TestPrintSchema_PrintsTheDefinitionsInTheirOrder(t *testing.T) {
+	schema, err := types.BuildSchema(printSchemaTestSDL)
+	if err != nil {
+		t.Fatalf("Error building schema: %v", err)
+	}
+	expected := `type Query {
+  feed(limit: Int = 10, after: ID): [Article]
+  article(id: ID!): Article
+}
+
+type Article implements Node {
+  title: String
+  id: ID!
+  headline: String @deprecated(reason: "Use title.")
+  status: Status
+}
+
+interface Node {
+  id: ID!
+}
+
+enum Status {
+  PUBLISHED
+  DRAFT @deprecated
+}
+
+input ArticleFilter {
+  status: Status = PUBLISHED
+  author: String
+}
+
+union SearchResult = Article
+
+scalar URL
+`
+	options := types.PrintOptions{
+		PreserveOrder:    true,
+		OmitDescriptions: true,
+	}
+	printed := types.PrintSchema(schema, options)
+	if printed != expected {
+		t.Fatalf("Unexpected schema, Diff: %v", testutil.Diff(expected, printed))
+	}
+
+	// the printed schema builds the same schema
+	rebuilt, err := types.BuildSchema(printed)
+	if err != nil {
+		t.Fatalf("Error building printed schema: %v", err)
+	}
+	if reprinted := types.PrintSchema(rebuilt, options); reprinted != expected {
+		t.Fatalf("Unexpected schema, Diff: %v", testutil.Diff(expected, reprinted))
+	}
+}
+
+func TestPrintSchema_PrintsCustomDirectivesAndRootTypes(t *testing.T) {
+	queryRoot := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "QueryRoot",
+		Fields: types.GraphQLFieldConfigMap{
+			"hello": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: queryRoot,
+		Directives: []*types.GraphQLDirective{
+			types.GraphQLIncludeDirective,
+			types.GraphQLSkipDirective,
+			types.NewGraphQLDirective(&types.GraphQLDirective{
+				Name:        "cache",
+				Description: "Caches the field.",
+				Args: []*types.GraphQLArgument{
+					&types.GraphQLArgument{
+						Name: "maxAge",
+						Type: types.NewGraphQLNonNull(types.GraphQLInt),
+					},
+				},
+				Locations:    []string{types.DirectiveLocationField, types.DirectiveLocationFragmentSpread},
+				IsRepeatable: true,
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating schema: %v", err)
+	}
+	expected := `schema {
+  query: QueryRoot
+}
+
+"""Caches the field."""
+directive @cache(maxAge: Int!) repeatable on FIELD | FRAGMENT_SPREAD
+
+type QueryRoot {
+  hello: String
+}
+`
+	if printed := types.PrintSchema(schema, types.PrintOptions{}); printed != expected {
+		t.Fatalf("Unexpected schema, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
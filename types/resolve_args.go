@@ -0,0 +1,61 @@
+package types
+
+// HasArg reports whether the argument was given to the field, or has a
+// default value, even if its value is null.
+func (p GQLFRParams) HasArg(name string) bool {
+	_, ok := p.Args[name]
+	return ok
+}
+
+// Arg returns the value of the argument, ok is false if the argument was not
+// given or is null.
+func (p GQLFRParams) Arg(name string) (value interface{}, ok bool) {
+	value = p.Args[name]
+	return value, value != nil
+}
+
+// IntArg returns the value of the argument as an int, converted as by the
+// Int scalar, e.g. from an int64 or a whole float64. ok is false if the
+// argument was not given, is null or cannot be converted, e.g. a list or a
+// value outside of the 32-bit range.
+func (p GQLFRParams) IntArg(name string) (value int, ok bool) {
+	arg, ok := p.Arg(name)
+	if !ok || !isScalarArg(arg) {
+		return 0, false
+	}
+	value, ok = coerceInt(arg).(int)
+	return value, ok
+}
+
+// StringArg returns the value of the argument as a string, converted as by
+// the String scalar, e.g. from an int. ok is false if the argument was not
+// given, is null or is not a scalar value, e.g. an input object.
+func (p GQLFRParams) StringArg(name string) (value string, ok bool) {
+	arg, ok := p.Arg(name)
+	if !ok || !isScalarArg(arg) {
+		return "", false
+	}
+	return coerceString(arg).(string), true
+}
+
+// BoolArg returns the value of the argument as a bool, converted as by the
+// Boolean scalar, e.g. from a non-zero int. ok is false if the argument was
+// not given, is null or is not a scalar value.
+func (p GQLFRParams) BoolArg(name string) (value bool, ok bool) {
+	arg, ok := p.Arg(name)
+	if !ok || !isScalarArg(arg) {
+		return false, false
+	}
+	value, ok = coerceBool(arg).(bool)
+	return value, ok
+}
+
+// Reports whether an argument value is one the built-in scalars convert,
+// rather than format or default, e.g. a list of ints.
+func isScalarArg(value interface{}) bool {
+	switch value.(type) {
+	case bool, string, int, int32, int64, float32, float64:
+		return true
+	}
+	return false
+}
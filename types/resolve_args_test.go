@@ -0,0 +1,71 @@
+package types_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestResolveArgs_ReturnsTypedArguments(t *testing.T) {
+	type typedArgs struct {
+		has                      bool
+		width                    int
+		widthOK                  bool
+		label                    string
+		labelOK                  bool
+		square, squareOK, tagsOK bool
+	}
+	var args typedArgs
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"pic": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Args: types.GraphQLFieldConfigArgumentMap{
+						"width":  &types.GraphQLArgumentConfig{Type: types.GraphQLInt},
+						"label":  &types.GraphQLArgumentConfig{Type: types.GraphQLString},
+						"square": &types.GraphQLArgumentConfig{Type: types.GraphQLBoolean},
+						"tags":   &types.GraphQLArgumentConfig{Type: types.NewGraphQLList(types.GraphQLString)},
+					},
+					Resolve: func(p types.GQLFRParams) interface{} {
+						args = typedArgs{has: p.HasArg("width")}
+						args.width, args.widthOK = p.IntArg("width")
+						args.label, args.labelOK = p.StringArg("label")
+						args.square, args.squareOK = p.BoolArg("square")
+						_, args.tagsOK = p.StringArg("tags")
+						return "pic"
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	tests := map[string]typedArgs{
+		`{ pic }`: {},
+		`{ pic(width: 640, label: "cover", square: true, tags: ["a"]) }`: {
+			has:      true,
+			width:    640,
+			widthOK:  true,
+			label:    "cover",
+			labelOK:  true,
+			square:   true,
+			squareOK: true,
+		},
+	}
+	for query, expected := range tests {
+		result := gql.ExecuteString(schema, query)
+		if len(result.Errors) > 0 {
+			t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+		}
+		if !reflect.DeepEqual(expected, args) {
+			t.Fatalf("Unexpected arguments for %q, Diff: %v", query, testutil.Diff(expected, args))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"github.com/chris-ramon/graphql-go/language/ast"
+)
+
+// FieldDefinition returns the definition of the field being resolved, e.g.
+// to read its Extensions, nil for a meta-field such as `__typename`.
+func (info GraphQLResolveInfo) FieldDefinition() *GraphQLFieldDefinition {
+	var fields GraphQLFieldDefinitionMap
+	switch parentType := info.ParentType.(type) {
+	case *GraphQLObjectType:
+		fields = parentType.GetFields()
+	case *GraphQLInterfaceType:
+		fields = parentType.GetFields()
+	}
+	return fields[info.FieldName]
+}
+
+// FieldNames returns the names of the sub-fields requested under the field,
+// without duplicates, in the order of the document, e.g. to select only the
+// requested columns of a table. See SubFields.
+func (info GraphQLResolveInfo) FieldNames() []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, field := range info.SubFields() {
+		if field.Name == nil || seen[field.Name.Value] {
+			continue
+		}
+		seen[field.Name.Value] = true
+		names = append(names, field.Name.Value)
+	}
+	return names
+}
+
+// SubFields returns the ASTs of the sub-fields requested under the field, in
+// the order of the document. Fragments are expanded and the fields excluded
+// by @skip or @include are left out. The fragments of a type condition the
+// return type does not satisfy are left out, unless the return type is an
+// abstract type as its runtime type is not known yet.
+func (info GraphQLResolveInfo) SubFields() []*ast.Field {
+	fields := []*ast.Field{}
+	visitedFragments := map[string]bool{}
+	for _, fieldAST := range info.FieldASTs {
+		if fieldAST != nil {
+			fields = info.collectSubFields(fieldAST.SelectionSet, fields, visitedFragments)
+		}
+	}
+	return fields
+}
+
+func (info GraphQLResolveInfo) collectSubFields(selectionSet *ast.SelectionSet, fields []*ast.Field, visitedFragments map[string]bool) []*ast.Field {
+	if selectionSet == nil {
+		return fields
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if info.isIncluded(selection.Directives) {
+				fields = append(fields, selection)
+			}
+		case *ast.InlineFragment:
+			if info.isIncluded(selection.Directives) && info.satisfiesTypeCondition(selection.TypeCondition) {
+				fields = info.collectSubFields(selection.SelectionSet, fields, visitedFragments)
+			}
+		case *ast.FragmentSpread:
+			if selection.Name == nil || visitedFragments[selection.Name.Value] || !info.isIncluded(selection.Directives) {
+				continue
+			}
+			visitedFragments[selection.Name.Value] = true
+			fragment, ok := info.Fragments[selection.Name.Value].(*ast.FragmentDefinition)
+			if ok && info.satisfiesTypeCondition(fragment.TypeCondition) {
+				fields = info.collectSubFields(fragment.SelectionSet, fields, visitedFragments)
+			}
+		}
+	}
+	return fields
+}
+
+func (info GraphQLResolveInfo) isIncluded(directives []*ast.Directive) bool {
+	return ShouldIncludeNode(directives, info.VariableValues)
+}
+
+// Reports whether the fragments of the type condition apply to the return
+// type, they do for an abstract return type as its runtime type is not known
+// yet.
+func (info GraphQLResolveInfo) satisfiesTypeCondition(condition *ast.NamedType) bool {
+	objectType, ok := GetNamedType(info.ReturnType).(*GraphQLObjectType)
+	if !ok {
+		return true
+	}
+	return DoesFragmentConditionMatch(info.Schema, condition, objectType)
+}
@@ -0,0 +1,84 @@
+package types_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestResolveInfo_FieldNamesListsTheRequestedSubFields(t *testing.T) {
+	var fieldNames []string
+	var responseNames []string
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"body": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"article": &types.GraphQLFieldConfig{
+					Type: articleType,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						fieldNames = p.Info.FieldNames()
+						responseNames = []string{}
+						for _, field := range p.Info.SubFields() {
+							responseName := field.Name.Value
+							if field.Alias != nil {
+								responseName = field.Alias.Value
+							}
+							responseNames = append(responseNames, responseName)
+						}
+						return map[string]interface{}{"id": "1", "title": "GraphQL"}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	query := `
+      query Article($withBody: Boolean) {
+        article {
+          ...articleFields
+          headline: title
+          body @include(if: $withBody)
+        }
+      }
+
+      fragment articleFields on Article {
+        id
+        title
+      }
+	`
+	result := graphql(t, gql.GraphqlParams{
+		Schema:         schema,
+		RequestString:  query,
+		VariableValues: map[string]interface{}{"withBody": false},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	expectedFieldNames := []string{"id", "title"}
+	if !reflect.DeepEqual(expectedFieldNames, fieldNames) {
+		t.Fatalf("Unexpected field names, Diff: %v", testutil.Diff(expectedFieldNames, fieldNames))
+	}
+	expectedResponseNames := []string{"id", "title", "headline"}
+	if !reflect.DeepEqual(expectedResponseNames, responseNames) {
+		t.Fatalf("Unexpected sub-fields, Diff: %v", testutil.Diff(expectedResponseNames, responseNames))
+	}
+}
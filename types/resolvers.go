@@ -0,0 +1,109 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	argsType    = reflect.TypeOf(map[string]interface{}{})
+)
+
+// BindResolvers sets the resolve functions of the fields of objectType from
+// the methods of resolvers, easing the migration of gqlgen-style resolver
+// structs. Each field is bound to the method named after it, compared
+// case-insensitively, e.g. `title` to `Title` and `id` to `ID`; fields without
+// a matching method keep their resolve function.
+//
+// A method takes the request context and the parent object, optionally
+// followed by the field arguments, and returns the field value, optionally
+// followed by an error:
+//
+//	func (r *articleResolver) Title(ctx context.Context, obj *Article) (string, error)
+//	func (r *articleResolver) Comments(ctx context.Context, obj *Article, args map[string]interface{}) []*Comment
+//
+// The parent object must be assignable to the type of the obj parameter;
+// a returned non-nil error is reported as the field's error, with the error
+// itself as its OriginalError.
+func BindResolvers(objectType *GraphQLObjectType, resolvers interface{}) error {
+	if objectType == nil {
+		return errors.New("BindResolvers expects an object type")
+	}
+	resolversVal := reflect.ValueOf(resolvers)
+	if !resolversVal.IsValid() {
+		return errors.New(fmt.Sprintf("%v resolvers must not be nil", objectType))
+	}
+
+	objectType.mu.Lock()
+	defer objectType.mu.Unlock()
+	fieldConfigs, err := objectType.getFieldConfigs()
+	if err != nil {
+		return err
+	}
+	methods := map[string]reflect.Value{}
+	for i := 0; i < resolversVal.NumMethod(); i++ {
+		methods[strings.ToLower(resolversVal.Type().Method(i).Name)] = resolversVal.Method(i)
+	}
+	for fieldName, fieldConfig := range fieldConfigs {
+		method, ok := methods[strings.ToLower(fieldName)]
+		if !ok || fieldConfig == nil {
+			continue
+		}
+		resolveFn, err := methodResolveFn(method)
+		if err != nil {
+			return errors.New(fmt.Sprintf("%v.%v resolver %v", objectType, fieldName, err))
+		}
+		fieldConfig.Resolve = resolveFn
+	}
+	objectType.fields = nil
+	return nil
+}
+
+// Adapts a resolver method to a resolve function, checking its signature.
+func methodResolveFn(method reflect.Value) (GraphQLFieldResolveFn, error) {
+	methodType := method.Type()
+	numIn, numOut := methodType.NumIn(), methodType.NumOut()
+	if numIn < 2 || numIn > 3 || methodType.In(0) != contextType {
+		return nil, errors.New("must take a context.Context, the parent object and optionally the arguments")
+	}
+	if numIn == 3 && methodType.In(2) != argsType {
+		return nil, errors.New("must take the arguments as a map[string]interface{}")
+	}
+	if numOut < 1 || numOut > 2 || (numOut == 2 && methodType.Out(1) != errorType) {
+		return nil, errors.New("must return the field value and optionally an error")
+	}
+	objType := methodType.In(1)
+
+	return func(p GQLFRParams) interface{} {
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		obj := reflect.ValueOf(p.Source)
+		if !obj.IsValid() {
+			obj = reflect.Zero(objType)
+		}
+		if !obj.Type().AssignableTo(objType) {
+			return errors.New(fmt.Sprintf("Expected %v parent object to be %v, got %v.", p.Info.ParentType, objType, obj.Type()))
+		}
+		in := []reflect.Value{reflect.ValueOf(ctx), obj}
+		if numIn == 3 {
+			args := p.Args
+			if args == nil {
+				args = map[string]interface{}{}
+			}
+			in = append(in, reflect.ValueOf(args))
+		}
+		out := method.Call(in)
+		if numOut == 2 && !out[1].IsNil() {
+			// the executor reports the error, keeping it as the original error
+			return out[1].Interface()
+		}
+		return out[0].Interface()
+	}, nil
+}
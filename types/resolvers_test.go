@@ -0,0 +1,157 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go"
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+type resolversTestArticle struct {
+	ID    int
+	Title string
+	Tags  []string
+}
+
+var errResolversTestNoTitle = errors.New("Article has no title")
+
+type resolversTestArticleResolver struct {
+	prefix string
+}
+
+func (r *resolversTestArticleResolver) ID(ctx context.Context, obj *resolversTestArticle) int {
+	return obj.ID
+}
+
+func (r *resolversTestArticleResolver) Title(ctx context.Context, obj *resolversTestArticle) (string, error) {
+	if obj.Title == "" {
+		return "", errResolversTestNoTitle
+	}
+	return r.prefix + obj.Title, nil
+}
+
+func (r *resolversTestArticleResolver) Tags(ctx context.Context, obj *resolversTestArticle, args map[string]interface{}) ([]string, error) {
+	first, _ := args["first"].(int)
+	if first < len(obj.Tags) {
+		return obj.Tags[:first], nil
+	}
+	return obj.Tags, nil
+}
+
+func (r *resolversTestArticleResolver) Author(ctx context.Context, obj *resolversTestArticle) string {
+	return ctx.Value("author").(string)
+}
+
+func TestBindResolvers_ResolvesFieldsWithTheMethodsOfAResolverStruct(t *testing.T) {
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLInt,
+			},
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"tags": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLList(types.GraphQLString),
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"first": &types.GraphQLArgumentConfig{
+						Type:         types.GraphQLInt,
+						DefaultValue: 10,
+					},
+				},
+			},
+			"author": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	err := types.BindResolvers(articleType, &resolversTestArticleResolver{prefix: "Re: "})
+	if err != nil {
+		t.Fatalf("unexpected error binding resolvers: %v", err)
+	}
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"articles": &types.GraphQLFieldConfig{
+					Type: types.NewGraphQLList(articleType),
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return []*resolversTestArticle{
+							&resolversTestArticle{ID: 1, Title: "Hello", Tags: []string{"a", "b", "c"}},
+							&resolversTestArticle{ID: 2},
+						}
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"articles": []interface{}{
+				map[string]interface{}{
+					"id":     1,
+					"title":  "Re: Hello",
+					"tags":   []interface{}{"a", "b"},
+					"author": "Jane",
+				},
+				map[string]interface{}{
+					"id":     2,
+					"title":  nil,
+					"tags":   nil,
+					"author": "Jane",
+				},
+			},
+		},
+		Errors: []graphqlerrors.GraphQLFormattedError{
+			graphqlerrors.GraphQLFormattedError{
+				Message: "Article has no title",
+				Locations: []location.SourceLocation{
+					location.SourceLocation{Line: 1, Column: 18},
+				},
+				Path:          []interface{}{"articles", 1, "title"},
+				OriginalError: errResolversTestNoTitle,
+			},
+		},
+	}
+	result := graphql(t, gql.GraphqlParams{
+		Schema:        schema,
+		RequestString: `{ articles { id, title, tags(first: 2), author } }`,
+		Context:       context.WithValue(context.Background(), "author", "Jane"),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+type resolversTestInvalidResolver struct{}
+
+func (r resolversTestInvalidResolver) Title(obj *resolversTestArticle) string {
+	return obj.Title
+}
+
+func TestBindResolvers_RejectsMethodsWithUnexpectedSignatures(t *testing.T) {
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Article",
+		Fields: types.GraphQLFieldConfigMap{
+			"title": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	err := types.BindResolvers(articleType, resolversTestInvalidResolver{})
+	if err == nil || !strings.HasPrefix(err.Error(), "Article.title resolver must take a context.Context") {
+		t.Fatalf("expected signature error, got: %v", err)
+	}
+}
@@ -2,14 +2,18 @@ package types
 
 import (
 	"fmt"
+	"math"
+	"reflect"
 	"strconv"
 
 	"github.com/chris-ramon/graphql-go/language/ast"
 )
 
+// As per the GraphQL spec, Integers are only treated as valid when a valid
+// 32-bit signed integer, providing the broadest support across platforms.
 var (
-	MaxInt = 9007199254740991
-	MinInt = -9007199254740991
+	MaxInt = 2147483647
+	MinInt = -2147483648
 )
 
 func coerceInt(value interface{}) interface{} {
@@ -20,11 +24,15 @@ func coerceInt(value interface{}) interface{} {
 		}
 		return int(0)
 	case int:
-		return value
+		return intOrNil(int64(value))
+	case int32:
+		return int(value)
+	case int64:
+		return intOrNil(value)
 	case float32:
-		return intOrNil(int(value))
+		return floatToIntOrNil(float64(value))
 	case float64:
-		return intOrNil(int(value))
+		return floatToIntOrNil(value)
 	case string:
 		val, err := strconv.ParseFloat(value, 0)
 		if err != nil {
@@ -35,12 +43,19 @@ func coerceInt(value interface{}) interface{} {
 	return int(0)
 }
 
-// Integers are only safe when between -(2^53 - 1) and 2^53 - 1 due to being
-// encoded in JavaScript and represented in JSON as double-precision floating
-// point numbers, as specified by IEEE 754.
-func intOrNil(value int) interface{} {
-	if value <= MaxInt && value >= MinInt {
-		return value
+// Values outside of the 32-bit range are not truncated but coerced to nil.
+func intOrNil(value int64) interface{} {
+	if value <= int64(MaxInt) && value >= int64(MinInt) {
+		return int(value)
+	}
+	return nil
+}
+
+// The range is checked before the conversion, as converting a float which
+// does not fit in an int is implementation-specific.
+func floatToIntOrNil(value float64) interface{} {
+	if value <= float64(MaxInt) && value >= float64(MinInt) {
+		return int(value)
 	}
 	return nil
 }
@@ -52,57 +67,83 @@ var GraphQLInt *GraphQLScalarType = NewGraphQLScalarType(GraphQLScalarTypeConfig
 	ParseLiteral: func(valueAST ast.Value) interface{} {
 		switch valueAST := valueAST.(type) {
 		case *ast.IntValue:
-			if intValue, err := strconv.Atoi(valueAST.Value); err == nil {
-				return intValue
+			if intValue, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+				return intOrNil(intValue)
 			}
 		}
 		return nil
 	},
 })
 
-func coerceFloat32(value interface{}) interface{} {
+// Floats are double-precision, as specified by IEEE 754; NaN and the
+// infinities have no representation and coerce to nil.
+func coerceFloat(value interface{}) interface{} {
 	switch value := value.(type) {
 	case bool:
 		if value == true {
-			return float32(1)
+			return float64(1)
 		}
-		return float32(0)
+		return float64(0)
 	case int:
-		return float32(value)
+		return float64(value)
+	case int32:
+		return float64(value)
+	case int64:
+		return float64(value)
 	case float32:
-		return value
+		return finiteOrNil(float64(value))
 	case float64:
-		return float32(value)
+		return finiteOrNil(value)
 	case string:
-		val, err := strconv.ParseFloat(value, 0)
+		val, err := strconv.ParseFloat(value, 64)
 		if err != nil {
 			return nil
 		}
-		return coerceFloat32(val)
+		return coerceFloat(val)
 	}
-	return float32(0)
+	return float64(0)
+}
+
+func finiteOrNil(value float64) interface{} {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	return value
 }
 
 var GraphQLFloat *GraphQLScalarType = NewGraphQLScalarType(GraphQLScalarTypeConfig{
 	Name:       "Float",
-	Serialize:  coerceFloat32,
-	ParseValue: coerceFloat32,
+	Serialize:  coerceFloat,
+	ParseValue: coerceFloat,
 	ParseLiteral: func(valueAST ast.Value) interface{} {
 		switch valueAST := valueAST.(type) {
 		case *ast.FloatValue:
-			if floatValue, err := strconv.ParseFloat(valueAST.Value, 32); err == nil {
-				return floatValue
+			if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+				return finiteOrNil(floatValue)
 			}
 		case *ast.IntValue:
-			if floatValue, err := strconv.ParseFloat(valueAST.Value, 32); err == nil {
-				return floatValue
+			// Int literals are coerced to Float in input positions.
+			if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+				return finiteOrNil(floatValue)
 			}
 		}
-		return float32(0)
+		return nil
 	},
 })
 
+// Values implementing fmt.Stringer, e.g. time.Duration or net.IP, serialize
+// to their String() form; a custom scalar can serialize them otherwise. A nil
+// pointer implementing it, e.g. a nil *url.URL, serializes to null.
 func coerceString(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return value
+	case fmt.Stringer:
+		if reflectValue := reflect.ValueOf(value); reflectValue.Kind() == reflect.Ptr && reflectValue.IsNil() {
+			return nil
+		}
+		return value.String()
+	}
 	return fmt.Sprintf("%v", value)
 }
 
@@ -161,10 +202,34 @@ var GraphQLBoolean *GraphQLScalarType = NewGraphQLScalarType(GraphQLScalarTypeCo
 	},
 })
 
+// IDs may be provided as either strings or integers, and are always
+// represented internally and serialized as strings.
+func coerceID(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case int32:
+		return strconv.FormatInt(int64(value), 10)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float32:
+		if float32(int(value)) == value {
+			return strconv.Itoa(int(value))
+		}
+	case float64:
+		if float64(int(value)) == value {
+			return strconv.Itoa(int(value))
+		}
+	}
+	return nil
+}
+
 var GraphQLID *GraphQLScalarType = NewGraphQLScalarType(GraphQLScalarTypeConfig{
 	Name:       "ID",
 	Serialize:  coerceString,
-	ParseValue: coerceString,
+	ParseValue: coerceID,
 	ParseLiteral: func(valueAST ast.Value) interface{} {
 		switch valueAST := valueAST.(type) {
 		case *ast.IntValue:
@@ -172,6 +237,25 @@ var GraphQLID *GraphQLScalarType = NewGraphQLScalarType(GraphQLScalarTypeConfig{
 		case *ast.StringValue:
 			return valueAST.Value
 		}
-		return ""
+		return nil
 	},
 })
+
+func identity(value interface{}) interface{} {
+	return value
+}
+
+// GraphQLJSON is a scalar passing arbitrary values through unchanged, e.g.
+// nested maps and slices, for the fields and arguments whose shape is not
+// described by the schema. Its literals are converted to the corresponding
+// Go values, e.g. an object literal to a map[string]interface{} and an Int
+// literal to an int, the variables nested in a literal to their values.
+// Unlike the built-in scalars, it is only in the schemas using it.
+var GraphQLJSON *GraphQLScalarType = NewGraphQLScalarType(GraphQLScalarTypeConfig{
+	Name:                      "JSON",
+	Description:               "The `JSON` scalar type represents arbitrary JSON values.",
+	Serialize:                 identity,
+	ParseValue:                identity,
+	ParseLiteral:              literalValue,
+	ParseLiteralWithVariables: literalValueWithVariables,
+})
@@ -1,22 +1,26 @@
 package types
 
 import (
+	"math"
+	"net"
+	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 type intSerializationTest struct {
 	Value    interface{}
 	Expected interface{}
 }
-type float32SerializationTest struct {
+type floatSerializationTest struct {
 	Value    interface{}
 	Expected interface{}
 }
 
 type stringSerializationTest struct {
 	Value    interface{}
-	Expected string
+	Expected interface{}
 }
 
 type boolSerializationTest struct {
@@ -32,9 +36,17 @@ func TestTypeSystem_Scalar_SerializesOutputInt(t *testing.T) {
 		{float32(0.1), 0},
 		{float32(1.1), 1},
 		{float32(-1.1), -1},
-		{float32(1e5), 100000}, // Bigger than 2^32, but still representable as an Int
-		{9876504321, 9876504321},
-		{-9876504321, -9876504321},
+		{float32(1e5), 100000},
+		{2147483647, 2147483647},
+		{-2147483648, -2147483648},
+		{int64(2147483647), 2147483647},
+		// Bigger than 2^32, not representable as an Int
+		{2147483648, nil},
+		{-2147483649, nil},
+		{int64(2147483648), nil},
+		{9876504321, nil},
+		{-9876504321, nil},
+		{float64(2147483648), nil},
 		{float64(1e100), nil},
 		{float64(-1e100), nil},
 		{"-1.1", -1},
@@ -53,17 +65,21 @@ func TestTypeSystem_Scalar_SerializesOutputInt(t *testing.T) {
 }
 
 func TestTypeSystem_Scalar_SerializesOutputFloat(t *testing.T) {
-	tests := []float32SerializationTest{
-		{int(1), float32(1.0)},
-		{int(0), float32(0.0)},
-		{int(-1), float32(-1.0)},
-		{float32(0.1), float32(0.1)},
-		{float32(1.1), float32(1.1)},
-		{float32(-1.1), float32(-1.1)},
-		{"-1.1", float32(-1.1)},
+	tests := []floatSerializationTest{
+		{int(1), 1.0},
+		{int(0), 0.0},
+		{int(-1), -1.0},
+		{int64(1 << 40), float64(1 << 40)},
+		{0.1, 0.1},
+		{1.1, 1.1},
+		{-1.1, -1.1},
+		{float32(0.5), 0.5},
+		{math.Inf(1), nil},
+		{math.NaN(), nil},
+		{"-1.1", -1.1},
 		{"one", nil},
-		{false, float32(0.0)},
-		{true, float32(1.0)},
+		{false, 0.0},
+		{true, 1.0},
 	}
 
 	for i, test := range tests {
@@ -82,6 +98,9 @@ func TestTypeSystem_Scalar_SerializesOutputStrings(t *testing.T) {
 		{float32(-1.1), "-1.1"},
 		{true, "true"},
 		{false, "false"},
+		{90 * time.Second, "1m30s"},
+		{net.IPv4(127, 0, 0, 1), "127.0.0.1"},
+		{(*url.URL)(nil), nil},
 	}
 
 	for _, test := range tests {
@@ -113,3 +132,24 @@ func TestTypeSystem_Scalar_SerializesOutputBoolean(t *testing.T) {
 		}
 	}
 }
+
+func TestTypeSystem_Scalar_ParsesInputID(t *testing.T) {
+	tests := []intSerializationTest{
+		{"1", "1"},
+		{"abc", "abc"},
+		{int(1), "1"},
+		{int32(-2), "-2"},
+		{int64(1 << 40), "1099511627776"},
+		{float64(1), "1"},
+		{float64(1.5), nil},
+		{true, nil},
+	}
+
+	for _, test := range tests {
+		val := GraphQLID.ParseValue(test.Value)
+		if val != test.Expected {
+			reflectedValue := reflect.ValueOf(test.Value)
+			t.Fatalf("Failed GraphQLID.ParseValue(%v(%v)), expected: %v, got %v", reflectedValue.Type(), test.Value, test.Expected, val)
+		}
+	}
+}
@@ -2,31 +2,51 @@ package types
 
 import (
 	"fmt"
+	"sort"
+
+	"github.com/chris-ramon/graphql-go/language/ast"
 )
 
 /**
 Schema Definition
 A Schema is created by supplying the root types of each type of operation,
-query and mutation (optional). A schema definition is then supplied to the
-validator and executor.
+query, mutation (optional) and subscription (optional). A schema definition is
+then supplied to the validator and executor.
 Example:
     myAppSchema, err := NewGraphQLSchema(GraphQLSchemaConfig({
       Query: MyAppQueryRootType
       Mutation: MyAppMutationRootType
+      Subscription: MyAppSubscriptionRootType
     });
 */
 type GraphQLSchemaConfig struct {
-	Query    *GraphQLObjectType
-	Mutation *GraphQLObjectType
+	Query        *GraphQLObjectType
+	Mutation     *GraphQLObjectType
+	Subscription *GraphQLObjectType
+	// Types added to the schema besides those referenced by the root types,
+	// e.g. the implementations of an interface which are not otherwise
+	// referenced.
+	Types []GraphQLType
+	// The directives supported by the schema besides the built-in @include,
+	// @skip, @deprecated and @specifiedBy directives, a directive of the same
+	// name as a built-in one replacing it.
+	Directives []*GraphQLDirective
 }
 
 // chose to name as GraphQLTypeMap instead of TypeMap
 type GraphQLTypeMap map[string]GraphQLType
 
 type GraphQLSchema struct {
-	schemaConfig GraphQLSchemaConfig
-	typeMap      GraphQLTypeMap
-	directives   []*GraphQLDirective
+	schemaConfig    GraphQLSchemaConfig
+	typeMap         GraphQLTypeMap
+	directives      []*GraphQLDirective
+	implementations map[string][]*GraphQLObjectType
+	possibleTypeMap map[string]map[string]bool
+	// the order of the definitions of a schema built from a document, see
+	// PrintOptions.PreserveOrder
+	definitionOrder definitionOrder
+	// memoized introspection, see IntrospectionResult
+	introspection *introspectionCache
 }
 
 func NewGraphQLSchema(config GraphQLSchemaConfig) (GraphQLSchema, error) {
@@ -39,51 +59,169 @@ func NewGraphQLSchema(config GraphQLSchemaConfig) (GraphQLSchema, error) {
 		return schema, err
 	}
 
+	schema.schemaConfig = config
+	// set once, so that the schema is only read while executed
+	schema.directives = mergeDirectives(config.Directives)
+	schema.introspection = &introspectionCache{}
+
 	// if schema config contains error at creation time, return those errors
 	if config.Query != nil && config.Query.err != nil {
-		return schema, config.Query.err
+		return schema, schema.validationError(config.Query.err)
 	}
 	if config.Mutation != nil && config.Mutation.err != nil {
-		return schema, config.Mutation.err
+		return schema, schema.validationError(config.Mutation.err)
+	}
+	if config.Subscription != nil && config.Subscription.err != nil {
+		return schema, schema.validationError(config.Subscription.err)
 	}
-
-	schema.schemaConfig = config
 
 	// Build type map now to detect any errors within this schema.
 	typeMap := GraphQLTypeMap{}
+	origins := typeMapOrigins{}
 	objectTypes := []*GraphQLObjectType{
 		schema.GetQueryType(),
 		schema.GetMutationType(),
+		schema.GetSubscriptionType(),
 		__Type,
 		__Schema,
 	}
-	for _, objectType := range objectTypes {
+	objectTypeOrigins := []string{
+		"the query type",
+		"the mutation type",
+		"the subscription type",
+		"introspection",
+		"introspection",
+	}
+	for i, objectType := range objectTypes {
 		if objectType == nil {
 			continue
 		}
 		if objectType.err != nil {
 			return schema, objectType.err
 		}
-		typeMap, err = typeMapReducer(typeMap, objectType)
+		typeMap, err = typeMapReducer(typeMap, origins, objectType, objectTypeOrigins[i])
 		if err != nil {
-			return schema, err
+			return schema, schema.validationError(err)
+		}
+	}
+	for _, ttype := range config.Types {
+		if ttype == nil {
+			continue
+		}
+		typeMap, err = typeMapReducer(typeMap, origins, ttype, "the schema types")
+		if err != nil {
+			return schema, schema.validationError(err)
+		}
+	}
+	// The types of the arguments of the directives, e.g. an enum only
+	// referenced by a custom directive, are part of the schema too.
+	for _, directive := range schema.GetDirectives() {
+		if directive == nil {
+			continue
+		}
+		for _, arg := range directive.Args {
+			if arg == nil || arg.Type == nil {
+				continue
+			}
+			typeMap, err = typeMapReducer(typeMap, origins, arg.Type, fmt.Sprintf("the @%v directive", directive.Name))
+			if err != nil {
+				return schema, schema.validationError(err)
+			}
 		}
 	}
 	schema.typeMap = typeMap
-	// Enforce correct interface implementations
+	schema.buildPossibleTypes(typeMap)
+
+	// Enforce correct interface implementations, and the other checks of
+	// the schema definition which do not prevent building the type map.
+	if errs := schema.Validate(); len(errs) > 0 {
+		return schema, schema.validationError(errs...)
+	}
+	markBuilt(typeMap)
+
+	return schema, nil
+}
+
+// Marks the types of the type map of a built schema, no field may be added
+// to them from then on, see GraphQLObjectType.AddFieldConfig.
+func markBuilt(typeMap GraphQLTypeMap) {
 	for _, ttype := range typeMap {
 		switch ttype := ttype.(type) {
+		case *GraphQLObjectType:
+			ttype.mu.Lock()
+			ttype.built = true
+			ttype.mu.Unlock()
+		case *GraphQLInterfaceType:
+			ttype.mu.Lock()
+			ttype.built = true
+			ttype.mu.Unlock()
+		}
+	}
+}
+
+// Reports the errors of a schema definition, along with any other problem
+// found by Validate when err stopped the schema construction.
+func (gq *GraphQLSchema) validationError(errs ...error) error {
+	if len(errs) == 1 {
+		for _, err := range gq.Validate() {
+			if err.Error() != errs[0].Error() {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return GraphQLSchemaErrors(errs)
+}
+
+// Builds the possible types of the abstract types of the type map.
+func (schema *GraphQLSchema) buildPossibleTypes(typeMap GraphQLTypeMap) {
+
+	// Keep track of all implementations by interface name, and of all
+	// possible types by abstract type name. Implementations keep the order
+	// in which they were defined, followed by any other object type in the
+	// type map which implements the interface.
+	schema.implementations = map[string][]*GraphQLObjectType{}
+	schema.possibleTypeMap = map[string]map[string]bool{}
+	typeNames := []string{}
+	for typeName := range typeMap {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+	for _, typeName := range typeNames {
+		switch ttype := typeMap[typeName].(type) {
+		case *GraphQLInterfaceType:
+			possibleTypes := map[string]bool{}
+			for _, possibleType := range ttype.GetPossibleTypes() {
+				if possibleType == nil || typeMap[possibleType.Name] != possibleType {
+					continue
+				}
+				schema.implementations[ttype.Name] = append(schema.implementations[ttype.Name], possibleType)
+				possibleTypes[possibleType.Name] = true
+			}
+			schema.possibleTypeMap[ttype.Name] = possibleTypes
+		case *GraphQLUnionType:
+			possibleTypes := map[string]bool{}
+			for _, possibleType := range ttype.GetPossibleTypes() {
+				possibleTypes[possibleType.Name] = true
+			}
+			schema.possibleTypeMap[ttype.Name] = possibleTypes
+		}
+	}
+	for _, typeName := range typeNames {
+		switch ttype := typeMap[typeName].(type) {
 		case *GraphQLObjectType:
 			for _, iface := range ttype.GetInterfaces() {
-				err := assertObjectImplementsInterface(ttype, iface)
-				if err != nil {
-					return schema, err
+				possibleTypes, ok := schema.possibleTypeMap[iface.Name]
+				if !ok || possibleTypes[ttype.Name] {
+					continue
 				}
+				schema.implementations[iface.Name] = append(schema.implementations[iface.Name], ttype)
+				possibleTypes[ttype.Name] = true
 			}
 		}
 	}
-
-	return schema, nil
 }
 
 func (gq *GraphQLSchema) GetQueryType() *GraphQLObjectType {
@@ -94,16 +232,47 @@ func (gq *GraphQLSchema) GetMutationType() *GraphQLObjectType {
 	return gq.schemaConfig.Mutation
 }
 
+func (gq *GraphQLSchema) GetSubscriptionType() *GraphQLObjectType {
+	return gq.schemaConfig.Subscription
+}
+
+// GetDirectives returns the directives of the schema, the built-in ones
+// followed by the other directives of the config, see NewGraphQLSchema.
 func (gq *GraphQLSchema) GetDirectives() []*GraphQLDirective {
-	if len(gq.directives) == 0 {
-		gq.directives = []*GraphQLDirective{
-			GraphQLIncludeDirective,
-			GraphQLSkipDirective,
-		}
+	if gq.directives == nil {
+		return mergeDirectives(nil)
 	}
 	return gq.directives
 }
 
+// Returns the built-in directives, replaced by the directives of the same
+// name, followed by the other directives.
+func mergeDirectives(directives []*GraphQLDirective) []*GraphQLDirective {
+	merged := []*GraphQLDirective{
+		GraphQLIncludeDirective,
+		GraphQLSkipDirective,
+		GraphQLDeprecatedDirective,
+		GraphQLSpecifiedByDirective,
+	}
+	builtIns := len(merged)
+	for _, directive := range directives {
+		if directive == nil {
+			continue
+		}
+		replaced := false
+		for i := 0; i < builtIns; i++ {
+			if merged[i].Name == directive.Name {
+				merged[i] = directive
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, directive)
+		}
+	}
+	return merged
+}
+
 func (gq *GraphQLSchema) GetTypeMap() GraphQLTypeMap {
 	return gq.typeMap
 }
@@ -112,7 +281,35 @@ func (gq *GraphQLSchema) GetType(name string) GraphQLType {
 	return gq.GetTypeMap()[name]
 }
 
-func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLTypeMap, error) {
+// Returns the object types in this schema which may be returned for the
+// given abstract type: every implementation of an interface found in the
+// type map, or every member of a union.
+func (gq *GraphQLSchema) GetPossibleTypes(abstractType GraphQLType) []*GraphQLObjectType {
+	switch abstractType := abstractType.(type) {
+	case *GraphQLInterfaceType:
+		return gq.implementations[abstractType.Name]
+	case *GraphQLUnionType:
+		return abstractType.GetPossibleTypes()
+	}
+	return nil
+}
+
+func (gq *GraphQLSchema) IsPossibleType(abstractType GraphQLType, possibleType *GraphQLObjectType) bool {
+	if abstractType == nil || possibleType == nil {
+		return false
+	}
+	possibleTypes, ok := gq.possibleTypeMap[abstractType.GetName()]
+	if !ok {
+		return false
+	}
+	return possibleTypes[possibleType.Name]
+}
+
+// Where each type of a type map was first found, e.g. `Query.author`, so that
+// conflicting definitions of a type name can be traced back to their origins.
+type typeMapOrigins map[string]string
+
+func typeMapReducer(typeMap GraphQLTypeMap, origins typeMapOrigins, objectType GraphQLType, origin string) (GraphQLTypeMap, error) {
 	var err error
 	if objectType == nil || objectType.GetName() == "" {
 		return typeMap, nil
@@ -121,11 +318,11 @@ func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLType
 	switch objectType := objectType.(type) {
 	case *GraphQLList:
 		if objectType.OfType != nil {
-			return typeMapReducer(typeMap, objectType.OfType)
+			return typeMapReducer(typeMap, origins, objectType.OfType, origin)
 		}
 	case *GraphQLNonNull:
 		if objectType.OfType != nil {
-			return typeMapReducer(typeMap, objectType.OfType)
+			return typeMapReducer(typeMap, origins, objectType.OfType, origin)
 		}
 	case *GraphQLObjectType:
 		if objectType.err != nil {
@@ -136,7 +333,8 @@ func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLType
 	if mappedObjectType, ok := typeMap[objectType.GetName()]; ok {
 		err := invariant(
 			mappedObjectType == objectType,
-			fmt.Sprintf(`Schema must contain unique named types but contains multiple types named "%v".`, objectType.GetName()),
+			fmt.Sprintf(`Schema must contain unique named types but contains multiple types named "%v", `+
+				`found at %v and at %v.`, objectType.GetName(), origins[objectType.GetName()], origin),
 		)
 		if err != nil {
 			return typeMap, err
@@ -148,6 +346,7 @@ func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLType
 	}
 
 	typeMap[objectType.GetName()] = objectType
+	origins[objectType.GetName()] = origin
 
 	switch objectType := objectType.(type) {
 	case *GraphQLUnionType:
@@ -159,7 +358,7 @@ func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLType
 			if innerObjectType.err != nil {
 				return typeMap, innerObjectType.err
 			}
-			typeMap, err = typeMapReducer(typeMap, innerObjectType)
+			typeMap, err = typeMapReducer(typeMap, origins, innerObjectType, fmt.Sprintf("possible types of %v", objectType))
 			if err != nil {
 				return typeMap, err
 			}
@@ -173,7 +372,7 @@ func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLType
 			if innerObjectType.err != nil {
 				return typeMap, innerObjectType.err
 			}
-			typeMap, err = typeMapReducer(typeMap, innerObjectType)
+			typeMap, err = typeMapReducer(typeMap, origins, innerObjectType, fmt.Sprintf("implementations of %v", objectType))
 			if err != nil {
 				return typeMap, err
 			}
@@ -187,7 +386,7 @@ func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLType
 			if innerObjectType.err != nil {
 				return typeMap, innerObjectType.err
 			}
-			typeMap, err = typeMapReducer(typeMap, innerObjectType)
+			typeMap, err = typeMapReducer(typeMap, origins, innerObjectType, fmt.Sprintf("interfaces of %v", objectType))
 			if err != nil {
 				return typeMap, err
 			}
@@ -200,42 +399,32 @@ func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLType
 		if objectType.err != nil {
 			return typeMap, objectType.err
 		}
-		for _, field := range fieldMap {
-			for _, arg := range field.Args {
-				typeMap, err = typeMapReducer(typeMap, arg.Type)
-				if err != nil {
-					return typeMap, err
-				}
-			}
-			typeMap, err = typeMapReducer(typeMap, field.Type)
-			if err != nil {
-				return typeMap, err
-			}
+		typeMap, err = fieldMapReducer(typeMap, origins, objectType, fieldMap)
+		if err != nil {
+			return typeMap, err
 		}
 	case *GraphQLInterfaceType:
 		fieldMap := objectType.GetFields()
 		if objectType.err != nil {
 			return typeMap, objectType.err
 		}
-		for _, field := range fieldMap {
-			for _, arg := range field.Args {
-				typeMap, err = typeMapReducer(typeMap, arg.Type)
-				if err != nil {
-					return typeMap, err
-				}
-			}
-			typeMap, err = typeMapReducer(typeMap, field.Type)
-			if err != nil {
-				return typeMap, err
-			}
+		typeMap, err = fieldMapReducer(typeMap, origins, objectType, fieldMap)
+		if err != nil {
+			return typeMap, err
 		}
 	case *GraphQLInputObjectType:
 		fieldMap := objectType.GetFields()
 		if objectType.err != nil {
 			return typeMap, objectType.err
 		}
-		for _, field := range fieldMap {
-			typeMap, err = typeMapReducer(typeMap, field.Type)
+		fieldNames := []string{}
+		for fieldName := range fieldMap {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			origin := fmt.Sprintf("%v.%v", objectType, fieldName)
+			typeMap, err = typeMapReducer(typeMap, origins, fieldMap[fieldName].Type, origin)
 			if err != nil {
 				return typeMap, err
 			}
@@ -244,7 +433,34 @@ func typeMapReducer(typeMap GraphQLTypeMap, objectType GraphQLType) (GraphQLType
 	return typeMap, nil
 }
 
-func assertObjectImplementsInterface(object *GraphQLObjectType, iface *GraphQLInterfaceType) error {
+// Reduces the types of the fields and their arguments, in the order of the
+// field names so that the origins of the types are deterministic.
+func fieldMapReducer(typeMap GraphQLTypeMap, origins typeMapOrigins, parentType GraphQLType, fieldMap GraphQLFieldDefinitionMap) (GraphQLTypeMap, error) {
+	var err error
+	fieldNames := []string{}
+	for fieldName := range fieldMap {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		field := fieldMap[fieldName]
+		for _, arg := range field.Args {
+			origin := fmt.Sprintf("%v.%v(%v:)", parentType, fieldName, arg.Name)
+			typeMap, err = typeMapReducer(typeMap, origins, arg.Type, origin)
+			if err != nil {
+				return typeMap, err
+			}
+		}
+		origin := fmt.Sprintf("%v.%v", parentType, fieldName)
+		typeMap, err = typeMapReducer(typeMap, origins, field.Type, origin)
+		if err != nil {
+			return typeMap, err
+		}
+	}
+	return typeMap, nil
+}
+
+func assertObjectImplementsInterface(schema *GraphQLSchema, object *GraphQLObjectType, iface *GraphQLInterfaceType) error {
 	objectFieldMap := object.GetFields()
 	ifaceFieldMap := iface.GetFields()
 
@@ -263,9 +479,10 @@ func assertObjectImplementsInterface(object *GraphQLObjectType, iface *GraphQLIn
 			return err
 		}
 
-		// Assert interface field type matches object field type. (invariant)
+		// Assert interface field type is satisfied by object field type, by
+		// being a valid subtype. (covariant)
 		err = invariant(
-			isEqualType(ifaceField.Type, objectField.Type),
+			IsTypeSubTypeOf(schema, objectField.Type, ifaceField.Type),
 			fmt.Sprintf(`%v.%v expects type "%v" but `+
 				`%v.%v provides type "%v".`,
 				iface, fieldName, ifaceField.Type,
@@ -337,6 +554,32 @@ func assertObjectImplementsInterface(object *GraphQLObjectType, iface *GraphQLIn
 	return nil
 }
 
+// TypeFromAST returns the type of the schema referenced by the AST type, e.g.
+// `[Int!]`, wrapped in the List and NonNull types of the reference. Returns
+// nil if the named type is not in the schema.
+func TypeFromAST(schema GraphQLSchema, node ast.Type) GraphQLType {
+	switch node := node.(type) {
+	case *ast.ListType:
+		innerType := TypeFromAST(schema, node.Type)
+		if innerType == nil {
+			return nil
+		}
+		return NewGraphQLList(innerType)
+	case *ast.NonNullType:
+		innerType := TypeFromAST(schema, node.Type)
+		if innerType == nil {
+			return nil
+		}
+		return NewGraphQLNonNull(innerType)
+	case *ast.NamedType:
+		if node == nil || node.Name == nil {
+			return nil
+		}
+		return schema.GetType(node.Name.Value)
+	}
+	return nil
+}
+
 func isEqualType(typeA GraphQLType, typeB GraphQLType) bool {
 	if typeA, ok := typeA.(*GraphQLNonNull); ok {
 		if typeB, ok := typeB.(*GraphQLNonNull); ok {
@@ -350,3 +593,53 @@ func isEqualType(typeA GraphQLType, typeB GraphQLType) bool {
 	}
 	return typeA == typeB
 }
+
+// IsTypeSubTypeOf reports whether a value of maybeSubType can be used where
+// superType is expected: a non-null type is a subtype of its nullable type,
+// lists are covariant in their item type, and an object type is a subtype
+// of the interfaces and unions it is a possible type of within the schema.
+func IsTypeSubTypeOf(schema *GraphQLSchema, maybeSubType GraphQLType, superType GraphQLType) bool {
+	if maybeSubType == nil || superType == nil {
+		return false
+	}
+	// Equivalent type is a valid subtype.
+	if maybeSubType == superType {
+		return true
+	}
+
+	// If superType is non-null, maybeSubType must also be non-null.
+	if superType, ok := superType.(*GraphQLNonNull); ok {
+		if maybeSubType, ok := maybeSubType.(*GraphQLNonNull); ok {
+			return IsTypeSubTypeOf(schema, maybeSubType.OfType, superType.OfType)
+		}
+		return false
+	}
+	if maybeSubType, ok := maybeSubType.(*GraphQLNonNull); ok {
+		// If superType is nullable, maybeSubType may be non-null or nullable.
+		return IsTypeSubTypeOf(schema, maybeSubType.OfType, superType)
+	}
+
+	// If superType type is a list, maybeSubType type must also be a list.
+	if superType, ok := superType.(*GraphQLList); ok {
+		if maybeSubType, ok := maybeSubType.(*GraphQLList); ok {
+			return IsTypeSubTypeOf(schema, maybeSubType.OfType, superType.OfType)
+		}
+		return false
+	}
+	if _, ok := maybeSubType.(*GraphQLList); ok {
+		// If superType is not a list, maybeSubType must also be not a list.
+		return false
+	}
+
+	// If superType is an abstract type, maybeSubType type may be a currently
+	// possible object type.
+	if maybeSubType, ok := maybeSubType.(*GraphQLObjectType); ok && schema != nil {
+		switch superType.(type) {
+		case *GraphQLInterfaceType, *GraphQLUnionType:
+			return schema.IsPossibleType(superType, maybeSubType)
+		}
+	}
+
+	// Otherwise, the child type is not a valid subtype of the parent type.
+	return false
+}
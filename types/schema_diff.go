@@ -0,0 +1,334 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaChangeSeverity tells how a change of a schema affects its clients.
+type SchemaChangeSeverity string
+
+const (
+	// The change breaks queries which were valid, e.g. a removed field.
+	SchemaChangeBreaking SchemaChangeSeverity = "BREAKING"
+	// The change keeps queries valid but may change their results, e.g. a
+	// value added to an enum a client switches on.
+	SchemaChangeDangerous SchemaChangeSeverity = "DANGEROUS"
+	// The change keeps queries valid and their results unchanged, e.g. an
+	// added field.
+	SchemaChangeSafe SchemaChangeSeverity = "SAFE"
+)
+
+// SchemaChange is a difference between two schemas, see DiffSchemas.
+type SchemaChange struct {
+	Severity    SchemaChangeSeverity
+	Description string
+}
+
+// DiffSchemas returns the changes from an old schema to a new one, e.g. to
+// reject deploying a schema which breaks its clients. The types of the type
+// maps of the schemas are compared by name, then their fields, arguments,
+// enum values, union members and interfaces, followed by the directives of
+// the schemas. The changes are ordered by type name, then directive name.
+func DiffSchemas(oldSchema, newSchema GraphQLSchema) []SchemaChange {
+	d := &schemaDiffer{changes: []SchemaChange{}}
+	oldTypes, newTypes := oldSchema.GetTypeMap(), newSchema.GetTypeMap()
+	for _, name := range unionKeys(oldTypes, newTypes) {
+		oldType, newType := oldTypes[name], newTypes[name]
+		switch {
+		case newType == nil:
+			d.report(SchemaChangeBreaking, "Type %v was removed.", name)
+		case oldType == nil:
+			d.report(SchemaChangeSafe, "Type %v was added.", name)
+		case typeKind(oldType) != typeKind(newType):
+			d.report(SchemaChangeBreaking, "Type %v changed from %v to %v.", name, typeKind(oldType), typeKind(newType))
+		default:
+			d.diffType(oldType, newType)
+		}
+	}
+
+	oldDirectives := map[string]*GraphQLDirective{}
+	for _, directive := range oldSchema.GetDirectives() {
+		oldDirectives[directive.Name] = directive
+	}
+	newDirectives := map[string]*GraphQLDirective{}
+	for _, directive := range newSchema.GetDirectives() {
+		newDirectives[directive.Name] = directive
+	}
+	for _, name := range unionKeys(oldDirectives, newDirectives) {
+		oldDirective, newDirective := oldDirectives[name], newDirectives[name]
+		switch {
+		case newDirective == nil:
+			d.report(SchemaChangeBreaking, "Directive @%v was removed.", name)
+		case oldDirective == nil:
+			d.report(SchemaChangeSafe, "Directive @%v was added.", name)
+		default:
+			d.diffDirective(oldDirective, newDirective)
+		}
+	}
+	return d.changes
+}
+
+type schemaDiffer struct {
+	changes []SchemaChange
+}
+
+func (d *schemaDiffer) report(severity SchemaChangeSeverity, format string, a ...interface{}) {
+	d.changes = append(d.changes, SchemaChange{
+		Severity:    severity,
+		Description: fmt.Sprintf(format, a...),
+	})
+}
+
+// Compares two types of the same name and kind.
+func (d *schemaDiffer) diffType(oldType, newType GraphQLType) {
+	switch oldType := oldType.(type) {
+	case *GraphQLObjectType:
+		newType := newType.(*GraphQLObjectType)
+		d.diffFields(oldType.Name, oldType.GetFields(), newType.GetFields())
+		d.diffMembers(oldType.Name, "interface", interfaceNames(oldType.GetInterfaces()), interfaceNames(newType.GetInterfaces()))
+	case *GraphQLInterfaceType:
+		d.diffFields(oldType.Name, oldType.GetFields(), newType.(*GraphQLInterfaceType).GetFields())
+	case *GraphQLUnionType:
+		newType := newType.(*GraphQLUnionType)
+		d.diffMembers(oldType.Name, "member", objectNames(oldType.GetPossibleTypes()), objectNames(newType.GetPossibleTypes()))
+	case *GraphQLEnumType:
+		oldValues, newValues := map[string]bool{}, map[string]bool{}
+		for _, value := range oldType.GetValues() {
+			oldValues[value.Name] = true
+		}
+		for _, value := range newType.(*GraphQLEnumType).GetValues() {
+			newValues[value.Name] = true
+		}
+		for _, name := range unionKeys(oldValues, newValues) {
+			switch {
+			case !newValues[name]:
+				d.report(SchemaChangeBreaking, "Enum value %v.%v was removed.", oldType.Name, name)
+			case !oldValues[name]:
+				d.report(SchemaChangeDangerous, "Enum value %v.%v was added.", oldType.Name, name)
+			}
+		}
+	case *GraphQLInputObjectType:
+		oldFields, newFields := oldType.GetFields(), newType.(*GraphQLInputObjectType).GetFields()
+		for _, name := range unionKeys(oldFields, newFields) {
+			oldField, newField := oldFields[name], newFields[name]
+			path := oldType.Name + "." + name
+			switch {
+			case newField == nil:
+				d.report(SchemaChangeBreaking, "Input field %v was removed.", path)
+			case oldField == nil:
+				d.reportAddedInput("Input field", path, newField.Type, newField.DefaultValue)
+			default:
+				d.diffInput("Input field", path, oldField.Type, newField.Type, oldField.DefaultValue, newField.DefaultValue)
+			}
+		}
+	}
+}
+
+func (d *schemaDiffer) diffFields(typeName string, oldFields, newFields GraphQLFieldDefinitionMap) {
+	for _, name := range unionKeys(oldFields, newFields) {
+		oldField, newField := oldFields[name], newFields[name]
+		path := typeName + "." + name
+		switch {
+		case newField == nil:
+			d.report(SchemaChangeBreaking, "Field %v was removed.", path)
+			continue
+		case oldField == nil:
+			d.report(SchemaChangeSafe, "Field %v was added.", path)
+			continue
+		}
+		if oldField.Type.String() != newField.Type.String() {
+			severity := SchemaChangeBreaking
+			if isSafeOutputTypeChange(oldField.Type, newField.Type) {
+				severity = SchemaChangeSafe
+			}
+			d.report(severity, "Field %v changed type from %v to %v.", path, oldField.Type, newField.Type)
+		}
+		if oldField.DeprecationReason == "" && newField.DeprecationReason != "" {
+			d.report(SchemaChangeSafe, "Field %v was deprecated.", path)
+		}
+		d.diffArgs(path, oldField.Args, newField.Args)
+	}
+}
+
+func (d *schemaDiffer) diffDirective(oldDirective, newDirective *GraphQLDirective) {
+	path := "@" + oldDirective.Name
+	d.diffArgs(path, oldDirective.Args, newDirective.Args)
+	oldLocations, newLocations := map[string]bool{}, map[string]bool{}
+	for _, location := range oldDirective.GetLocations() {
+		oldLocations[location] = true
+	}
+	for _, location := range newDirective.GetLocations() {
+		newLocations[location] = true
+	}
+	for _, location := range unionKeys(oldLocations, newLocations) {
+		switch {
+		case !newLocations[location]:
+			d.report(SchemaChangeBreaking, "Location %v was removed from directive %v.", location, path)
+		case !oldLocations[location]:
+			d.report(SchemaChangeSafe, "Location %v was added to directive %v.", location, path)
+		}
+	}
+}
+
+// Compares the arguments of a field or directive, its path being e.g.
+// "Query.article" or "@include".
+func (d *schemaDiffer) diffArgs(path string, oldArgs, newArgs []*GraphQLArgument) {
+	oldByName, newByName := map[string]*GraphQLArgument{}, map[string]*GraphQLArgument{}
+	for _, arg := range oldArgs {
+		oldByName[arg.Name] = arg
+	}
+	for _, arg := range newArgs {
+		newByName[arg.Name] = arg
+	}
+	for _, name := range unionKeys(oldByName, newByName) {
+		oldArg, newArg := oldByName[name], newByName[name]
+		argPath := fmt.Sprintf("%v(%v:)", path, name)
+		switch {
+		case newArg == nil:
+			d.report(SchemaChangeBreaking, "Argument %v was removed.", argPath)
+		case oldArg == nil:
+			d.reportAddedInput("Argument", argPath, newArg.Type, newArg.DefaultValue)
+		default:
+			d.diffInput("Argument", argPath, oldArg.Type, newArg.Type, oldArg.DefaultValue, newArg.DefaultValue)
+		}
+	}
+}
+
+// Reports an added argument or input field, breaking if it is required.
+func (d *schemaDiffer) reportAddedInput(kind string, path string, ttype GraphQLInputType, defaultValue interface{}) {
+	if _, ok := ttype.(*GraphQLNonNull); ok && defaultValue == nil {
+		d.report(SchemaChangeBreaking, "Required %v %v was added.", lowerFirst(kind), path)
+		return
+	}
+	d.report(SchemaChangeDangerous, "Optional %v %v was added.", lowerFirst(kind), path)
+}
+
+// Compares an argument or input field present in both schemas.
+func (d *schemaDiffer) diffInput(kind string, path string, oldType, newType GraphQLInputType, oldDefault, newDefault interface{}) {
+	if oldType.String() != newType.String() {
+		severity := SchemaChangeBreaking
+		if isSafeInputTypeChange(oldType, newType) {
+			severity = SchemaChangeSafe
+		}
+		d.report(severity, "%v %v changed type from %v to %v.", kind, path, oldType, newType)
+	}
+	if !reflect.DeepEqual(oldDefault, newDefault) {
+		d.report(SchemaChangeDangerous, "%v %v changed default value from %v to %v.", kind, path, oldDefault, newDefault)
+	}
+}
+
+// Compares the interfaces of an object, or the members of a union.
+func (d *schemaDiffer) diffMembers(typeName string, kind string, oldNames, newNames map[string]bool) {
+	for _, name := range unionKeys(oldNames, newNames) {
+		switch {
+		case !newNames[name]:
+			d.report(SchemaChangeBreaking, "%v was removed from the %ss of %v.", name, kind, typeName)
+		case !oldNames[name]:
+			d.report(SchemaChangeDangerous, "%v was added to the %ss of %v.", name, kind, typeName)
+		}
+	}
+}
+
+// Whether the values of a field of the new type are values of the old type,
+// e.g. when a field becomes non-null.
+func isSafeOutputTypeChange(oldType, newType GraphQLType) bool {
+	if newNonNull, ok := newType.(*GraphQLNonNull); ok {
+		if oldNonNull, ok := oldType.(*GraphQLNonNull); ok {
+			return isSafeOutputTypeChange(oldNonNull.OfType, newNonNull.OfType)
+		}
+		return isSafeOutputTypeChange(oldType, newNonNull.OfType)
+	}
+	switch oldType := oldType.(type) {
+	case *GraphQLList:
+		newList, ok := newType.(*GraphQLList)
+		return ok && isSafeOutputTypeChange(oldType.OfType, newList.OfType)
+	case *GraphQLNonNull:
+		return false
+	}
+	if _, ok := newType.(*GraphQLList); ok {
+		return false
+	}
+	return oldType.GetName() == newType.GetName()
+}
+
+// Whether the values of an argument or input field of the old type are
+// values of the new type, e.g. when an argument becomes nullable.
+func isSafeInputTypeChange(oldType, newType GraphQLType) bool {
+	if oldNonNull, ok := oldType.(*GraphQLNonNull); ok {
+		if newNonNull, ok := newType.(*GraphQLNonNull); ok {
+			return isSafeInputTypeChange(oldNonNull.OfType, newNonNull.OfType)
+		}
+		return isSafeInputTypeChange(oldNonNull.OfType, newType)
+	}
+	switch newType := newType.(type) {
+	case *GraphQLList:
+		oldList, ok := oldType.(*GraphQLList)
+		return ok && isSafeInputTypeChange(oldList.OfType, newType.OfType)
+	case *GraphQLNonNull:
+		return false
+	}
+	if _, ok := oldType.(*GraphQLList); ok {
+		return false
+	}
+	return oldType.GetName() == newType.GetName()
+}
+
+func typeKind(ttype GraphQLType) string {
+	switch ttype.(type) {
+	case *GraphQLScalarType:
+		return "a scalar"
+	case *GraphQLObjectType:
+		return "an object"
+	case *GraphQLInterfaceType:
+		return "an interface"
+	case *GraphQLUnionType:
+		return "a union"
+	case *GraphQLEnumType:
+		return "an enum"
+	case *GraphQLInputObjectType:
+		return "an input object"
+	}
+	return fmt.Sprintf("%T", ttype)
+}
+
+func interfaceNames(interfaces []*GraphQLInterfaceType) map[string]bool {
+	names := map[string]bool{}
+	for _, iface := range interfaces {
+		names[iface.Name] = true
+	}
+	return names
+}
+
+func objectNames(objects []*GraphQLObjectType) map[string]bool {
+	names := map[string]bool{}
+	for _, object := range objects {
+		names[object.Name] = true
+	}
+	return names
+}
+
+// Returns the keys of two maps keyed by string, sorted.
+func unionKeys(a, b interface{}) []string {
+	seen := map[string]bool{}
+	for _, m := range []interface{}{a, b} {
+		for _, key := range reflect.ValueOf(m).MapKeys() {
+			seen[key.String()] = true
+		}
+	}
+	keys := []string{}
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
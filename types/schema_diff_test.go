@@ -0,0 +1,92 @@
+package types_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestDiffSchemas_ClassifiesTheChanges(t *testing.T) {
+	oldSchema, err := types.BuildSchema(`
+      type Query {
+        article(id: String): Article
+        feed: [Article]
+      }
+
+      type Article {
+        id: String
+        title: String
+        body: String
+        status: Status
+      }
+
+      enum Status { DRAFT, PUBLISHED }
+
+      type Image {
+        url: String
+      }
+	`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	newSchema, err := types.BuildSchema(`
+      type Query {
+        article(id: String!, lang: String!): Article
+        feed(limit: Int): [Article]
+      }
+
+      type Article {
+        id: String!
+        title: Int
+        subtitle: String
+        status: Status
+      }
+
+      enum Status { DRAFT, PUBLISHED, ARCHIVED }
+	`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []types.SchemaChange{
+		{types.SchemaChangeBreaking, "Field Article.body was removed."},
+		{types.SchemaChangeSafe, "Field Article.id changed type from String to String!."},
+		{types.SchemaChangeSafe, "Field Article.subtitle was added."},
+		{types.SchemaChangeBreaking, "Field Article.title changed type from String to Int."},
+		{types.SchemaChangeBreaking, "Type Image was removed."},
+		// referenced by the added limit argument
+		{types.SchemaChangeSafe, "Type Int was added."},
+		{types.SchemaChangeBreaking, "Argument Query.article(id:) changed type from String to String!."},
+		{types.SchemaChangeBreaking, "Required argument Query.article(lang:) was added."},
+		{types.SchemaChangeDangerous, "Optional argument Query.feed(limit:) was added."},
+		{types.SchemaChangeDangerous, "Enum value Status.ARCHIVED was added."},
+	}
+	if changes := types.DiffSchemas(oldSchema, newSchema); !reflect.DeepEqual(expected, changes) {
+		t.Fatalf("Unexpected changes, Diff: %v", testutil.Diff(expected, changes))
+	}
+}
+
+func TestDiffSchemas_ReportsNoChangesOfTheSameSchema(t *testing.T) {
+	sdl := `
+      type Query {
+        article(id: String!): Article
+      }
+
+      type Article {
+        id: String
+      }
+	`
+	oldSchema, err := types.BuildSchema(sdl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	newSchema, err := types.BuildSchema(sdl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	changes := types.DiffSchemas(oldSchema, newSchema)
+	if len(changes) != 0 {
+		t.Fatalf("Expected no changes, got %v", changes)
+	}
+}
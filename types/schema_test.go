@@ -0,0 +1,205 @@
+package types_test
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	gql "github.com/chris-ramon/graphql-go"
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestIsTypeSubTypeOf_ComparesNonNullListAndAbstractTypes(t *testing.T) {
+	nodeInterface := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Node",
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return nil
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLID,
+			},
+		},
+	})
+	articleType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "Article",
+		Interfaces: []*types.GraphQLInterfaceType{nodeInterface},
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLID,
+			},
+		},
+	})
+	imageType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Image",
+		Fields: types.GraphQLFieldConfigMap{
+			"url": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	mediaUnion := types.NewGraphQLUnionType(types.GraphQLUnionTypeConfig{
+		Name:  "Media",
+		Types: []*types.GraphQLObjectType{imageType},
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return imageType
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"node": &types.GraphQLFieldConfig{
+					Type: nodeInterface,
+				},
+				"media": &types.GraphQLFieldConfig{
+					Type: mediaUnion,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	nonNull := func(ttype types.GraphQLType) types.GraphQLType {
+		return types.NewGraphQLNonNull(ttype)
+	}
+	list := func(ttype types.GraphQLType) types.GraphQLType {
+		return types.NewGraphQLList(ttype)
+	}
+	tests := []struct {
+		maybeSubType types.GraphQLType
+		superType    types.GraphQLType
+		expected     bool
+	}{
+		{types.GraphQLString, types.GraphQLString, true},
+		{types.GraphQLString, types.GraphQLInt, false},
+		{nonNull(types.GraphQLString), types.GraphQLString, true},
+		{types.GraphQLString, nonNull(types.GraphQLString), false},
+		{nonNull(types.GraphQLString), nonNull(types.GraphQLString), true},
+		{list(types.GraphQLString), list(types.GraphQLString), true},
+		{list(nonNull(types.GraphQLString)), list(types.GraphQLString), true},
+		{list(types.GraphQLString), list(nonNull(types.GraphQLString)), false},
+		{nonNull(list(types.GraphQLString)), list(types.GraphQLString), true},
+		{list(types.GraphQLString), types.GraphQLString, false},
+		{types.GraphQLString, list(types.GraphQLString), false},
+		{articleType, nodeInterface, true},
+		{nonNull(articleType), nodeInterface, true},
+		{list(nonNull(articleType)), list(nodeInterface), true},
+		{nodeInterface, articleType, false},
+		{imageType, nodeInterface, false},
+		{imageType, mediaUnion, true},
+		{articleType, mediaUnion, false},
+		{list(imageType), mediaUnion, false},
+		{nil, types.GraphQLString, false},
+	}
+	for _, test := range tests {
+		if result := types.IsTypeSubTypeOf(&schema, test.maybeSubType, test.superType); result != test.expected {
+			t.Errorf("IsTypeSubTypeOf(%v, %v) expected %v, got %v", test.maybeSubType, test.superType, test.expected, result)
+		}
+	}
+}
+
+func TestTypeFromAST_WrapsTheNamedTypesOfTheSchema(t *testing.T) {
+	authorType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Author",
+		Fields: types.GraphQLFieldConfigMap{
+			"name": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"author": &types.GraphQLFieldConfig{
+					Type: authorType,
+				},
+				"count": &types.GraphQLFieldConfig{
+					Type: types.GraphQLInt,
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	tests := []struct {
+		typeRef  string
+		expected types.GraphQLType
+	}{
+		{"Int", types.GraphQLInt},
+		{"Author", authorType},
+		{"Int!", types.NewGraphQLNonNull(types.GraphQLInt)},
+		{"[Int!]", types.NewGraphQLList(types.NewGraphQLNonNull(types.GraphQLInt))},
+		{"[Author]!", types.NewGraphQLNonNull(types.NewGraphQLList(authorType))},
+		{"[[Int!]!]!", types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(
+			types.NewGraphQLList(types.NewGraphQLNonNull(types.GraphQLInt)))))},
+		{"Unknown", nil},
+		{"[Unknown!]!", nil},
+	}
+	for _, test := range tests {
+		astDoc := testutil.Parse(t, fmt.Sprintf(`query Q($v: %v) { count }`, test.typeRef))
+		typeAST := astDoc.Definitions[0].(*ast.OperationDefinition).VariableDefinitions[0].Type
+		ttype := types.TypeFromAST(schema, typeAST)
+		if !reflect.DeepEqual(test.expected, ttype) {
+			t.Fatalf("Unexpected type for %v, expected: %v, got: %v", test.typeRef, test.expected, ttype)
+		}
+	}
+}
+
+func TestGetDirectives_ReadsTheDirectivesWhileExecutedConcurrently(t *testing.T) {
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+			Name: "Query",
+			Fields: types.GraphQLFieldConfigMap{
+				"a": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+					Resolve: func(p types.GQLFRParams) interface{} {
+						return "a"
+					},
+				},
+			},
+		}),
+		Directives: []*types.GraphQLDirective{},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	expected := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"__schema": map[string]interface{}{
+				"directives": []interface{}{
+					map[string]interface{}{"name": "include"},
+					map[string]interface{}{"name": "skip"},
+					map[string]interface{}{"name": "deprecated"},
+					map[string]interface{}{"name": "specifiedBy"},
+				},
+			},
+		},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if directives := schema.GetDirectives(); len(directives) != 4 {
+				t.Errorf("expected the built-in directives, got: %v", directives)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			result := gql.ExecuteString(schema, `{ a @skip(if: true), __schema { directives { name } } }`)
+			if !reflect.DeepEqual(expected, result) {
+				t.Errorf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+			}
+		}()
+	}
+	wg.Wait()
+}
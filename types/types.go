@@ -1,16 +1,75 @@
 package types
 
 import (
+	"encoding/json"
+
 	"github.com/chris-ramon/graphql-go/errors"
 )
 
 type Schema interface{}
 
 type GraphQLResult struct {
-	Data   interface{}                           `json:"data"`
-	Errors []graphqlerrors.GraphQLFormattedError `json:"errors,omitempty"`
+	Data       interface{}                           `json:"data"`
+	Errors     []graphqlerrors.GraphQLFormattedError `json:"errors,omitempty"`
+	Extensions map[string]interface{}                `json:"extensions,omitempty"`
 }
 
 func (gqR *GraphQLResult) HasErrors() bool {
 	return (len(gqR.Errors) > 0)
 }
+
+// Encodes the result using the spec-compliant `data`, `errors` and
+// `extensions` keys.
+func (gqR GraphQLResult) MarshalJSON() ([]byte, error) {
+	return MarshalResult(&gqR, EnvelopeOptions{})
+}
+
+// EnvelopeOptions names the top-level keys of a marshaled GraphQLResult.
+// Empty keys default to the spec-compliant `data`, `errors` and `extensions`;
+// other names are only meant to support legacy transports.
+type EnvelopeOptions struct {
+	DataKey       string
+	ErrorsKey     string
+	ExtensionsKey string
+}
+
+// MarshalResult encodes the result as JSON using the envelope keys given by
+// options. Errors and extensions are omitted when empty, and so is data when
+// it is nil and the errors are request errors, i.e. when the request failed
+// before its execution started, e.g. because of a syntax error or invalid
+// variables. A field error, which has the path of the field, nulling the
+// data during the execution is encoded with `"data": null`.
+func MarshalResult(result *GraphQLResult, options EnvelopeOptions) ([]byte, error) {
+	if options.DataKey == "" {
+		options.DataKey = "data"
+	}
+	if options.ErrorsKey == "" {
+		options.ErrorsKey = "errors"
+	}
+	if options.ExtensionsKey == "" {
+		options.ExtensionsKey = "extensions"
+	}
+	envelope := map[string]interface{}{}
+	if result == nil {
+		return json.Marshal(envelope)
+	}
+	if result.Data != nil || !hasRequestErrorsOnly(result.Errors) {
+		envelope[options.DataKey] = result.Data
+	}
+	if len(result.Errors) > 0 {
+		envelope[options.ErrorsKey] = result.Errors
+	}
+	if len(result.Extensions) > 0 {
+		envelope[options.ExtensionsKey] = result.Extensions
+	}
+	return json.Marshal(envelope)
+}
+
+func hasRequestErrorsOnly(errs []graphqlerrors.GraphQLFormattedError) bool {
+	for _, err := range errs {
+		if err.Path != nil {
+			return false
+		}
+	}
+	return len(errs) > 0
+}
@@ -0,0 +1,85 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+func TestMarshalResult_UsesSpecEnvelopeByDefault(t *testing.T) {
+	result := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"a": "Apple",
+		},
+		Extensions: map[string]interface{}{
+			"tracing": "on",
+		},
+	}
+	b, err := types.MarshalResult(result, types.EnvelopeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"data":{"a":"Apple"},"extensions":{"tracing":"on"}}`
+	if string(b) != expected {
+		t.Fatalf("expected JSON %v, got: %v", expected, string(b))
+	}
+}
+
+func TestMarshalResult_UsesRenamedEnvelopeKeys(t *testing.T) {
+	result := &types.GraphQLResult{
+		Data: map[string]interface{}{
+			"a": "Apple",
+		},
+		Extensions: map[string]interface{}{
+			"tracing": "on",
+		},
+	}
+	b, err := types.MarshalResult(result, types.EnvelopeOptions{
+		DataKey:       "result",
+		ExtensionsKey: "meta",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"meta":{"tracing":"on"},"result":{"a":"Apple"}}`
+	if string(b) != expected {
+		t.Fatalf("expected JSON %v, got: %v", expected, string(b))
+	}
+}
+
+func TestMarshalResult_OmitsDataOnlyForRequestErrors(t *testing.T) {
+	tests := []struct {
+		result   *types.GraphQLResult
+		expected string
+	}{
+		{
+			result: &types.GraphQLResult{
+				Errors: []graphqlerrors.GraphQLFormattedError{
+					graphqlerrors.NewGraphQLFormattedError(`Variable "$id" expected value of type "ID!" but got: null.`),
+				},
+			},
+			expected: `{"errors":[{"message":"Variable \"$id\" expected value of type \"ID!\" but got: null."}]}`,
+		},
+		{
+			result: &types.GraphQLResult{
+				Errors: []graphqlerrors.GraphQLFormattedError{
+					graphqlerrors.GraphQLFormattedError{
+						Message: "Cannot return null for non-nullable field Query.viewer.",
+						Path:    []interface{}{"viewer"},
+					},
+				},
+			},
+			expected: `{"data":null,"errors":[{"message":"Cannot return null for non-nullable field Query.viewer.","path":["viewer"]}]}`,
+		},
+	}
+	for _, test := range tests {
+		b, err := types.MarshalResult(test.result, types.EnvelopeOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(b) != test.expected {
+			t.Fatalf("expected JSON %v, got: %v", test.expected, string(b))
+		}
+	}
+}
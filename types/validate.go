@@ -0,0 +1,282 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GraphQLSchemaErrors are the problems found in a schema definition, see
+// GraphQLSchema.Validate.
+type GraphQLSchemaErrors []error
+
+func (errs GraphQLSchemaErrors) Error() string {
+	messages := []string{}
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate reports all the problems of the schema definition at once, rather
+// than the first one NewGraphQLSchema stops at: a missing query type, type
+// definition errors such as fields without a type, interfaces which are not
+// correctly implemented, input objects referencing themselves through
+// non-null fields and user-defined types, fields, arguments and enum values
+// using the reserved `__` prefix.
+func (gq *GraphQLSchema) Validate() []error {
+	errs := []error{}
+	if gq.schemaConfig.Query == nil {
+		errs = append(errs, invariant(false, "Schema query must be Object Type but got: nil."))
+	}
+
+	// Check the reachable types, including the ones whose definition failed
+	// and are thus missing from the type map.
+	typeMap := GraphQLTypeMap{}
+	reported := map[string]bool{}
+	report := func(err error) {
+		if err != nil && !reported[err.Error()] {
+			reported[err.Error()] = true
+			errs = append(errs, err)
+		}
+	}
+	if gq.schemaConfig.Query != nil {
+		collectTypes(typeMap, gq.schemaConfig.Query, report)
+	}
+	if gq.schemaConfig.Mutation != nil {
+		collectTypes(typeMap, gq.schemaConfig.Mutation, report)
+	}
+	if gq.schemaConfig.Subscription != nil {
+		collectTypes(typeMap, gq.schemaConfig.Subscription, report)
+	}
+	typeNames := []string{}
+	for typeName := range typeMap {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		if isIntrospectionType(typeMap[typeName]) {
+			continue
+		}
+		if strings.HasPrefix(typeName, "__") {
+			report(invariant(false, fmt.Sprintf(
+				`Name "%v" must not begin with "__", which is reserved by GraphQL introspection.`, typeName)))
+		}
+		for _, name := range reservedMemberNames(typeMap[typeName]) {
+			report(invariant(false, fmt.Sprintf(
+				`Name "%v" must not begin with "__", which is reserved by GraphQL introspection.`, name)))
+		}
+	}
+
+	schema := *gq
+	if schema.possibleTypeMap == nil {
+		schema.buildPossibleTypes(typeMap)
+	}
+	for _, typeName := range typeNames {
+		ttype, ok := typeMap[typeName].(*GraphQLObjectType)
+		if !ok {
+			continue
+		}
+		for _, iface := range ttype.GetInterfaces() {
+			report(assertObjectImplementsInterface(&schema, ttype, iface))
+		}
+	}
+
+	inputObjects := []*GraphQLInputObjectType{}
+	for _, typeName := range typeNames {
+		if ttype, ok := typeMap[typeName].(*GraphQLInputObjectType); ok {
+			inputObjects = append(inputObjects, ttype)
+		}
+	}
+	for _, err := range assertInputObjectNonNullCycles(inputObjects) {
+		report(err)
+	}
+	return errs
+}
+
+// Adds the type and the types it references to the type map, reporting the
+// errors of their definitions. The first type of a name wins, conflicting
+// names are reported by typeMapReducer.
+func collectTypes(typeMap GraphQLTypeMap, ttype GraphQLType, report func(err error)) {
+	switch wrapping := ttype.(type) {
+	case *GraphQLList:
+		if wrapping.OfType != nil {
+			collectTypes(typeMap, wrapping.OfType, report)
+		}
+		return
+	case *GraphQLNonNull:
+		if wrapping.OfType != nil {
+			collectTypes(typeMap, wrapping.OfType, report)
+		}
+		return
+	}
+	if ttype == nil || ttype.GetName() == "" {
+		if ttype != nil {
+			report(ttype.GetError())
+		}
+		return
+	}
+	if _, ok := typeMap[ttype.GetName()]; ok {
+		return
+	}
+	typeMap[ttype.GetName()] = ttype
+	report(ttype.GetError())
+
+	switch ttype := ttype.(type) {
+	case *GraphQLObjectType:
+		interfaces := ttype.GetInterfaces()
+		report(ttype.GetError())
+		fields := ttype.GetFields()
+		report(ttype.GetError())
+		for _, iface := range interfaces {
+			collectTypes(typeMap, iface, report)
+		}
+		collectFieldTypes(typeMap, fields, report)
+	case *GraphQLInterfaceType:
+		fields := ttype.GetFields()
+		report(ttype.GetError())
+		for _, possibleType := range ttype.GetPossibleTypes() {
+			collectTypes(typeMap, possibleType, report)
+		}
+		collectFieldTypes(typeMap, fields, report)
+	case *GraphQLUnionType:
+		for _, possibleType := range ttype.GetPossibleTypes() {
+			collectTypes(typeMap, possibleType, report)
+		}
+		report(ttype.GetError())
+	case *GraphQLInputObjectType:
+		fields := ttype.GetFields()
+		report(ttype.GetError())
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			if fields[fieldName].Type != nil {
+				collectTypes(typeMap, fields[fieldName].Type, report)
+			}
+		}
+	}
+}
+
+func collectFieldTypes(typeMap GraphQLTypeMap, fields GraphQLFieldDefinitionMap, report func(err error)) {
+	fieldNames := []string{}
+	for fieldName := range fields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		field := fields[fieldName]
+		for _, arg := range field.Args {
+			if arg.Type != nil {
+				collectTypes(typeMap, arg.Type, report)
+			}
+		}
+		if field.Type != nil {
+			collectTypes(typeMap, field.Type, report)
+		}
+	}
+}
+
+// Returns the coordinates of the fields, arguments, enum values and input
+// fields of a type whose names begin with "__", e.g. "Query.__secret",
+// "Query.article(__id:)" or "Color.__RED", sorted.
+func reservedMemberNames(ttype GraphQLType) []string {
+	names := []string{}
+	reserved := func(name string) bool {
+		return strings.HasPrefix(name, "__")
+	}
+	addFields := func(fields GraphQLFieldDefinitionMap) {
+		for fieldName, field := range fields {
+			if reserved(fieldName) {
+				names = append(names, ttype.GetName()+"."+fieldName)
+			}
+			for _, arg := range field.Args {
+				if reserved(arg.Name) {
+					names = append(names, fmt.Sprintf("%v.%v(%v:)", ttype.GetName(), fieldName, arg.Name))
+				}
+			}
+		}
+	}
+	switch ttype := ttype.(type) {
+	case *GraphQLObjectType:
+		addFields(ttype.GetFields())
+	case *GraphQLInterfaceType:
+		addFields(ttype.GetFields())
+	case *GraphQLEnumType:
+		for _, value := range ttype.GetValues() {
+			if reserved(value.Name) {
+				names = append(names, ttype.Name+"."+value.Name)
+			}
+		}
+	case *GraphQLInputObjectType:
+		for fieldName := range ttype.GetFields() {
+			if reserved(fieldName) {
+				names = append(names, ttype.Name+"."+fieldName)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isIntrospectionType(ttype GraphQLType) bool {
+	switch ttype {
+	case __Schema, __Directive, __DirectiveLocation, __Type, __Field, __InputValue, __EnumValue, __TypeKind:
+		return true
+	}
+	return false
+}
+
+// Reports the input objects which reference themselves through a series of
+// non-null fields, as no value of them could be provided, listing the fields
+// of each cycle once. Nullable and list fields break a cycle.
+func assertInputObjectNonNullCycles(inputObjects []*GraphQLInputObjectType) []error {
+	errs := []error{}
+	// input objects whose cycles were all reported
+	visited := map[*GraphQLInputObjectType]bool{}
+	// position of the input objects of the current path of fields
+	pathIndex := map[*GraphQLInputObjectType]int{}
+	fieldPath := []string{}
+	var detectCycles func(inputObject *GraphQLInputObjectType)
+	detectCycles = func(inputObject *GraphQLInputObjectType) {
+		if visited[inputObject] {
+			return
+		}
+		visited[inputObject] = true
+		pathIndex[inputObject] = len(fieldPath)
+
+		fields := inputObject.GetFields()
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			nonNull, ok := fields[fieldName].Type.(*GraphQLNonNull)
+			if !ok {
+				continue
+			}
+			fieldType, ok := nonNull.OfType.(*GraphQLInputObjectType)
+			if !ok {
+				continue
+			}
+			fieldPath = append(fieldPath, fieldName)
+			if cycleIndex, ok := pathIndex[fieldType]; ok {
+				errs = append(errs, invariant(false, fmt.Sprintf(
+					`Cannot reference Input Object "%v" within itself through a series of non-null fields: "%v".`,
+					fieldType, strings.Join(fieldPath[cycleIndex:], "."))))
+			} else {
+				detectCycles(fieldType)
+			}
+			fieldPath = fieldPath[:len(fieldPath)-1]
+		}
+		delete(pathIndex, inputObject)
+	}
+	for _, inputObject := range inputObjects {
+		detectCycles(inputObject)
+	}
+	return errs
+}
@@ -1,9 +1,12 @@
 package types_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/testutil"
 	"github.com/chris-ramon/graphql-go/types"
 )
 
@@ -324,7 +327,8 @@ func TestTypeSystem_SchemaMustContainUniquelyNamedTypes_RejectsASchemaWhichRedef
 	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
 		Query: queryType,
 	})
-	expectedError := `Schema must contain unique named types but contains multiple types named "String".`
+	expectedError := `Schema must contain unique named types but contains multiple types named "String", ` +
+		`found at Query.fake and at Query.normal.`
 	if err == nil || err.Error() != expectedError {
 		t.Fatalf("Expected error: %v, got %v", expectedError, err)
 	}
@@ -361,7 +365,58 @@ func TestTypeSystem_SchemaMustContainUniquelyNamedTypes_RejectsASchemaWhichDefin
 	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
 		Query: queryType,
 	})
-	expectedError := `Schema must contain unique named types but contains multiple types named "SameName".`
+	expectedError := `Schema must contain unique named types but contains multiple types named "SameName", ` +
+		`found at Query.a and at Query.b.`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+func TestTypeSystem_SchemaMustContainUniquelyNamedTypes_ReportsTheArgumentsAndInputFieldsDefiningATypeTwice(t *testing.T) {
+
+	newInputType := func() *types.GraphQLInputObjectType {
+		return types.NewGraphQLInputObjectType(types.InputObjectConfig{
+			Name: "SameInput",
+			Fields: types.InputObjectConfigFieldMap{
+				"f": &types.InputObjectFieldConfig{
+					Type: types.GraphQLString,
+				},
+			},
+		})
+	}
+	wrapperInput := types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "WrapperInput",
+		Fields: types.InputObjectConfigFieldMap{
+			"inner": &types.InputObjectFieldConfig{
+				Type: types.NewGraphQLList(newInputType()),
+			},
+		},
+	})
+	queryType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"a": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"input": &types.GraphQLArgumentConfig{
+						Type: types.NewGraphQLNonNull(newInputType()),
+					},
+				},
+			},
+			"b": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"wrapper": &types.GraphQLArgumentConfig{
+						Type: wrapperInput,
+					},
+				},
+			},
+		},
+	})
+	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: queryType,
+	})
+	expectedError := `Schema must contain unique named types but contains multiple types named "SameInput", ` +
+		`found at Query.a(input:) and at WrapperInput.inner.`
 	if err == nil || err.Error() != expectedError {
 		t.Fatalf("Expected error: %v, got %v", expectedError, err)
 	}
@@ -412,7 +467,8 @@ func TestTypeSystem_SchemaMustContainUniquelyNamedTypes_RejectsASchemaWhichHaveS
 	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
 		Query: queryType,
 	})
-	expectedError := `Schema must contain unique named types but contains multiple types named "BadObject".`
+	expectedError := `Schema must contain unique named types but contains multiple types named "BadObject", ` +
+		`found at implementations of AnotherInterface and at implementations of AnotherInterface.`
 	if err == nil || err.Error() != expectedError {
 		t.Fatalf("Expected error: %v, got %v", expectedError, err)
 	}
@@ -1385,24 +1441,359 @@ func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_RejectsAnObjectWit
 		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
 			return nil
 		},
+		Fields: types.GraphQLFieldConfigMap{
+			"field": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.GraphQLString),
+			},
+		},
+	})
+	anotherObject := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "AnotherObject",
+		Interfaces: []*types.GraphQLInterfaceType{anotherInterface},
 		Fields: types.GraphQLFieldConfigMap{
 			"field": &types.GraphQLFieldConfig{
 				Type: types.GraphQLString,
 			},
 		},
 	})
+	_, err := schemaWithObjectFieldOfType(anotherObject)
+	expectedError := `AnotherInterface.field expects type "String!" but AnotherObject.field provides type "String".`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_ReportsNestedWrapperTypesInSpecNotation(t *testing.T) {
+	anotherInterface := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "AnotherInterface",
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return nil
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"field": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(types.GraphQLString))),
+			},
+		},
+	})
 	anotherObject := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
 		Name:       "AnotherObject",
 		Interfaces: []*types.GraphQLInterfaceType{anotherInterface},
 		Fields: types.GraphQLFieldConfigMap{
 			"field": &types.GraphQLFieldConfig{
-				Type: types.NewGraphQLNonNull(types.GraphQLString),
+				Type: types.NewGraphQLList(types.NewGraphQLList(types.GraphQLString)),
+			},
+		},
+	})
+	_, err := schemaWithObjectFieldOfType(anotherObject)
+	expectedError := `AnotherInterface.field expects type "[String!]!" but AnotherObject.field provides type "[[String]]".`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error: %v, got %v", expectedError, err)
+	}
+}
+func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_AcceptsAnObjectWithANonNullInterfaceFieldType(t *testing.T) {
+	anotherInterface := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "AnotherInterface",
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return nil
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"field": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLList(types.GraphQLString),
+			},
+		},
+	})
+	anotherObject := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "AnotherObject",
+		Interfaces: []*types.GraphQLInterfaceType{anotherInterface},
+		Fields: types.GraphQLFieldConfigMap{
+			"field": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(types.GraphQLString))),
+			},
+		},
+	})
+	_, err := schemaWithObjectFieldOfType(anotherObject)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v for type "%v"`, err, anotherObject)
+	}
+}
+func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_AcceptsAnObjectWithASubtypedInterfaceField(t *testing.T) {
+	var anotherInterface *types.GraphQLInterfaceType
+	var anotherObject *types.GraphQLObjectType
+	anotherInterface = types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "AnotherInterface",
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return nil
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"field": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	anotherInterface.AddFieldConfig("related", &types.GraphQLFieldConfig{
+		Type: anotherInterface,
+	})
+	anotherObject = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "AnotherObject",
+		Interfaces: []*types.GraphQLInterfaceType{anotherInterface},
+		Fields: (types.GraphQLFieldConfigMapThunk)(func() types.GraphQLFieldConfigMap {
+			return types.GraphQLFieldConfigMap{
+				"field": &types.GraphQLFieldConfig{
+					Type: types.GraphQLString,
+				},
+				"related": &types.GraphQLFieldConfig{
+					Type: anotherObject,
+				},
+			}
+		}),
+	})
+	_, err := schemaWithObjectFieldOfType(anotherObject)
+	if err != nil {
+		t.Fatalf(`unexpected error: %v for type "%v"`, err, anotherObject)
+	}
+}
+func TestTypeSystem_ObjectsMustAdhereToInterfaceTheyImplement_RejectsAnObjectWithANonSubtypedInterfaceField(t *testing.T) {
+	anotherInterface := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "AnotherInterface",
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return nil
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"field": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	anotherInterface.AddFieldConfig("related", &types.GraphQLFieldConfig{
+		Type: anotherInterface,
+	})
+	anotherObject := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name:       "AnotherObject",
+		Interfaces: []*types.GraphQLInterfaceType{anotherInterface},
+		Fields: types.GraphQLFieldConfigMap{
+			"field": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+			"related": &types.GraphQLFieldConfig{
+				Type: someObjectType,
 			},
 		},
 	})
 	_, err := schemaWithObjectFieldOfType(anotherObject)
-	expectedError := `AnotherInterface.field expects type "String" but AnotherObject.field provides type "String!".`
+	expectedError := `AnotherInterface.related expects type "AnotherInterface" but AnotherObject.related provides type "SomeObject".`
 	if err == nil || err.Error() != expectedError {
 		t.Fatalf("Expected error: %v, got %v", expectedError, err)
 	}
 }
+
+func TestTypeSystem_SchemaValidation_ReportsAllTheDefectsOfASchemaAtOnce(t *testing.T) {
+	nodeInterface := types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+		Name: "Node",
+		ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+			return nil
+		},
+		Fields: types.GraphQLFieldConfigMap{
+			"id": &types.GraphQLFieldConfig{
+				Type: types.GraphQLID,
+			},
+		},
+	})
+	queryType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"a": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+					Name: "BadFields",
+					Fields: types.GraphQLFieldConfigMap{
+						"bad": &types.GraphQLFieldConfig{},
+					},
+				}),
+			},
+			"b": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+					Name:       "Article",
+					Interfaces: []*types.GraphQLInterfaceType{nodeInterface},
+					Fields: types.GraphQLFieldConfigMap{
+						"title": &types.GraphQLFieldConfig{
+							Type: types.GraphQLString,
+						},
+					},
+				}),
+			},
+			"c": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+					Name: "__Foo",
+					Fields: types.GraphQLFieldConfigMap{
+						"f": &types.GraphQLFieldConfig{
+							Type: types.GraphQLString,
+						},
+					},
+				}),
+			},
+		},
+	})
+	schema, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: queryType,
+	})
+	expectedErrors := []string{
+		`BadFields.bad field type must be Output Type but got: <nil>.`,
+		`Name "__Foo" must not begin with "__", which is reserved by GraphQL introspection.`,
+		`"Node" expects field "id" but "Article" does not provide it.`,
+	}
+	schemaErrors, ok := err.(types.GraphQLSchemaErrors)
+	if !ok {
+		t.Fatalf("Expected schema errors, got %v", err)
+	}
+	if err.Error() != strings.Join(expectedErrors, "\n") {
+		t.Fatalf("Unexpected error, Diff: %v", testutil.Diff(strings.Join(expectedErrors, "\n"), err.Error()))
+	}
+	errs := schema.Validate()
+	if len(errs) != len(schemaErrors) {
+		t.Fatalf("Expected Validate to report %v errors, got %v", len(schemaErrors), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != expectedErrors[i] {
+			t.Fatalf("Expected error: %v, got %v", expectedErrors[i], err)
+		}
+	}
+}
+
+func TestTypeSystem_SchemaValidation_RejectsReservedAndInvalidNames(t *testing.T) {
+	colorEnum := types.NewGraphQLEnumType(types.GraphQLEnumTypeConfig{
+		Name: "Color",
+		Values: types.GraphQLEnumValueConfigMap{
+			"RED":   &types.GraphQLEnumValueConfig{},
+			"__RED": &types.GraphQLEnumValueConfig{},
+		},
+	})
+	queryType := types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "Query",
+		Fields: types.GraphQLFieldConfigMap{
+			"foo": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+					Name: "__Foo",
+					Fields: types.GraphQLFieldConfigMap{
+						"f": &types.GraphQLFieldConfig{
+							Type: types.GraphQLString,
+						},
+					},
+				}),
+			},
+			"bar": &types.GraphQLFieldConfig{
+				Type: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+					Name: "Bar",
+					Fields: types.GraphQLFieldConfigMap{
+						"1bad": &types.GraphQLFieldConfig{
+							Type: types.GraphQLString,
+						},
+					},
+				}),
+			},
+			"color": &types.GraphQLFieldConfig{
+				Type: colorEnum,
+				Args: types.GraphQLFieldConfigArgumentMap{
+					"__id": &types.GraphQLArgumentConfig{
+						Type: types.GraphQLID,
+					},
+				},
+			},
+			"__secret": &types.GraphQLFieldConfig{
+				Type: types.GraphQLString,
+			},
+		},
+	})
+	_, err := types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+		Query: queryType,
+	})
+	expectedErrors := []string{
+		`Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "1bad" does not.`,
+		`Name "Color.__RED" must not begin with "__", which is reserved by GraphQL introspection.`,
+		`Name "Query.__secret" must not begin with "__", which is reserved by GraphQL introspection.`,
+		`Name "Query.color(__id:)" must not begin with "__", which is reserved by GraphQL introspection.`,
+		`Name "__Foo" must not begin with "__", which is reserved by GraphQL introspection.`,
+	}
+	if err == nil {
+		t.Fatalf("Expected the schema to be rejected")
+	}
+	if err.Error() != strings.Join(expectedErrors, "\n") {
+		t.Fatalf("Unexpected error, Diff: %v", testutil.Diff(strings.Join(expectedErrors, "\n"), err.Error()))
+	}
+}
+
+func TestTypeSystem_SchemaValidation_ReportsNoErrorsForAValidSchema(t *testing.T) {
+	schema, err := schemaWithFieldType(someObjectType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := schema.Validate(); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestTypeSystem_InputObjectsMustNotReferenceThemselvesThroughNonNullFields_AcceptsNullableAndListRecursion(t *testing.T) {
+	var filterInput *types.GraphQLInputObjectType
+	filterInput = types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "FilterInput",
+		Fields: types.InputObjectConfigFieldMapThunk(func() types.InputObjectConfigFieldMap {
+			return types.InputObjectConfigFieldMap{
+				"not": &types.InputObjectFieldConfig{
+					Type: filterInput,
+				},
+				"and": &types.InputObjectFieldConfig{
+					Type: types.NewGraphQLNonNull(types.NewGraphQLList(types.NewGraphQLNonNull(filterInput))),
+				},
+			}
+		}),
+	})
+	_, err := schemaWithInputObject(filterInput)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTypeSystem_InputObjectsMustNotReferenceThemselvesThroughNonNullFields_RejectsANonNullCycle(t *testing.T) {
+	var aInput, bInput, cInput *types.GraphQLInputObjectType
+	aInput = types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "AInput",
+		Fields: types.InputObjectConfigFieldMapThunk(func() types.InputObjectConfigFieldMap {
+			return types.InputObjectConfigFieldMap{
+				"b": &types.InputObjectFieldConfig{
+					Type: types.NewGraphQLNonNull(bInput),
+				},
+				"self": &types.InputObjectFieldConfig{
+					Type: types.NewGraphQLNonNull(aInput),
+				},
+			}
+		}),
+	})
+	bInput = types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "BInput",
+		Fields: types.InputObjectConfigFieldMapThunk(func() types.InputObjectConfigFieldMap {
+			return types.InputObjectConfigFieldMap{
+				"c": &types.InputObjectFieldConfig{
+					Type: types.NewGraphQLNonNull(cInput),
+				},
+			}
+		}),
+	})
+	cInput = types.NewGraphQLInputObjectType(types.InputObjectConfig{
+		Name: "CInput",
+		Fields: types.InputObjectConfigFieldMapThunk(func() types.InputObjectConfigFieldMap {
+			return types.InputObjectConfigFieldMap{
+				"a": &types.InputObjectFieldConfig{
+					Type: types.NewGraphQLNonNull(aInput),
+				},
+				"optionalB": &types.InputObjectFieldConfig{
+					Type: bInput,
+				},
+			}
+		}),
+	})
+	_, err := schemaWithInputObject(aInput)
+	expectedErrors := []string{
+		`Cannot reference Input Object "AInput" within itself through a series of non-null fields: "b.c.a".`,
+		`Cannot reference Input Object "AInput" within itself through a series of non-null fields: "self".`,
+	}
+	if err == nil || err.Error() != strings.Join(expectedErrors, "\n") {
+		t.Fatalf("Unexpected error, Diff: %v", testutil.Diff(strings.Join(expectedErrors, "\n"), fmt.Sprintf("%v", err)))
+	}
+}
@@ -0,0 +1,132 @@
+package validator_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/testutil"
+	"github.com/chris-ramon/graphql-go/types"
+	"github.com/chris-ramon/graphql-go/validator"
+)
+
+var petInterface = types.NewGraphQLInterfaceType(types.GraphQLInterfaceTypeConfig{
+	Name: "Pet",
+	Fields: types.GraphQLFieldConfigMap{
+		"name": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+	},
+})
+var dogType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name:       "Dog",
+	Interfaces: []*types.GraphQLInterfaceType{petInterface},
+	Fields: types.GraphQLFieldConfigMap{
+		"name": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"nickname": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"barkVolume": &types.GraphQLFieldConfig{
+			Type: types.GraphQLInt,
+		},
+		"owner": &types.GraphQLFieldConfig{
+			Type: humanType,
+		},
+		"pic": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+			Args: types.GraphQLFieldConfigArgumentMap{
+				"width": &types.GraphQLArgumentConfig{
+					Type: types.GraphQLInt,
+				},
+			},
+		},
+	},
+})
+var catType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name:       "Cat",
+	Interfaces: []*types.GraphQLInterfaceType{petInterface},
+	Fields: types.GraphQLFieldConfigMap{
+		"name": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"meowVolume": &types.GraphQLFieldConfig{
+			Type: types.NewGraphQLNonNull(types.GraphQLInt),
+		},
+		"owner": &types.GraphQLFieldConfig{
+			Type: humanType,
+		},
+	},
+})
+var humanType = types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+	Name: "Human",
+	Fields: types.GraphQLFieldConfigMap{
+		"name": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"nickname": &types.GraphQLFieldConfig{
+			Type: types.GraphQLString,
+		},
+		"pets": &types.GraphQLFieldConfig{
+			Type: types.NewGraphQLList(petInterface),
+		},
+	},
+})
+var catOrDogUnion = types.NewGraphQLUnionType(types.GraphQLUnionTypeConfig{
+	Name:  "CatOrDog",
+	Types: []*types.GraphQLObjectType{dogType, catType},
+	ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+		return nil
+	},
+})
+var dogOrHumanUnion = types.NewGraphQLUnionType(types.GraphQLUnionTypeConfig{
+	Name:  "DogOrHuman",
+	Types: []*types.GraphQLObjectType{dogType, humanType},
+	ResolveType: func(value interface{}, info types.GraphQLResolveInfo) *types.GraphQLObjectType {
+		return nil
+	},
+})
+
+var validatorTestSchema, _ = types.NewGraphQLSchema(types.GraphQLSchemaConfig{
+	Query: types.NewGraphQLObjectType(types.GraphQLObjectTypeConfig{
+		Name: "QueryRoot",
+		Fields: types.GraphQLFieldConfigMap{
+			"dog": &types.GraphQLFieldConfig{
+				Type: dogType,
+			},
+			"cat": &types.GraphQLFieldConfig{
+				Type: catType,
+			},
+			"pet": &types.GraphQLFieldConfig{
+				Type: petInterface,
+			},
+			"human": &types.GraphQLFieldConfig{
+				Type: humanType,
+			},
+			"catOrDog": &types.GraphQLFieldConfig{
+				Type: catOrDogUnion,
+			},
+			"dogOrHuman": &types.GraphQLFieldConfig{
+				Type: dogOrHumanUnion,
+			},
+		},
+	}),
+})
+
+func expectPassesRule(t *testing.T, rule validator.ValidationRuleFn, query string) {
+	result := validator.ValidateDocumentWithRules(validatorTestSchema, testutil.Parse(t, query), []validator.ValidationRuleFn{rule})
+	if !result.IsValid || len(result.Errors) > 0 {
+		t.Fatalf("Expected the query to pass the rule, got errors: %v", result.Errors)
+	}
+}
+
+func expectFailsRule(t *testing.T, rule validator.ValidationRuleFn, query string, expectedErrors []graphqlerrors.GraphQLFormattedError) {
+	result := validator.ValidateDocumentWithRules(validatorTestSchema, testutil.Parse(t, query), []validator.ValidationRuleFn{rule})
+	if result.IsValid {
+		t.Fatalf("Expected the query to fail the rule")
+	}
+	if !reflect.DeepEqual(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected errors, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
@@ -0,0 +1,28 @@
+package validator
+
+import (
+	"github.com/chris-ramon/graphql-go/language/ast"
+)
+
+// LoneAnonymousOperationRule reports the anonymous operations of a document
+// which defines other operations, as an operation name could not select them.
+func LoneAnonymousOperationRule(context *ValidationContext) []error {
+	errs := []error{}
+	operations := []*ast.OperationDefinition{}
+	for _, definition := range context.Document().Definitions {
+		if operation, ok := definition.(*ast.OperationDefinition); ok {
+			operations = append(operations, operation)
+		}
+	}
+	if len(operations) < 2 {
+		return errs
+	}
+	for _, operation := range operations {
+		if operation.Name == nil || operation.Name.Value == "" {
+			errs = append(errs, newValidationError(
+				`This anonymous operation must be the only defined operation.`,
+				[]ast.Node{operation}))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,37 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/validator"
+)
+
+func TestValidate_LoneAnonymousOperation_AcceptsASingleAnonymousOperation(t *testing.T) {
+	expectPassesRule(t, validator.LoneAnonymousOperationRule, `
+      {
+        dog { name }
+      }
+      fragment dogName on Dog { name }
+    `)
+}
+
+func TestValidate_LoneAnonymousOperation_AcceptsMultipleNamedOperations(t *testing.T) {
+	expectPassesRule(t, validator.LoneAnonymousOperationRule, `
+      query Foo { dog { name } }
+      query Bar { cat { name } }
+    `)
+}
+
+func TestValidate_LoneAnonymousOperation_RejectsAnAnonymousOperationWithANamedOne(t *testing.T) {
+	expectFailsRule(t, validator.LoneAnonymousOperationRule, `
+      {
+        dog { name }
+      }
+      query Foo {
+        cat { name }
+      }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`This anonymous operation must be the only defined operation.`, 2, 7),
+	})
+}
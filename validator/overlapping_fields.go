@@ -0,0 +1,227 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/printer"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// OverlappingFieldsCanBeMergedRule reports the fields of a selection set
+// which share a response name but would resolve to different values: fields
+// with different names or arguments, fields returning different types, or
+// fields whose sub-fields conflict. Fields selected through fragments are
+// compared as well.
+func OverlappingFieldsCanBeMergedRule(context *ValidationContext) []error {
+	errs := []error{}
+	compared := map[comparedFields]bool{}
+	visitSelectionSets(context, func(parentType types.GraphQLType, selectionSet *ast.SelectionSet) {
+		fieldMap := collectFieldsAndDefs(context, parentType, selectionSet, fieldsAndDefsMap{}, map[string]bool{})
+		for _, conflict := range findConflicts(context, fieldMap, false, compared) {
+			nodes := []ast.Node{}
+			for _, field := range conflict.fields {
+				nodes = append(nodes, field)
+			}
+			errs = append(errs, newValidationError(fmt.Sprintf(
+				`Fields "%v" conflict because %v. Use different aliases on the fields to fetch both if this was intentional.`,
+				conflict.responseName, conflictReasonMessage(conflict.reason)), nodes))
+		}
+	})
+	return errs
+}
+
+// A field selected under a response name, with the type it is selected on.
+type fieldAndDef struct {
+	parentType types.GraphQLType
+	field      *ast.Field
+	fieldDef   *types.GraphQLFieldDefinition
+}
+
+// The fields of a selection set by response name, in the order in which the
+// response names appear.
+type fieldsAndDefsMap struct {
+	responseNames []string
+	fields        map[string][]fieldAndDef
+}
+
+// A pair of fields compared, whether their parent fields are never both
+// resolved or not: fields which may differ under mutually exclusive parents
+// must be compared again if they are also selected under the same parent.
+type comparedFields struct {
+	field1            *ast.Field
+	field2            *ast.Field
+	mutuallyExclusive bool
+}
+
+type fieldConflict struct {
+	responseName string
+	// why the fields conflict: a message, or the conflicts of their
+	// sub-fields
+	reason interface{}
+	fields []*ast.Field
+}
+
+func collectFieldsAndDefs(context *ValidationContext, parentType types.GraphQLType, selectionSet *ast.SelectionSet, fieldMap fieldsAndDefsMap, visitedFragments map[string]bool) fieldsAndDefsMap {
+	if fieldMap.fields == nil {
+		fieldMap.fields = map[string][]fieldAndDef{}
+	}
+	if selectionSet == nil {
+		return fieldMap
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Name == nil {
+				continue
+			}
+			responseName := selection.Name.Value
+			if selection.Alias != nil {
+				responseName = selection.Alias.Value
+			}
+			if _, ok := fieldMap.fields[responseName]; !ok {
+				fieldMap.responseNames = append(fieldMap.responseNames, responseName)
+			}
+			fieldMap.fields[responseName] = append(fieldMap.fields[responseName], fieldAndDef{
+				parentType: parentType,
+				field:      selection,
+				fieldDef:   getFieldDef(context.Schema(), parentType, selection),
+			})
+		case *ast.InlineFragment:
+			fragmentType := typeCondition(context.Schema(), selection.TypeCondition, parentType)
+			fieldMap = collectFieldsAndDefs(context, fragmentType, selection.SelectionSet, fieldMap, visitedFragments)
+		case *ast.FragmentSpread:
+			if selection.Name == nil || visitedFragments[selection.Name.Value] {
+				continue
+			}
+			visitedFragments[selection.Name.Value] = true
+			fragment := context.Fragment(selection.Name.Value)
+			if fragment == nil {
+				continue
+			}
+			fragmentType := typeCondition(context.Schema(), fragment.TypeCondition, nil)
+			fieldMap = collectFieldsAndDefs(context, fragmentType, fragment.SelectionSet, fieldMap, visitedFragments)
+		}
+	}
+	return fieldMap
+}
+
+// Compares the fields of each response name with each other, each pair of
+// fields is compared once across the document. parentFieldsAreMutuallyExclusive
+// is true if the fields are sub-fields of fields never both resolved.
+func findConflicts(context *ValidationContext, fieldMap fieldsAndDefsMap, parentFieldsAreMutuallyExclusive bool, compared map[comparedFields]bool) []*fieldConflict {
+	conflicts := []*fieldConflict{}
+	for _, responseName := range fieldMap.responseNames {
+		fields := fieldMap.fields[responseName]
+		for i := 0; i < len(fields); i++ {
+			for j := i + 1; j < len(fields); j++ {
+				if conflict := findConflict(context, responseName, fields[i], fields[j], parentFieldsAreMutuallyExclusive, compared); conflict != nil {
+					conflicts = append(conflicts, conflict)
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+func findConflict(context *ValidationContext, responseName string, field1, field2 fieldAndDef, parentFieldsAreMutuallyExclusive bool, compared map[comparedFields]bool) *fieldConflict {
+	ast1, ast2 := field1.field, field2.field
+	// a pair compared under the same parent covers mutually exclusive ones
+	if ast1 == ast2 || compared[comparedFields{ast1, ast2, false}] ||
+		compared[comparedFields{ast1, ast2, parentFieldsAreMutuallyExclusive}] {
+		return nil
+	}
+	compared[comparedFields{ast1, ast2, parentFieldsAreMutuallyExclusive}] = true
+	compared[comparedFields{ast2, ast1, parentFieldsAreMutuallyExclusive}] = true
+
+	// Fields selected on different object types, or under such fields, are
+	// never both resolved, they may differ as long as their values can be
+	// merged.
+	_, isObject1 := field1.parentType.(*types.GraphQLObjectType)
+	_, isObject2 := field2.parentType.(*types.GraphQLObjectType)
+	mutuallyExclusive := parentFieldsAreMutuallyExclusive ||
+		field1.parentType != field2.parentType && isObject1 && isObject2
+
+	if !mutuallyExclusive {
+		name1, name2 := ast1.Name.Value, ast2.Name.Value
+		if name1 != name2 {
+			return &fieldConflict{
+				responseName: responseName,
+				reason:       fmt.Sprintf("%v and %v are different fields", name1, name2),
+				fields:       []*ast.Field{ast1, ast2},
+			}
+		}
+		if !sameArguments(ast1.Arguments, ast2.Arguments) {
+			return &fieldConflict{
+				responseName: responseName,
+				reason:       "they have differing arguments",
+				fields:       []*ast.Field{ast1, ast2},
+			}
+		}
+	}
+
+	var type1, type2 types.GraphQLType
+	if field1.fieldDef != nil {
+		type1 = field1.fieldDef.Type
+	}
+	if field2.fieldDef != nil {
+		type2 = field2.fieldDef.Type
+	}
+	if type1 != nil && type2 != nil && type1.String() != type2.String() {
+		return &fieldConflict{
+			responseName: responseName,
+			reason:       fmt.Sprintf("they return differing types %v and %v", type1, type2),
+			fields:       []*ast.Field{ast1, ast2},
+		}
+	}
+
+	if ast1.SelectionSet != nil && ast2.SelectionSet != nil {
+		subfieldMap := collectFieldsAndDefs(context, types.GetNamedType(type1), ast1.SelectionSet, fieldsAndDefsMap{}, map[string]bool{})
+		subfieldMap = collectFieldsAndDefs(context, types.GetNamedType(type2), ast2.SelectionSet, subfieldMap, map[string]bool{})
+		conflicts := findConflicts(context, subfieldMap, mutuallyExclusive, compared)
+		if len(conflicts) > 0 {
+			fields := []*ast.Field{ast1, ast2}
+			for _, conflict := range conflicts {
+				fields = append(fields, conflict.fields...)
+			}
+			return &fieldConflict{
+				responseName: responseName,
+				reason:       conflicts,
+				fields:       fields,
+			}
+		}
+	}
+	return nil
+}
+
+func sameArguments(arguments1, arguments2 []*ast.Argument) bool {
+	if len(arguments1) != len(arguments2) {
+		return false
+	}
+	for _, argument1 := range arguments1 {
+		found := false
+		for _, argument2 := range arguments2 {
+			if argument1.Name.Value == argument2.Name.Value {
+				found = fmt.Sprintf("%v", printer.Print(argument1.Value)) == fmt.Sprintf("%v", printer.Print(argument2.Value))
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func conflictReasonMessage(reason interface{}) string {
+	if conflicts, ok := reason.([]*fieldConflict); ok {
+		messages := []string{}
+		for _, conflict := range conflicts {
+			messages = append(messages, fmt.Sprintf(`subfields "%v" conflict because %v`,
+				conflict.responseName, conflictReasonMessage(conflict.reason)))
+		}
+		return strings.Join(messages, " and ")
+	}
+	return fmt.Sprintf("%v", reason)
+}
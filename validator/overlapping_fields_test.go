@@ -0,0 +1,164 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/validator"
+)
+
+func ruleError(message string, locations ...int) graphqlerrors.GraphQLFormattedError {
+	err := graphqlerrors.GraphQLFormattedError{
+		Message:   message,
+		Locations: []location.SourceLocation{},
+	}
+	for i := 0; i+1 < len(locations); i += 2 {
+		err.Locations = append(err.Locations, location.SourceLocation{Line: locations[i], Column: locations[i+1]})
+	}
+	return err
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_AcceptsUniqueFields(t *testing.T) {
+	expectPassesRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      fragment uniqueFields on Dog {
+        name
+        nickname
+      }
+    `)
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_AcceptsIdenticalFields(t *testing.T) {
+	expectPassesRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      fragment mergeIdenticalFields on Dog {
+        name
+        name
+      }
+    `)
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_AcceptsIdenticalFieldsWithIdenticalArgs(t *testing.T) {
+	expectPassesRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      fragment mergeIdenticalFieldsWithIdenticalArgs on Dog {
+        pic(width: 1)
+        pic(width: 1)
+      }
+    `)
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_AcceptsDifferentArgsWithDifferentAliases(t *testing.T) {
+	expectPassesRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      fragment differentArgsWithDifferentAliases on Dog {
+        small: pic(width: 1)
+        large: pic(width: 2)
+      }
+    `)
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_AcceptsIdenticalFieldsThroughFragments(t *testing.T) {
+	expectPassesRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      {
+        dog {
+          petName: name
+          ...dogFields
+          ... on Dog { petName: name }
+        }
+      }
+      fragment dogFields on Dog {
+        petName: name
+      }
+    `)
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_AcceptsDifferentFieldsOnMutuallyExclusiveObjectTypes(t *testing.T) {
+	expectPassesRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      {
+        catOrDog {
+          ... on Dog { name: nickname }
+          ... on Cat { name }
+        }
+      }
+    `)
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_AcceptsDifferentSubfieldsOfFieldsOnMutuallyExclusiveObjectTypes(t *testing.T) {
+	expectPassesRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      {
+        catOrDog {
+          ... on Dog { owner { name: nickname } }
+          ... on Cat { owner { name } }
+        }
+      }
+    `)
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_RejectsSameAliasesWithDifferentFieldTargets(t *testing.T) {
+	expectFailsRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      fragment sameAliasesWithDifferentFieldTargets on Dog {
+        fido: name
+        fido: nickname
+      }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fields "fido" conflict because name and nickname are different fields. `+
+			`Use different aliases on the fields to fetch both if this was intentional.`, 3, 9, 4, 9),
+	})
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_RejectsConflictingArgs(t *testing.T) {
+	expectFailsRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      fragment conflictingArgs on Dog {
+        pic: pic(width: 1)
+        pic(width: 2)
+      }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fields "pic" conflict because they have differing arguments. `+
+			`Use different aliases on the fields to fetch both if this was intentional.`, 3, 9, 4, 9),
+	})
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_RejectsConflictsThroughFragmentSpreads(t *testing.T) {
+	expectFailsRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      {
+        dog {
+          ...dogName
+          name: nickname
+        }
+      }
+      fragment dogName on Dog {
+        name
+      }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fields "name" conflict because name and nickname are different fields. `+
+			`Use different aliases on the fields to fetch both if this was intentional.`, 9, 9, 5, 11),
+	})
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_RejectsDifferingReturnTypesOnMutuallyExclusiveObjectTypes(t *testing.T) {
+	expectFailsRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      {
+        catOrDog {
+          ... on Dog { volume: barkVolume }
+          ... on Cat { volume: meowVolume }
+        }
+      }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fields "volume" conflict because they return differing types Int and Int!. `+
+			`Use different aliases on the fields to fetch both if this was intentional.`, 4, 24, 5, 24),
+	})
+}
+
+func TestValidate_OverlappingFieldsCanBeMerged_RejectsConflictingSubfields(t *testing.T) {
+	expectFailsRule(t, validator.OverlappingFieldsCanBeMergedRule, `
+      {
+        dog {
+          x: name
+        }
+        dog {
+          x: nickname
+        }
+      }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fields "dog" conflict because subfields "x" conflict because name and nickname are different fields. `+
+			`Use different aliases on the fields to fetch both if this was intentional.`, 3, 9, 6, 9, 4, 11, 7, 11),
+	})
+}
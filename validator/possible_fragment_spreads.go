@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// PossibleFragmentSpreadsRule reports the fragments spread within a selection
+// set although their type condition can never apply to the selection set's
+// type, as no object type is possible for both types.
+func PossibleFragmentSpreadsRule(context *ValidationContext) []error {
+	errs := []error{}
+	visitSelectionSets(context, func(parentType types.GraphQLType, selectionSet *ast.SelectionSet) {
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.InlineFragment:
+				fragType := typeCondition(context.Schema(), selection.TypeCondition, nil)
+				if fragType == nil || parentType == nil || doTypesOverlap(context.Schema(), fragType, parentType) {
+					continue
+				}
+				errs = append(errs, newValidationError(fmt.Sprintf(
+					`Fragment cannot be spread here as objects of type "%v" can never be of type "%v".`,
+					parentType, fragType), []ast.Node{selection}))
+			case *ast.FragmentSpread:
+				if selection.Name == nil {
+					continue
+				}
+				fragment := context.Fragment(selection.Name.Value)
+				if fragment == nil {
+					continue
+				}
+				fragType := typeCondition(context.Schema(), fragment.TypeCondition, nil)
+				if fragType == nil || parentType == nil || doTypesOverlap(context.Schema(), fragType, parentType) {
+					continue
+				}
+				errs = append(errs, newValidationError(fmt.Sprintf(
+					`Fragment "%v" cannot be spread here as objects of type "%v" can never be of type "%v".`,
+					selection.Name.Value, parentType, fragType), []ast.Node{selection}))
+			}
+		}
+	})
+	return errs
+}
+
+// Returns whether an object type is possible for both types, types which are
+// not composite are considered to overlap.
+func doTypesOverlap(schema *types.GraphQLSchema, typeA, typeB types.GraphQLType) bool {
+	if typeA == typeB {
+		return true
+	}
+	possibleTypesA, ok := compositePossibleTypes(schema, typeA)
+	if !ok {
+		return true
+	}
+	possibleTypesB, ok := compositePossibleTypes(schema, typeB)
+	if !ok {
+		return true
+	}
+	for _, possibleTypeA := range possibleTypesA {
+		for _, possibleTypeB := range possibleTypesB {
+			if possibleTypeA == possibleTypeB {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func compositePossibleTypes(schema *types.GraphQLSchema, ttype types.GraphQLType) ([]*types.GraphQLObjectType, bool) {
+	switch ttype := ttype.(type) {
+	case *types.GraphQLObjectType:
+		return []*types.GraphQLObjectType{ttype}, true
+	case *types.GraphQLInterfaceType, *types.GraphQLUnionType:
+		return schema.GetPossibleTypes(ttype), true
+	}
+	return nil, false
+}
@@ -0,0 +1,80 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/validator"
+)
+
+func TestValidate_PossibleFragmentSpreads_AcceptsObjectIntoTheSameObject(t *testing.T) {
+	expectPassesRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment objectWithinObject on Dog { ...dogFragment }
+      fragment dogFragment on Dog { barkVolume }
+    `)
+}
+
+func TestValidate_PossibleFragmentSpreads_AcceptsObjectIntoImplementedInterface(t *testing.T) {
+	expectPassesRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment objectWithinInterface on Pet { ...dogFragment }
+      fragment dogFragment on Dog { barkVolume }
+    `)
+}
+
+func TestValidate_PossibleFragmentSpreads_AcceptsObjectIntoContainingUnion(t *testing.T) {
+	expectPassesRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment objectWithinUnion on CatOrDog { ...dogFragment }
+      fragment dogFragment on Dog { barkVolume }
+    `)
+}
+
+func TestValidate_PossibleFragmentSpreads_AcceptsInterfaceIntoImplementingObject(t *testing.T) {
+	expectPassesRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment interfaceWithinObject on Dog { ...petFragment }
+      fragment petFragment on Pet { name }
+    `)
+}
+
+func TestValidate_PossibleFragmentSpreads_AcceptsUnionIntoOverlappingUnion(t *testing.T) {
+	expectPassesRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment unionWithinUnion on DogOrHuman { ...catOrDogFragment }
+      fragment catOrDogFragment on CatOrDog { __typename }
+    `)
+}
+
+func TestValidate_PossibleFragmentSpreads_RejectsObjectIntoDifferentObject(t *testing.T) {
+	expectFailsRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment invalidObjectWithinObject on Cat { ...dogFragment }
+      fragment dogFragment on Dog { barkVolume }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fragment "dogFragment" cannot be spread here as objects of type "Cat" can never be of type "Dog".`, 2, 51),
+	})
+}
+
+func TestValidate_PossibleFragmentSpreads_RejectsInlineObjectIntoDifferentObject(t *testing.T) {
+	expectFailsRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment invalidObjectWithinObjectAnon on Cat {
+        ... on Dog { barkVolume }
+      }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fragment cannot be spread here as objects of type "Cat" can never be of type "Dog".`, 3, 9),
+	})
+}
+
+func TestValidate_PossibleFragmentSpreads_RejectsInterfaceIntoNonImplementingObject(t *testing.T) {
+	expectFailsRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment invalidInterfaceWithinObject on Human { ...petFragment }
+      fragment petFragment on Pet { name }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fragment "petFragment" cannot be spread here as objects of type "Human" can never be of type "Pet".`, 2, 56),
+	})
+}
+
+func TestValidate_PossibleFragmentSpreads_RejectsUnionIntoNotContainedObject(t *testing.T) {
+	expectFailsRule(t, validator.PossibleFragmentSpreadsRule, `
+      fragment invalidUnionWithinObject on Human { ...catOrDogFragment }
+      fragment catOrDogFragment on CatOrDog { __typename }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Fragment "catOrDogFragment" cannot be spread here as objects of type "Human" can never be of type "CatOrDog".`, 2, 52),
+	})
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/types"
+)
+
+// Calls fn with every selection set of the document and the type it selects
+// fields of, nil if the type is unknown. Fragment definitions are visited
+// once, where they are defined rather than where they are spread.
+func visitSelectionSets(context *ValidationContext, fn func(parentType types.GraphQLType, selectionSet *ast.SelectionSet)) {
+	var visit func(parentType types.GraphQLType, selectionSet *ast.SelectionSet)
+	visit = func(parentType types.GraphQLType, selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		fn(parentType, selectionSet)
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				var fieldType types.GraphQLType
+				if fieldDef := getFieldDef(context.Schema(), parentType, selection); fieldDef != nil {
+					fieldType = types.GetNamedType(fieldDef.Type)
+				}
+				visit(fieldType, selection.SelectionSet)
+			case *ast.InlineFragment:
+				visit(typeCondition(context.Schema(), selection.TypeCondition, parentType), selection.SelectionSet)
+			}
+		}
+	}
+	for _, definition := range context.Document().Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			visit(operationRootType(context.Schema(), definition), definition.SelectionSet)
+		case *ast.FragmentDefinition:
+			visit(typeCondition(context.Schema(), definition.TypeCondition, nil), definition.SelectionSet)
+		}
+	}
+}
+
+// Returns the root type of the operation, nil if the schema has none.
+func operationRootType(schema *types.GraphQLSchema, operation *ast.OperationDefinition) types.GraphQLType {
+	var rootType *types.GraphQLObjectType
+	switch operation.Operation {
+	case "query":
+		rootType = schema.GetQueryType()
+	case "mutation":
+		rootType = schema.GetMutationType()
+	case "subscription":
+		rootType = schema.GetSubscriptionType()
+	}
+	if rootType == nil {
+		return nil
+	}
+	return rootType
+}
+
+// Returns the type of the type condition, or parentType if there is no type
+// condition.
+func typeCondition(schema *types.GraphQLSchema, condition *ast.NamedType, parentType types.GraphQLType) types.GraphQLType {
+	if condition == nil || condition.Name == nil {
+		return parentType
+	}
+	return schema.GetType(condition.Name.Value)
+}
+
+// Returns the definition of the field on the parent type, including the
+// introspection meta-fields, nil if there is none.
+func getFieldDef(schema *types.GraphQLSchema, parentType types.GraphQLType, field *ast.Field) *types.GraphQLFieldDefinition {
+	if parentType == nil || field.Name == nil {
+		return nil
+	}
+	fieldName := field.Name.Value
+	if queryType := schema.GetQueryType(); queryType != nil && parentType == types.GraphQLType(queryType) {
+		switch fieldName {
+		case types.SchemaMetaFieldDef.Name:
+			return types.SchemaMetaFieldDef
+		case types.TypeMetaFieldDef.Name:
+			return types.TypeMetaFieldDef
+		}
+	}
+	switch parentType := parentType.(type) {
+	case *types.GraphQLObjectType:
+		return parentType.GetField(fieldName)
+	case *types.GraphQLInterfaceType:
+		if fieldName == types.TypeNameMetaFieldDef.Name {
+			return types.TypeNameMetaFieldDef
+		}
+		return parentType.GetFields()[fieldName]
+	case *types.GraphQLUnionType:
+		if fieldName == types.TypeNameMetaFieldDef.Name {
+			return types.TypeNameMetaFieldDef
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/chris-ramon/graphql-go/language/ast"
+)
+
+// SingleFieldSubscriptionsRule reports the subscription operations selecting
+// more than one top level field, as each event of a subscription is the value
+// of its single root field. The fields are counted by response name once the
+// fragments are expanded.
+func SingleFieldSubscriptionsRule(context *ValidationContext) []error {
+	errs := []error{}
+	for _, definition := range context.Document().Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok || operation.Operation != "subscription" || operation.SelectionSet == nil {
+			continue
+		}
+		fieldMap := collectFieldsAndDefs(context, operationRootType(context.Schema(), operation), operation.SelectionSet, fieldsAndDefsMap{}, map[string]bool{})
+		if len(fieldMap.responseNames) <= 1 {
+			continue
+		}
+		nodes := []ast.Node{}
+		for _, responseName := range fieldMap.responseNames[1:] {
+			for _, field := range fieldMap.fields[responseName] {
+				nodes = append(nodes, field.field)
+			}
+		}
+		message := "Anonymous Subscription must select only one top level field."
+		if operation.Name != nil && operation.Name.Value != "" {
+			message = fmt.Sprintf(`Subscription "%v" must select only one top level field.`, operation.Name.Value)
+		}
+		errs = append(errs, newValidationError(message, nodes))
+	}
+	return errs
+}
@@ -0,0 +1,41 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/errors"
+	"github.com/chris-ramon/graphql-go/validator"
+)
+
+func TestValidate_SingleFieldSubscriptions_RejectsSubscriptionsWithMoreThanOneRootField(t *testing.T) {
+	expectPassesRule(t, validator.SingleFieldSubscriptionsRule, `
+      subscription NewDogs { dog { name } }
+    `)
+	expectFailsRule(t, validator.SingleFieldSubscriptionsRule, `
+      subscription NewPets {
+        dog { name }
+        cat { name }
+      }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Subscription "NewPets" must select only one top level field.`, 4, 9),
+	})
+}
+
+func TestValidate_SingleFieldSubscriptions_CountsTheFieldsOfTheFragments(t *testing.T) {
+	expectPassesRule(t, validator.SingleFieldSubscriptionsRule, `
+      subscription NewDogs {
+        ...DogFields
+        dog { name }
+      }
+      fragment DogFields on QueryRoot { dog { barkVolume } }
+    `)
+	expectFailsRule(t, validator.SingleFieldSubscriptionsRule, `
+      subscription NewPets {
+        ...DogFields
+        ... on QueryRoot { cat { name } }
+      }
+      fragment DogFields on QueryRoot { dog { name } }
+    `, []graphqlerrors.GraphQLFormattedError{
+		ruleError(`Subscription "NewPets" must select only one top level field.`, 4, 28),
+	})
+}
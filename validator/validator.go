@@ -11,7 +11,75 @@ type ValidationResult struct {
 	Errors  []graphqlerrors.GraphQLFormattedError
 }
 
-func ValidateDocument(schema types.GraphQLSchema, ast *ast.Document) (vr ValidationResult) {
+// ValidationRuleFn reports the errors of a document for a validation rule.
+type ValidationRuleFn func(context *ValidationContext) []error
+
+// SpecifiedRules are the validation rules run by ValidateDocument.
+var SpecifiedRules = []ValidationRuleFn{
+	LoneAnonymousOperationRule,
+	SingleFieldSubscriptionsRule,
+	OverlappingFieldsCanBeMergedRule,
+	PossibleFragmentSpreadsRule,
+}
+
+// ValidateDocument checks that the document may be executed against the
+// schema, reporting the errors of every rule of SpecifiedRules.
+func ValidateDocument(schema types.GraphQLSchema, astDoc *ast.Document) (vr ValidationResult) {
+	return ValidateDocumentWithRules(schema, astDoc, SpecifiedRules)
+}
+
+// ValidateDocumentWithRules checks the document against the given rules.
+func ValidateDocumentWithRules(schema types.GraphQLSchema, astDoc *ast.Document, rules []ValidationRuleFn) (vr ValidationResult) {
 	vr.IsValid = true
+	if astDoc == nil {
+		return vr
+	}
+	context := newValidationContext(schema, astDoc)
+	for _, rule := range rules {
+		for _, err := range rule(context) {
+			vr.Errors = append(vr.Errors, graphqlerrors.FormatError(err))
+		}
+	}
+	vr.IsValid = len(vr.Errors) == 0
 	return vr
 }
+
+// ValidationContext gives the validation rules access to the schema and to
+// the document being validated.
+type ValidationContext struct {
+	schema    *types.GraphQLSchema
+	astDoc    *ast.Document
+	fragments map[string]*ast.FragmentDefinition
+}
+
+func newValidationContext(schema types.GraphQLSchema, astDoc *ast.Document) *ValidationContext {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range astDoc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	return &ValidationContext{
+		schema:    &schema,
+		astDoc:    astDoc,
+		fragments: fragments,
+	}
+}
+
+func (context *ValidationContext) Schema() *types.GraphQLSchema {
+	return context.schema
+}
+
+func (context *ValidationContext) Document() *ast.Document {
+	return context.astDoc
+}
+
+// Fragment returns the definition of the named fragment, nil if it is not
+// defined.
+func (context *ValidationContext) Fragment(name string) *ast.FragmentDefinition {
+	return context.fragments[name]
+}
+
+func newValidationError(message string, nodes []ast.Node) error {
+	return graphqlerrors.NewGraphQLError(message, nodes, "", nil, []int{})
+}
@@ -0,0 +1,132 @@
+package gql
+
+import (
+	"github.com/chris-ramon/graphql-go/language/ast"
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/language/visitor"
+)
+
+// VariableUsage is a variable of an operation, see VariableValues.
+type VariableUsage struct {
+	Name string
+	// The type the operation declares the variable of, nil if the operation
+	// uses the variable without declaring it.
+	Type ast.Type
+	// The locations of the usages of the variable, within the operation and
+	// the fragments it spreads, empty if the variable is declared but not
+	// used.
+	Locations []location.SourceLocation
+}
+
+// VariableValues returns the variables of an operation of a document: those
+// it declares, in the order of their declarations, followed by those it uses
+// without declaring them, e.g. for a gateway to reject undeclared variables
+// before executing the operation. The usages within the fragments spread by
+// the operation, directly or from other fragments, are included. The
+// operation name may be "" when the document has a single operation, nil is
+// returned when there is no such operation.
+func VariableValues(doc *ast.Document, operationName string) []VariableUsage {
+	operation := findOperation(doc, operationName)
+	if operation == nil {
+		return nil
+	}
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	usages := []VariableUsage{}
+	indices := map[string]int{}
+	for _, definition := range operation.VariableDefinitions {
+		if definition.Variable == nil || definition.Variable.Name == nil {
+			continue
+		}
+		name := definition.Variable.Name.Value
+		indices[name] = len(usages)
+		usages = append(usages, VariableUsage{
+			Name:      name,
+			Type:      definition.Type,
+			Locations: []location.SourceLocation{},
+		})
+	}
+	collector := &variablesCollector{visited: map[string]bool{}}
+	visitor.VisitNode(operation, collector)
+	// fragments spread by fragments are appended while they are visited
+	for i := 0; i < len(collector.spreads); i++ {
+		if fragment, ok := fragments[collector.spreads[i]]; ok {
+			visitor.VisitNode(fragment, collector)
+		}
+	}
+	for _, variable := range collector.variables {
+		name := variable.Name.Value
+		index, ok := indices[name]
+		if !ok {
+			index = len(usages)
+			indices[name] = index
+			usages = append(usages, VariableUsage{
+				Name:      name,
+				Locations: []location.SourceLocation{},
+			})
+		}
+		if variable.Loc != nil && variable.Loc.Source != nil {
+			usages[index].Locations = append(usages[index].Locations,
+				location.GetLocation(variable.Loc.Source.Body, variable.Loc.Start))
+		}
+	}
+	return usages
+}
+
+// Returns the operation of the given name, or the only operation of the
+// document when the name is "".
+func findOperation(doc *ast.Document, operationName string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" {
+			if found != nil {
+				return nil
+			}
+			found = operation
+			continue
+		}
+		if operation.Name != nil && operation.Name.Value == operationName {
+			return operation
+		}
+	}
+	return found
+}
+
+// Collects the variables used within the visited nodes, and the names of
+// the fragments they spread, each fragment once.
+type variablesCollector struct {
+	variables []*ast.Variable
+	spreads   []string
+	visited   map[string]bool
+}
+
+func (c *variablesCollector) Enter(node ast.Node) (string, ast.Node) {
+	switch node := node.(type) {
+	case *ast.VariableDefinition:
+		// declarations are not usages
+		return visitor.ActionSkip, nil
+	case *ast.Variable:
+		if node.Name != nil {
+			c.variables = append(c.variables, node)
+		}
+	case *ast.FragmentSpread:
+		if node.Name != nil && !c.visited[node.Name.Value] {
+			c.visited[node.Name.Value] = true
+			c.spreads = append(c.spreads, node.Name.Value)
+		}
+	}
+	return visitor.ActionNoChange, nil
+}
+
+func (c *variablesCollector) Leave(node ast.Node) (string, ast.Node) {
+	return visitor.ActionNoChange, nil
+}
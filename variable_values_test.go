@@ -0,0 +1,71 @@
+package gql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chris-ramon/graphql-go/language/location"
+	"github.com/chris-ramon/graphql-go/language/printer"
+	"github.com/chris-ramon/graphql-go/testutil"
+)
+
+func TestVariableValues_CollectsTheVariablesUsedWithinSpreadFragments(t *testing.T) {
+	doc := testutil.Parse(t, `query Feed($first: Int, $unused: String) {
+  feed(first: $first) {
+    ...ArticleFields
+  }
+}
+
+fragment ArticleFields on Article {
+  title(format: $format)
+  ...AuthorFields
+}
+
+fragment AuthorFields on Article {
+  author(first: $first) { name }
+}
+`)
+	usages := VariableValues(doc, "Feed")
+	names := []string{}
+	types := []string{}
+	for _, usage := range usages {
+		names = append(names, usage.Name)
+		ttype := ""
+		if usage.Type != nil {
+			ttype = printer.Print(usage.Type).(string)
+		}
+		types = append(types, ttype)
+	}
+	if expected := []string{"first", "unused", "format"}; !reflect.DeepEqual(expected, names) {
+		t.Fatalf("Unexpected variables, Diff: %v", testutil.Diff(expected, names))
+	}
+	if expected := []string{"Int", "String", ""}; !reflect.DeepEqual(expected, types) {
+		t.Fatalf("Unexpected variable types, Diff: %v", testutil.Diff(expected, types))
+	}
+	locations := [][]location.SourceLocation{
+		[]location.SourceLocation{{Line: 2, Column: 15}, {Line: 13, Column: 17}},
+		[]location.SourceLocation{},
+		[]location.SourceLocation{{Line: 8, Column: 17}},
+	}
+	for i, usage := range usages {
+		if !reflect.DeepEqual(locations[i], usage.Locations) {
+			t.Fatalf("Unexpected locations of $%v, Diff: %v", usage.Name, testutil.Diff(locations[i], usage.Locations))
+		}
+	}
+}
+
+func TestVariableValues_ReturnsNilForAnUnknownOperation(t *testing.T) {
+	doc := testutil.Parse(t, `
+      query One { a(x: $x) }
+      query Two { b }
+    `)
+	for _, operationName := range []string{"", "Three"} {
+		if usages := VariableValues(doc, operationName); usages != nil {
+			t.Fatalf("Expected no variables for %q, got: %v", operationName, usages)
+		}
+	}
+	expected := []VariableUsage{}
+	if usages := VariableValues(doc, "Two"); !reflect.DeepEqual(expected, usages) {
+		t.Fatalf("Unexpected variables, Diff: %v", testutil.Diff(expected, usages))
+	}
+}